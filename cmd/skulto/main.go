@@ -47,6 +47,6 @@ func main() {
 	defer telemetryClient.Close()
 
 	if err := cli.Execute(ctx, telemetryClient); err != nil {
-		os.Exit(1)
+		os.Exit(cli.ExitCode(err))
 	}
 }
@@ -0,0 +1,486 @@
+// Package bundle packs sources into a single file that can be carried to a
+// machine without network access and registered there with the same skills,
+// tags, auxiliary files and scan results as on the machine that made it.
+//
+// A bundle is a gzipped tar archive. Its first entry is manifest.json; the
+// rest is each source's clone under repos/<owner>/<repo>/, including the
+// .git directory when the clone has one, so installs, lock files and later
+// pulls see an ordinary clone.
+package bundle
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+
+	"github.com/asteroid-belt/skulto/internal/db"
+	"github.com/asteroid-belt/skulto/internal/models"
+)
+
+// FormatVersion is the current bundle format version.
+const FormatVersion = 1
+
+const (
+	manifestName = "manifest.json"
+	reposDir     = "repos"
+)
+
+// ErrLocalSource is returned when asked to bundle a local directory source,
+// which is read in place and has no clone to carry.
+var ErrLocalSource = errors.New("local directory sources can't be bundled")
+
+// Manifest describes the contents of a bundle.
+type Manifest struct {
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+	Sources   []Entry   `json:"sources"`
+}
+
+// Entry is one bundled source with everything the database knows about it.
+type Entry struct {
+	Source models.Source  `json:"source"`
+	Skills []models.Skill `json:"skills"`           // With tags, auxiliary files and scan results
+	Commit string         `json:"commit,omitempty"` // HEAD of the bundled clone; empty for a snapshot
+}
+
+// Create writes a bundle of sources to w, reading their clones from
+// repoDir. Every source must have been pulled; local directory sources are
+// rejected with ErrLocalSource.
+func Create(w io.Writer, database *db.DB, repoDir string, sources []models.Source) (*Manifest, error) {
+	manifest := &Manifest{Version: FormatVersion, CreatedAt: time.Now().UTC()}
+	clones := make([]string, 0, len(sources))
+
+	for _, source := range sources {
+		if source.LocalDir() != "" {
+			return nil, fmt.Errorf("%s: %w", source.ID, ErrLocalSource)
+		}
+		clone := filepath.Join(repoDir, source.Owner, source.Repo)
+		if _, err := os.Stat(clone); err != nil {
+			return nil, fmt.Errorf("%s is not cloned; run 'skulto pull' first", source.ID)
+		}
+
+		skills, err := database.GetSkillsBySourceID(source.ID)
+		if err != nil {
+			return nil, fmt.Errorf("get skills for %s: %w", source.ID, err)
+		}
+		for i := range skills {
+			files, err := database.GetAuxiliaryFilesForSkill(skills[i].ID)
+			if err != nil {
+				return nil, fmt.Errorf("get auxiliary files for %s: %w", skills[i].Slug, err)
+			}
+			skills[i].AuxiliaryFiles = files
+		}
+
+		manifest.Sources = append(manifest.Sources, Entry{Source: source, Skills: skills, Commit: headCommit(clone)})
+		clones = append(clones, clone)
+	}
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal manifest: %w", err)
+	}
+	if err := tw.WriteHeader(&tar.Header{
+		Name:    manifestName,
+		Mode:    0644,
+		Size:    int64(len(data)),
+		ModTime: manifest.CreatedAt,
+	}); err != nil {
+		return nil, fmt.Errorf("write manifest: %w", err)
+	}
+	if _, err := tw.Write(data); err != nil {
+		return nil, fmt.Errorf("write manifest: %w", err)
+	}
+
+	for i, clone := range clones {
+		s := manifest.Sources[i].Source
+		if err := addTree(tw, clone, path.Join(reposDir, s.Owner, s.Repo)); err != nil {
+			return nil, fmt.Errorf("pack %s: %w", s.ID, err)
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, fmt.Errorf("finish bundle: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return nil, fmt.Errorf("finish bundle: %w", err)
+	}
+	return manifest, nil
+}
+
+// headCommit returns the commit a clone is checked out at, or "" if the
+// directory isn't a git repository.
+func headCommit(clone string) string {
+	r, err := git.PlainOpen(clone)
+	if err != nil {
+		return ""
+	}
+	head, err := r.Head()
+	if err != nil {
+		return ""
+	}
+	return head.Hash().String()
+}
+
+// addTree writes the directory tree at root into tw under prefix.
+func addTree(tw *tar.Writer, root, prefix string) error {
+	return filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		name := prefix
+		if rel != "." {
+			name = path.Join(prefix, filepath.ToSlash(rel))
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		var link string
+		if info.Mode()&fs.ModeSymlink != 0 {
+			if link, err = os.Readlink(p); err != nil {
+				return err
+			}
+		} else if !info.IsDir() && !info.Mode().IsRegular() {
+			return nil // Sockets, devices and the like have no place in a clone
+		}
+
+		hdr, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			return err
+		}
+		hdr.Name = name
+		if info.IsDir() {
+			hdr.Name += "/"
+		}
+		// Ownership means nothing on the other machine
+		hdr.Uid, hdr.Gid, hdr.Uname, hdr.Gname = 0, 0, "", ""
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		f, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = f.Close() }()
+		_, err = io.Copy(tw, f)
+		return err
+	})
+}
+
+// ImportResult is what Import registered.
+type ImportResult struct {
+	Manifest *Manifest
+	Skills   int // Skills registered across all sources
+}
+
+// Import reads a bundle from r, puts each source's clone under repoDir
+// (replacing any clone already there) and records the sources, skills, tags,
+// auxiliary files and scan results in the database. Clones are unpacked to a
+// staging directory first, so a damaged bundle leaves existing clones alone.
+func Import(r io.Reader, database *db.DB, repoDir string) (*ImportResult, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("not a skulto bundle: %w", err)
+	}
+	defer func() { _ = gz.Close() }()
+	tr := tar.NewReader(gz)
+
+	manifest, err := readManifest(tr)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(repoDir, 0755); err != nil {
+		return nil, fmt.Errorf("create %s: %w", repoDir, err)
+	}
+	staging, err := os.MkdirTemp(repoDir, ".bundle-")
+	if err != nil {
+		return nil, fmt.Errorf("create staging directory: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(staging) }()
+
+	if err := extract(tr, staging); err != nil {
+		return nil, err
+	}
+
+	result := &ImportResult{Manifest: manifest}
+	for i := range manifest.Sources {
+		entry := &manifest.Sources[i]
+		if err := installClone(staging, repoDir, &entry.Source); err != nil {
+			return result, err
+		}
+		n, err := register(database, entry)
+		if err != nil {
+			return result, err
+		}
+		result.Skills += n
+	}
+	return result, nil
+}
+
+// readManifest reads and checks the manifest, which must be the first entry.
+func readManifest(tr *tar.Reader) (*Manifest, error) {
+	hdr, err := tr.Next()
+	if err != nil {
+		return nil, fmt.Errorf("not a skulto bundle: %w", err)
+	}
+	if hdr.Name != manifestName {
+		return nil, fmt.Errorf("not a skulto bundle: first entry is %q, want %s", hdr.Name, manifestName)
+	}
+	var manifest Manifest
+	if err := json.NewDecoder(tr).Decode(&manifest); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", manifestName, err)
+	}
+	if manifest.Version > FormatVersion {
+		return nil, fmt.Errorf("bundle version %d is newer than supported version %d; upgrade skulto", manifest.Version, FormatVersion)
+	}
+	for _, e := range manifest.Sources {
+		if e.Source.ID == "" || !localName(e.Source.Owner) || !localName(e.Source.Repo) {
+			return nil, fmt.Errorf("bundle has an invalid source %q", e.Source.ID)
+		}
+		if e.Source.LocalDir() != "" {
+			return nil, fmt.Errorf("%s: %w", e.Source.ID, ErrLocalSource)
+		}
+	}
+	return &manifest, nil
+}
+
+// localName reports whether name is a relative slash path that stays where
+// it is put, so it can be joined to a directory safely.
+func localName(name string) bool {
+	return name != "" && filepath.IsLocal(filepath.FromSlash(name))
+}
+
+// maxLinkHops bounds the symlinks followed while checking where one leads.
+const maxLinkHops = 40
+
+// extract unpacks the remaining entries of tr into dir. Everything is
+// written through an os.Root, so no entry can land outside dir whatever
+// links came before it.
+func extract(tr *tar.Reader, dir string) error {
+	root, err := os.OpenRoot(dir)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = root.Close() }()
+
+	var links []string
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("read bundle: %w", err)
+		}
+		name := strings.TrimSuffix(hdr.Name, "/")
+		if !localName(name) || !strings.HasPrefix(name, reposDir+"/") {
+			return fmt.Errorf("bundle entry %q is outside %s/", hdr.Name, reposDir)
+		}
+		// Create never writes below a symlink
+		if underLink(root, name) {
+			return fmt.Errorf("bundle entry %q is inside a symlinked directory", hdr.Name)
+		}
+		target := filepath.FromSlash(name)
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := root.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := writeFile(root, target, tr, fs.FileMode(hdr.Mode).Perm()); err != nil {
+				return fmt.Errorf("extract %s: %w", hdr.Name, err)
+			}
+		case tar.TypeSymlink:
+			if path.IsAbs(hdr.Linkname) {
+				return fmt.Errorf("bundle entry %q links outside the bundle", hdr.Name)
+			}
+			if err := root.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return err
+			}
+			if err := root.Symlink(hdr.Linkname, target); err != nil {
+				return fmt.Errorf("extract %s: %w", hdr.Name, err)
+			}
+			links = append(links, name)
+		default:
+			// Nothing else is ever written by Create
+		}
+	}
+
+	// Links may only point inside the bundle's clones. They are checked once
+	// all are in place, since a later link can change where an earlier one
+	// leads.
+	for _, name := range links {
+		if !linksInside(root, name) {
+			return fmt.Errorf("bundle entry %q links outside the bundle", name)
+		}
+	}
+	return nil
+}
+
+// underLink reports whether any parent directory of the slash path name
+// is a symlink.
+func underLink(root *os.Root, name string) bool {
+	parts := strings.Split(name, "/")
+	for i := 1; i < len(parts); i++ {
+		fi, err := root.Lstat(filepath.Join(parts[:i]...))
+		if err != nil {
+			return false
+		}
+		if fi.Mode()&fs.ModeSymlink != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// linksInside reports whether the symlink at the slash path name resolves
+// to a path in reposDir, following links along the way as the operating
+// system would.
+func linksInside(root *os.Root, name string) bool {
+	var resolved []string
+	pending := strings.Split(name, "/")
+	for hops := 0; len(pending) > 0; {
+		part := pending[0]
+		pending = pending[1:]
+		switch part {
+		case "", ".":
+			continue
+		case "..":
+			if len(resolved) == 0 {
+				return false
+			}
+			resolved = resolved[:len(resolved)-1]
+			continue
+		}
+		resolved = append(resolved, part)
+		p := filepath.Join(resolved...)
+		fi, err := root.Lstat(p)
+		if err != nil || fi.Mode()&fs.ModeSymlink == 0 {
+			continue
+		}
+		if hops++; hops > maxLinkHops {
+			return false
+		}
+		link, err := root.Readlink(p)
+		if err != nil || path.IsAbs(link) || filepath.IsAbs(link) {
+			return false
+		}
+		resolved = resolved[:len(resolved)-1]
+		pending = append(strings.Split(filepath.ToSlash(link), "/"), pending...)
+	}
+	return len(resolved) > 0 && resolved[0] == reposDir
+}
+
+// writeFile copies r to a new file at target in root.
+func writeFile(root *os.Root, target string, r io.Reader, perm fs.FileMode) error {
+	if err := root.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+	f, err := root.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, perm|0600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// installClone moves a source's staged clone into place under repoDir.
+func installClone(staging, repoDir string, source *models.Source) error {
+	staged := filepath.Join(staging, reposDir, source.Owner, source.Repo)
+	if _, err := os.Stat(staged); err != nil {
+		return fmt.Errorf("bundle has no files for %s", source.ID)
+	}
+	dest := filepath.Join(repoDir, source.Owner, source.Repo)
+	if err := os.RemoveAll(dest); err != nil {
+		return fmt.Errorf("replace clone of %s: %w", source.ID, err)
+	}
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		return fmt.Errorf("create %s: %w", filepath.Dir(dest), err)
+	}
+	if err := os.Rename(staged, dest); err != nil {
+		return fmt.Errorf("move clone of %s into place: %w", source.ID, err)
+	}
+	return nil
+}
+
+// register records a bundled source and its skills, returning the number of
+// skills registered. Scan results come along as they were on the machine
+// that made the bundle, so quarantined skills stay quarantined.
+func register(database *db.DB, entry *Entry) (int, error) {
+	source := entry.Source
+	source.Skills = nil
+	if err := database.UpsertSource(&source); err != nil {
+		return 0, fmt.Errorf("register source %s: %w", source.ID, err)
+	}
+
+	for i := range entry.Skills {
+		skill := entry.Skills[i]
+		tags, files := skill.Tags, skill.AuxiliaryFiles
+		skill.Tags, skill.AuxiliaryFiles = nil, nil
+		skill.SourceID = &source.ID
+
+		if err := database.UpsertSkillWithTags(&skill, tags); err != nil {
+			return i, fmt.Errorf("register skill %s: %w", skill.Slug, err)
+		}
+		// The upsert keeps an existing skill's scan results; take the bundle's
+		if err := database.UpdateSkillSecurity(&skill); err != nil {
+			return i, fmt.Errorf("register scan results for %s: %w", skill.Slug, err)
+		}
+		for j := range files {
+			if err := database.UpsertAuxiliaryFile(&files[j]); err != nil {
+				return i, fmt.Errorf("register auxiliary file %s: %w", files[j].FilePath, err)
+			}
+		}
+	}
+
+	// Skills the bundled clone no longer has would fail to install
+	bundled := make(map[string]bool, len(entry.Skills))
+	for _, skill := range entry.Skills {
+		bundled[skill.ID] = true
+	}
+	existing, err := database.GetSkillsBySourceID(source.ID)
+	if err != nil {
+		return len(entry.Skills), fmt.Errorf("get skills for %s: %w", source.ID, err)
+	}
+	for _, skill := range existing {
+		if bundled[skill.ID] {
+			continue
+		}
+		if err := database.HardDeleteSkill(skill.ID); err != nil {
+			return len(entry.Skills), fmt.Errorf("remove stale skill %s: %w", skill.Slug, err)
+		}
+	}
+
+	if err := database.UpdateSourceSkillCount(source.ID); err != nil {
+		return len(entry.Skills), fmt.Errorf("update skill count for %s: %w", source.ID, err)
+	}
+	return len(entry.Skills), nil
+}
@@ -0,0 +1,206 @@
+package bundle
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/asteroid-belt/skulto/internal/db"
+	"github.com/asteroid-belt/skulto/internal/models"
+)
+
+func setupTestDB(t *testing.T) *db.DB {
+	t.Helper()
+	database, err := db.New(db.Config{Path: filepath.Join(t.TempDir(), "test.db")})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = database.Close() })
+	return database
+}
+
+// makeClone creates a git repository with one skill at repoDir/owner/repo
+// and returns its HEAD commit.
+func makeClone(t *testing.T, repoDir, owner, repo string) string {
+	t.Helper()
+	dir := filepath.Join(repoDir, owner, repo)
+	r, err := git.PlainInit(dir, false)
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "review", "scripts"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "review", "SKILL.md"), []byte("---\nname: review\n---\n# Review\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "review", "scripts", "run.sh"), []byte("#!/bin/sh\necho hi\n"), 0755))
+
+	w, err := r.Worktree()
+	require.NoError(t, err)
+	_, err = w.Add(".")
+	require.NoError(t, err)
+	hash, err := w.Commit("add review", &git.CommitOptions{
+		Author: &object.Signature{Name: "test", Email: "test@example.com", When: time.Now()},
+	})
+	require.NoError(t, err)
+	return hash.String()
+}
+
+func TestCreateAndImport(t *testing.T) {
+	// The connected machine: a pulled source with a scanned, quarantined skill
+	src := setupTestDB(t)
+	srcRepos := t.TempDir()
+	commit := makeClone(t, srcRepos, "gitlab.com/team", "skills")
+
+	source := models.Source{ID: "gitlab.com/team/skills", Owner: "gitlab.com/team", Repo: "skills", FullName: "gitlab.com/team/skills", Host: "gitlab.com", Ref: "v1", SkillPath: "review"}
+	require.NoError(t, src.UpsertSource(&source))
+	scannedAt := time.Now().UTC().Truncate(time.Second)
+	skill := models.Skill{
+		ID: "s1", Slug: "review", Title: "Code Review", Description: "Reviews pull requests",
+		Content: "# Review", SourceID: &source.ID, FilePath: "review/SKILL.md",
+	}
+	require.NoError(t, src.UpsertSkillWithTags(&skill, []models.Tag{{ID: "go", Name: "Go", Slug: "go"}}))
+	skill.SecurityStatus = models.SecurityStatusQuarantined
+	skill.ThreatLevel = models.ThreatLevelHigh
+	skill.ThreatSummary = "runs curl | sh"
+	skill.ScannedAt = &scannedAt
+	require.NoError(t, src.UpdateSkillSecurity(&skill))
+	aux := models.AuxiliaryFile{SkillID: "s1", DirType: models.AuxDirScripts, FilePath: "scripts/run.sh", FileName: "run.sh"}
+	require.NoError(t, src.UpsertAuxiliaryFile(&aux))
+
+	var buf bytes.Buffer
+	manifest, err := Create(&buf, src, srcRepos, []models.Source{source})
+	require.NoError(t, err)
+	require.Len(t, manifest.Sources, 1)
+	assert.Equal(t, commit, manifest.Sources[0].Commit)
+
+	// The isolated machine: empty database and repository directory, with a
+	// stale skill from an older import
+	dst := setupTestDB(t)
+	dstRepos := filepath.Join(t.TempDir(), "repositories")
+	require.NoError(t, dst.UpsertSource(&source))
+	stale := models.Skill{ID: "old", Slug: "old", Title: "Old", SourceID: &source.ID}
+	require.NoError(t, dst.CreateSkill(&stale))
+
+	result, err := Import(&buf, dst, dstRepos)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Skills)
+
+	// Clone is in place with its history and file modes
+	clone := filepath.Join(dstRepos, "gitlab.com/team", "skills")
+	assert.Equal(t, commit, headCommit(clone))
+	info, err := os.Stat(filepath.Join(clone, "review", "scripts", "run.sh"))
+	require.NoError(t, err)
+	assert.NotZero(t, info.Mode()&0100, "executable bit kept")
+	entries, err := os.ReadDir(dstRepos)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "staging directory removed")
+
+	// Source, skill, tags, scan results and auxiliary files are registered
+	got, err := dst.GetSource(source.ID)
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, "v1", got.Ref)
+	assert.Equal(t, "review", got.SkillPath)
+	assert.Equal(t, 1, got.SkillCount)
+
+	gotSkill, err := dst.GetSkill("s1")
+	require.NoError(t, err)
+	require.NotNil(t, gotSkill)
+	assert.Equal(t, models.SecurityStatusQuarantined, gotSkill.SecurityStatus)
+	assert.Equal(t, models.ThreatLevelHigh, gotSkill.ThreatLevel)
+	assert.Equal(t, "runs curl | sh", gotSkill.ThreatSummary)
+	require.Len(t, gotSkill.Tags, 1)
+	assert.Equal(t, "go", gotSkill.Tags[0].Slug)
+	files, err := dst.GetAuxiliaryFilesForSkill("s1")
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.Equal(t, "scripts/run.sh", files[0].FilePath)
+
+	gone, err := dst.GetSkill("old")
+	require.NoError(t, err)
+	assert.Nil(t, gone)
+
+	results, err := dst.Search("pull requests", 10)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "review", results[0].Slug)
+}
+
+func TestCreateRejectsLocalAndUnclonedSources(t *testing.T) {
+	database := setupTestDB(t)
+	repos := t.TempDir()
+
+	local := models.Source{ID: "local/0123abcd/skills", Owner: "local/0123abcd", Repo: "skills", Host: "local", CloneURL: "file:///tmp/skills"}
+	_, err := Create(&bytes.Buffer{}, database, repos, []models.Source{local})
+	assert.True(t, errors.Is(err, ErrLocalSource), "got %v", err)
+
+	missing := models.Source{ID: "owner/repo", Owner: "owner", Repo: "repo"}
+	_, err = Create(&bytes.Buffer{}, database, repos, []models.Source{missing})
+	assert.ErrorContains(t, err, "not cloned")
+}
+
+// writeBundle builds a bundle by hand from a manifest and extra tar entries.
+func writeBundle(t *testing.T, manifest string, entries ...tar.Header) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: manifestName, Mode: 0644, Size: int64(len(manifest))}))
+	_, err := tw.Write([]byte(manifest))
+	require.NoError(t, err)
+	for i := range entries {
+		require.NoError(t, tw.WriteHeader(&entries[i]))
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+	return &buf
+}
+
+func TestImportRejectsUnsafeBundles(t *testing.T) {
+	const good = `{"version":1,"sources":[{"source":{"id":"owner/repo","owner":"owner","repo":"repo"},"skills":[]}]}`
+
+	tests := []struct {
+		name     string
+		manifest string
+		entries  []tar.Header
+		want     string
+	}{
+		{"newer version", `{"version":99}`, nil, "newer than supported"},
+		{"source escapes", `{"version":1,"sources":[{"source":{"id":"x","owner":"..","repo":"x"}}]}`, nil, "invalid source"},
+		{"entry escapes", good, []tar.Header{{Name: "repos/../../evil", Typeflag: tar.TypeDir, Mode: 0755}}, "outside repos/"},
+		{"entry outside repos", good, []tar.Header{{Name: "evil", Typeflag: tar.TypeDir, Mode: 0755}}, "outside repos/"},
+		{"symlink escapes", good, []tar.Header{{Name: "repos/owner/repo/link", Typeflag: tar.TypeSymlink, Linkname: "../../../../etc/passwd"}}, "links outside"},
+		{"symlink chain escapes", good, []tar.Header{
+			{Name: "repos/owner/repo/l1", Typeflag: tar.TypeSymlink, Linkname: ".."},
+			{Name: "repos/owner/repo/l1/l2", Typeflag: tar.TypeSymlink, Linkname: "../../.."},
+			{Name: "repos/owner/repo/l1/l2/evil.txt", Typeflag: tar.TypeReg, Mode: 0644},
+		}, "inside a symlinked directory"},
+		{"symlink through a later symlink", good, []tar.Header{
+			{Name: "repos/owner/repo/a", Typeflag: tar.TypeSymlink, Linkname: "l1/../../.."},
+			{Name: "repos/owner/repo/l1", Typeflag: tar.TypeSymlink, Linkname: "../.."},
+		}, "links outside"},
+		{"missing clone", good, nil, "no files for owner/repo"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			database := setupTestDB(t)
+			repos := t.TempDir()
+			_, err := Import(writeBundle(t, tt.manifest, tt.entries...), database, repos)
+			assert.ErrorContains(t, err, tt.want)
+			assert.NoFileExists(t, filepath.Join(repos, "evil.txt"))
+
+			source, err := database.GetSource("owner/repo")
+			require.NoError(t, err)
+			assert.Nil(t, source)
+		})
+	}
+}
+
+func TestImportRejectsOtherFiles(t *testing.T) {
+	_, err := Import(bytes.NewReader([]byte("not gzip")), setupTestDB(t), t.TempDir())
+	assert.ErrorContains(t, err, "not a skulto bundle")
+}
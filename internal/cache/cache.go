@@ -0,0 +1,422 @@
+// Package cache reports on and trims the disk space skulto uses: cloned
+// repositories, the SQLite database, the vector store, and backups skulto
+// left in platform skill directories when a skill replaced a directory.
+//
+// A clone is needed while any of its skills is installed or favorited;
+// installs are symlinks into the clone. Other clones are only a cache, and
+// the next pull re-clones them.
+package cache
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/asteroid-belt/skulto/internal/db"
+	"github.com/asteroid-belt/skulto/internal/favorites"
+	"github.com/asteroid-belt/skulto/internal/models"
+	"github.com/asteroid-belt/skulto/internal/scraper"
+	"github.com/asteroid-belt/skulto/internal/vector"
+)
+
+// backupSuffix is the suffix installer.SymlinkManager gives a directory it
+// moves aside to link a skill from a clone in its place.
+const backupSuffix = ".backup"
+
+// ingestBackupSuffix is the suffix discovery ingestion gives a skill
+// directory it moves aside to link the skill from skulto's local skills
+// directory instead.
+const ingestBackupSuffix = ".skulto-backup"
+
+// Repo is one cloned repository.
+type Repo struct {
+	Path       string
+	Size       int64
+	LastAccess time.Time      // Last clone, fetch or checkout
+	Source     *models.Source // nil if no source uses the clone
+	Skills     int
+	Installed  int
+	Favorited  int
+
+	owner, repo string // Where the clone sits under the repositories directory
+}
+
+// ID names the clone by its source, or by its path if it has none.
+func (r Repo) ID() string {
+	if r.Source != nil {
+		return r.Source.ID
+	}
+	return r.Path
+}
+
+// Needed reports whether removing the clone would break an installed or
+// favorited skill.
+func (r Repo) Needed() bool {
+	return r.Installed > 0 || r.Favorited > 0
+}
+
+// Backup is a directory skulto moved aside whose original has since been
+// replaced by skulto's symlink, so nothing will restore it.
+type Backup struct {
+	Path string
+	Size int64
+}
+
+// Stats describes the disk space in use.
+type Stats struct {
+	Repos        []Repo // Largest first
+	ReposSize    int64
+	DatabaseSize int64
+	VectorsSize  int64
+	Backups      []Backup
+	BackupsSize  int64
+}
+
+// Total returns the bytes used by everything in s.
+func (s *Stats) Total() int64 {
+	return s.ReposSize + s.DatabaseSize + s.VectorsSize + s.BackupsSize
+}
+
+// Paths locates the files a Cache looks after.
+type Paths struct {
+	Database  string   // SQLite database file
+	Vectors   string   // Vector store directory
+	SkillDirs []string // Platform skill directories searched for backups
+
+	Repositories string // Clone directory that installs link into
+	LocalSkills  string // Directory ingested skills are moved to and linked from
+}
+
+// Cache reports on and trims skulto's disk usage.
+type Cache struct {
+	db        *db.DB
+	repos     *scraper.RepositoryManager
+	favorites *favorites.Store
+	paths     Paths
+}
+
+// New creates a Cache. favs may be nil if no skills are favorited.
+func New(database *db.DB, repos *scraper.RepositoryManager, favs *favorites.Store, paths Paths) *Cache {
+	return &Cache{db: database, repos: repos, favorites: favs, paths: paths}
+}
+
+// Stats measures the clones, database, vector store and orphaned backups.
+func (c *Cache) Stats() (*Stats, error) {
+	repos, err := c.collectRepos()
+	if err != nil {
+		return nil, err
+	}
+	stats := &Stats{
+		Repos:        repos,
+		DatabaseSize: fileSize(c.paths.Database) + fileSize(c.paths.Database+"-wal") + fileSize(c.paths.Database+"-shm"),
+		VectorsSize:  dirSize(c.paths.Vectors),
+		Backups:      c.orphanedBackups(),
+	}
+	for _, r := range repos {
+		stats.ReposSize += r.Size
+	}
+	for _, b := range stats.Backups {
+		stats.BackupsSize += b.Size
+	}
+	return stats, nil
+}
+
+// collectRepos measures each clone and counts its skills.
+func (c *Cache) collectRepos() ([]Repo, error) {
+	sources, err := c.db.ListSources()
+	if err != nil {
+		return nil, fmt.Errorf("list sources: %w", err)
+	}
+	skills, err := c.db.GetAllSkills()
+	if err != nil {
+		return nil, fmt.Errorf("list skills: %w", err)
+	}
+	installations, err := c.db.GetAllInstallations()
+	if err != nil {
+		return nil, fmt.Errorf("list installations: %w", err)
+	}
+
+	byPath := make(map[string]*models.Source, len(sources))
+	for i := range sources {
+		if sources[i].LocalDir() != "" {
+			continue
+		}
+		byPath[filepath.Clean(c.repos.GetRepoPath(sources[i].Owner, sources[i].Repo))] = &sources[i]
+	}
+	installed := make(map[string]bool, len(installations))
+	for _, inst := range installations {
+		installed[inst.SkillID] = true
+	}
+	type counts struct{ skills, installed, favorited int }
+	bySource := make(map[string]*counts)
+	for _, s := range skills {
+		if s.SourceID == nil {
+			continue
+		}
+		n := bySource[*s.SourceID]
+		if n == nil {
+			n = &counts{}
+			bySource[*s.SourceID] = n
+		}
+		n.skills++
+		if s.IsInstalled || installed[s.ID] {
+			n.installed++
+		}
+		if c.favorites != nil && c.favorites.IsFavorite(s.Slug) {
+			n.favorited++
+		}
+	}
+
+	var repos []Repo
+	for _, clone := range c.repos.Clones() {
+		r := Repo{
+			Path:       clone.Path,
+			Size:       dirSize(clone.Path),
+			LastAccess: lastAccess(clone.Path),
+			Source:     byPath[filepath.Clean(clone.Path)],
+			owner:      clone.Owner,
+			repo:       clone.Repo,
+		}
+		if r.Source != nil {
+			if n := bySource[r.Source.ID]; n != nil {
+				r.Skills, r.Installed, r.Favorited = n.skills, n.installed, n.favorited
+			}
+		}
+		repos = append(repos, r)
+	}
+	sort.Slice(repos, func(i, j int) bool {
+		if repos[i].Size != repos[j].Size {
+			return repos[i].Size > repos[j].Size
+		}
+		return repos[i].Path < repos[j].Path
+	})
+	return repos, nil
+}
+
+// orphanedBackups finds backups in the platform skill directories that
+// skulto made: their original is now a symlink into the directory the
+// backup's owner links skills from. Anything else named like a backup is
+// left alone.
+func (c *Cache) orphanedBackups() []Backup {
+	owners := map[string]string{
+		backupSuffix:       c.paths.Repositories,
+		ingestBackupSuffix: c.paths.LocalSkills,
+	}
+	var backups []Backup
+	seen := make(map[string]bool)
+	for _, dir := range c.paths.SkillDirs {
+		if dir == "" || seen[dir] {
+			continue
+		}
+		seen[dir] = true
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, e := range entries {
+			for suffix, linkDir := range owners {
+				original, ok := strings.CutSuffix(e.Name(), suffix)
+				if !ok || original == "" || !linksInto(filepath.Join(dir, original), linkDir) {
+					continue
+				}
+				path := filepath.Join(dir, e.Name())
+				backups = append(backups, Backup{Path: path, Size: dirSize(path)})
+				break
+			}
+		}
+	}
+	sort.Slice(backups, func(i, j int) bool { return backups[i].Path < backups[j].Path })
+	return backups
+}
+
+// linksInto reports whether path is a symlink pointing below dir.
+func linksInto(path, dir string) bool {
+	if dir == "" {
+		return false
+	}
+	target, err := os.Readlink(path)
+	if err != nil {
+		return false
+	}
+	if !filepath.IsAbs(target) {
+		target = filepath.Join(filepath.Dir(path), target)
+	}
+	rel, err := filepath.Rel(filepath.Clean(dir), filepath.Clean(target))
+	return err == nil && rel != "." && filepath.IsLocal(rel)
+}
+
+// GCOptions controls what GC removes.
+type GCOptions struct {
+	// Budget is the most space clones may use; 0 removes every clone that
+	// isn't needed. With a budget, unneeded clones are removed least
+	// recently used first until the rest fit.
+	Budget int64
+
+	// ReposOnly skips the database and the vector store.
+	ReposOnly bool
+
+	// Backups also deletes backups skulto made of skill directories it
+	// replaced with a symlink. They are only reported otherwise.
+	Backups bool
+
+	// DryRun reports what would be removed without removing it.
+	DryRun bool
+}
+
+// GCResult describes what GC removed, or would remove in a dry run.
+type GCResult struct {
+	Removed           []Repo
+	Backups           []Backup
+	EmbeddingsRemoved int
+	Before            *Stats
+	After             *Stats // nil in a dry run
+
+	// OverBudget is set when the needed clones alone exceed the budget.
+	OverBudget bool
+}
+
+// Freed returns the bytes GC freed, or would free in a dry run.
+func (r *GCResult) Freed() int64 {
+	if r.After != nil {
+		return r.Before.Total() - r.After.Total()
+	}
+	var n int64
+	for _, repo := range r.Removed {
+		n += repo.Size
+	}
+	for _, b := range r.Backups {
+		n += b.Size
+	}
+	return n
+}
+
+// GC removes clones no installed or favorited skill needs and stale
+// embeddings, then compacts the database. Orphaned backups are removed
+// only with opts.Backups.
+func (c *Cache) GC(ctx context.Context, opts GCOptions) (*GCResult, error) {
+	before, err := c.Stats()
+	if err != nil {
+		return nil, err
+	}
+	result := &GCResult{Before: before}
+
+	// Least recently used first, so a budget keeps the clones in use
+	candidates := make([]Repo, 0, len(before.Repos))
+	for _, r := range before.Repos {
+		if !r.Needed() {
+			candidates = append(candidates, r)
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].LastAccess.Before(candidates[j].LastAccess)
+	})
+	size := before.ReposSize
+	for _, r := range candidates {
+		if opts.Budget > 0 && size <= opts.Budget {
+			break
+		}
+		result.Removed = append(result.Removed, r)
+		size -= r.Size
+	}
+	result.OverBudget = opts.Budget > 0 && size > opts.Budget
+	if opts.Backups {
+		result.Backups = before.Backups
+	}
+	if opts.DryRun {
+		return result, nil
+	}
+
+	for _, r := range result.Removed {
+		if err := c.removeClone(r); err != nil {
+			return nil, err
+		}
+	}
+	for _, b := range result.Backups {
+		if err := os.RemoveAll(b.Path); err != nil {
+			return nil, fmt.Errorf("remove backup %s: %w", b.Path, err)
+		}
+	}
+	if !opts.ReposOnly {
+		if err := c.compact(ctx, result); err != nil {
+			return nil, err
+		}
+	}
+
+	if result.After, err = c.Stats(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// removeClone deletes a clone through the repository manager, so a
+// concurrent pull can't race the removal.
+func (c *Cache) removeClone(r Repo) error {
+	if err := c.repos.RemoveRepository(r.owner, r.repo); err != nil {
+		return fmt.Errorf("remove %s: %w", r.ID(), err)
+	}
+	return nil
+}
+
+// compact drops embeddings of skills that no longer exist and rebuilds the
+// database file.
+func (c *Cache) compact(ctx context.Context, result *GCResult) error {
+	if c.paths.Vectors != "" {
+		skills, err := c.db.GetAllSkills()
+		if err != nil {
+			return fmt.Errorf("list skills: %w", err)
+		}
+		ids := make([]string, len(skills))
+		for i, s := range skills {
+			ids[i] = s.ID
+		}
+		if result.EmbeddingsRemoved, err = vector.Compact(ctx, c.paths.Vectors, ids); err != nil {
+			return fmt.Errorf("compact vector store: %w", err)
+		}
+	}
+	if err := c.db.Vacuum(); err != nil {
+		return fmt.Errorf("vacuum database: %w", err)
+	}
+	return nil
+}
+
+// lastAccess returns when git last touched the clone at dir.
+func lastAccess(dir string) time.Time {
+	var latest time.Time
+	for _, p := range []string{dir, filepath.Join(dir, ".git", "FETCH_HEAD"), filepath.Join(dir, ".git", "HEAD"), filepath.Join(dir, ".git", "index")} {
+		if info, err := os.Stat(p); err == nil && info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+	return latest
+}
+
+// dirSize returns the bytes used by the files under dir.
+func dirSize(dir string) int64 {
+	var size int64
+	_ = filepath.WalkDir(dir, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.Type().IsRegular() {
+			if info, err := d.Info(); err == nil {
+				size += info.Size()
+			}
+		}
+		return nil
+	})
+	return size
+}
+
+// fileSize returns the size of the file at path, or 0 if it doesn't exist.
+func fileSize(path string) int64 {
+	info, err := os.Stat(path)
+	if err != nil {
+		return 0
+	}
+	return info.Size()
+}
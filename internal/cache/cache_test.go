@@ -0,0 +1,175 @@
+package cache
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/asteroid-belt/skulto/internal/db"
+	"github.com/asteroid-belt/skulto/internal/favorites"
+	"github.com/asteroid-belt/skulto/internal/models"
+	"github.com/asteroid-belt/skulto/internal/scraper"
+)
+
+type fixture struct {
+	cache     *Cache
+	db        *db.DB
+	repos     *scraper.RepositoryManager
+	reposDir  string
+	skillDir  string
+	favorites *favorites.Store
+}
+
+func setup(t *testing.T) *fixture {
+	t.Helper()
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, "skulto.db")
+	database, err := db.New(db.Config{Path: dbPath})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = database.Close() })
+
+	f := &fixture{
+		db:        database,
+		reposDir:  filepath.Join(dir, "repositories"),
+		skillDir:  filepath.Join(dir, "skills"),
+		favorites: favorites.NewStore(filepath.Join(dir, "favorites.json")),
+	}
+	require.NoError(t, os.MkdirAll(f.skillDir, 0o755))
+	f.repos = scraper.NewRepositoryManager(f.reposDir, "")
+	f.cache = New(database, f.repos, f.favorites, Paths{
+		Database:     dbPath,
+		Vectors:      filepath.Join(dir, "vectors"),
+		SkillDirs:    []string{f.skillDir},
+		Repositories: f.reposDir,
+	})
+	return f
+}
+
+// addClone registers a source with one skill and fakes its clone with size
+// bytes of content, last fetched at fetched.
+func (f *fixture) addClone(t *testing.T, owner, repo string, size int, fetched time.Time) *models.Source {
+	t.Helper()
+	source := &models.Source{ID: owner + "/" + repo, Owner: owner, Repo: repo, FullName: owner + "/" + repo}
+	require.NoError(t, f.db.CreateSource(source))
+	require.NoError(t, f.db.CreateSkill(&models.Skill{ID: repo + "-skill", Slug: repo + "-skill", Title: repo, SourceID: &source.ID}))
+
+	path := f.repos.GetRepoPath(owner, repo)
+	require.NoError(t, os.MkdirAll(filepath.Join(path, ".git"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(path, "SKILL.md"), make([]byte, size), 0o644))
+	for _, p := range []string{path, filepath.Join(path, ".git"), filepath.Join(path, "SKILL.md")} {
+		require.NoError(t, os.Chtimes(p, fetched, fetched))
+	}
+	return source
+}
+
+func TestStats(t *testing.T) {
+	f := setup(t)
+	now := time.Now()
+	f.addClone(t, "acme", "big", 4000, now)
+	f.addClone(t, "acme", "small", 1000, now)
+	require.NoError(t, f.db.AddInstallation(&models.SkillInstallation{ID: "i1", SkillID: "small-skill", Platform: "claude", Scope: "global", BasePath: "/home"}))
+
+	stats, err := f.cache.Stats()
+	require.NoError(t, err)
+	require.Len(t, stats.Repos, 2)
+	assert.Equal(t, "acme/big", stats.Repos[0].ID(), "largest first")
+	assert.Equal(t, int64(4000), stats.Repos[0].Size)
+	assert.Equal(t, 1, stats.Repos[0].Skills)
+	assert.False(t, stats.Repos[0].Needed())
+	assert.Equal(t, 1, stats.Repos[1].Installed)
+	assert.True(t, stats.Repos[1].Needed())
+	assert.Equal(t, int64(5000), stats.ReposSize)
+	assert.Positive(t, stats.DatabaseSize)
+}
+
+func TestGC(t *testing.T) {
+	f := setup(t)
+	now := time.Now()
+	f.addClone(t, "acme", "unused", 1000, now)
+	f.addClone(t, "acme", "installed", 1000, now)
+	f.addClone(t, "acme", "favorite", 1000, now)
+	require.NoError(t, f.db.AddInstallation(&models.SkillInstallation{ID: "i1", SkillID: "installed-skill", Platform: "claude", Scope: "global", BasePath: "/home"}))
+	require.NoError(t, f.favorites.Add("favorite-skill"))
+
+	// A clone no source uses
+	stray := filepath.Join(f.reposDir, "gone", "repo")
+	require.NoError(t, os.MkdirAll(filepath.Join(stray, ".git"), 0o755))
+
+	// A backup whose original became skulto's symlink, one still needed, and
+	// one whose original links somewhere skulto doesn't install from
+	require.NoError(t, os.MkdirAll(filepath.Join(f.skillDir, "replaced.backup"), 0o755))
+	require.NoError(t, os.Symlink(filepath.Join(f.reposDir, "acme", "installed", "replaced"), filepath.Join(f.skillDir, "replaced")))
+	require.NoError(t, os.MkdirAll(filepath.Join(f.skillDir, "kept.skulto-backup"), 0o755))
+	require.NoError(t, os.MkdirAll(filepath.Join(f.skillDir, "kept"), 0o755))
+	require.NoError(t, os.MkdirAll(filepath.Join(f.skillDir, "mine.backup"), 0o755))
+	require.NoError(t, os.Symlink(t.TempDir(), filepath.Join(f.skillDir, "mine")))
+
+	stats, err := f.cache.Stats()
+	require.NoError(t, err)
+	require.Len(t, stats.Backups, 1)
+	assert.Equal(t, filepath.Join(f.skillDir, "replaced.backup"), stats.Backups[0].Path)
+
+	dry, err := f.cache.GC(context.Background(), GCOptions{DryRun: true})
+	require.NoError(t, err)
+	assert.Len(t, dry.Removed, 2)
+	assert.Nil(t, dry.After)
+	assert.Equal(t, int64(1000), dry.Freed())
+	assert.DirExists(t, f.repos.GetRepoPath("acme", "unused"))
+
+	result, err := f.cache.GC(context.Background(), GCOptions{})
+	require.NoError(t, err)
+	var removed []string
+	for _, r := range result.Removed {
+		removed = append(removed, r.ID())
+	}
+	assert.ElementsMatch(t, []string{"acme/unused", stray}, removed)
+	assert.NoDirExists(t, f.repos.GetRepoPath("acme", "unused"))
+	assert.NoDirExists(t, filepath.Join(f.reposDir, "gone"))
+	assert.DirExists(t, f.repos.GetRepoPath("acme", "installed"))
+	assert.DirExists(t, f.repos.GetRepoPath("acme", "favorite"))
+	assert.Len(t, result.After.Repos, 2)
+
+	// Backups are only deleted when asked for
+	assert.Empty(t, result.Backups)
+	assert.DirExists(t, filepath.Join(f.skillDir, "replaced.backup"))
+
+	result, err = f.cache.GC(context.Background(), GCOptions{Backups: true})
+	require.NoError(t, err)
+	require.Len(t, result.Backups, 1)
+	assert.NoDirExists(t, filepath.Join(f.skillDir, "replaced.backup"))
+	assert.DirExists(t, filepath.Join(f.skillDir, "kept.skulto-backup"))
+	assert.DirExists(t, filepath.Join(f.skillDir, "mine.backup"))
+
+	// Sources and skills stay for the next pull
+	source, err := f.db.GetSource("acme/unused")
+	require.NoError(t, err)
+	assert.NotNil(t, source)
+}
+
+func TestGC_Budget(t *testing.T) {
+	f := setup(t)
+	now := time.Now()
+	f.addClone(t, "acme", "oldest", 1000, now.Add(-3*time.Hour))
+	f.addClone(t, "acme", "older", 1000, now.Add(-2*time.Hour))
+	f.addClone(t, "acme", "recent", 1000, now.Add(-time.Hour))
+
+	// Evicts least recently used until the rest fit
+	result, err := f.cache.GC(context.Background(), GCOptions{Budget: 2000, ReposOnly: true})
+	require.NoError(t, err)
+	require.Len(t, result.Removed, 1)
+	assert.Equal(t, "acme/oldest", result.Removed[0].ID())
+	assert.False(t, result.OverBudget)
+
+	// Needed clones are never evicted
+	require.NoError(t, f.favorites.Add("older-skill"))
+	require.NoError(t, f.favorites.Add("recent-skill"))
+	result, err = f.cache.GC(context.Background(), GCOptions{Budget: 500, ReposOnly: true})
+	require.NoError(t, err)
+	assert.Empty(t, result.Removed)
+	assert.True(t, result.OverBudget)
+}
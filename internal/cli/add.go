@@ -3,15 +3,22 @@ package cli
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/asteroid-belt/skulto/internal/config"
 	"github.com/asteroid-belt/skulto/internal/db"
+	"github.com/asteroid-belt/skulto/internal/models"
 	"github.com/asteroid-belt/skulto/internal/scraper"
 	"github.com/spf13/cobra"
 )
 
-var addNoSync bool
+var (
+	addNoSync      bool
+	addRef         string
+	addPath        string
+	addFullHistory bool
+)
 
 var addCmd = &cobra.Command{
 	Use:     "add <repository_url>",
@@ -20,10 +27,26 @@ var addCmd = &cobra.Command{
 	Long: `Add a skill repository to Skulto and sync its skills.
 
 Supports multiple URL formats:
-  - owner/repo                    (short format)
+  - owner/repo                    (short format, GitHub)
   - https://github.com/owner/repo
   - https://github.com/owner/repo.git
   - git@github.com:owner/repo.git
+  - host/namespace/repo           (any other git host)
+  - https://, ssh:// or git@host: URLs on GitLab, Bitbucket, Gitea, ...
+  - ./path/to/dir or file:///path  (a local directory)
+
+A local directory is scanned in place instead of cloned, and rescanned on
+every 'skulto pull', so edits show up without committing or pushing.
+Skills from it are installed as symlinks into the directory itself.
+
+By default skulto follows the repository's default branch and scans the
+whole tree. Use --ref to follow another branch or a tag, and --path to only
+look for skills under one directory. Both are remembered for 'skulto pull'.
+
+Clones are shallow unless --full-history is set, which keeps every commit
+so 'skulto log' can show how each skill changed.
+
+Private remotes use your git credentials; see 'skulto auth test'.
 
 Examples:
   # Add using short format
@@ -32,6 +55,16 @@ Examples:
   # Add using full URL
   skulto add https://github.com/asteroid-belt/skills
 
+  # Add from a self-hosted GitLab (nested groups are fine)
+  skulto add https://gitlab.example.com/platform/ai/skills
+  skulto add git@gitlab.example.com:platform/ai/skills.git
+
+  # Follow the release branch, scanning only skills/
+  skulto add acme/skills --ref release --path skills/
+
+  # Index a local checkout you're working on
+  skulto add ./my-skills
+
   # Add without syncing (manual sync later)
   skulto add asteroid-belt/skills --no-sync`,
 	Args: cobra.ExactArgs(1),
@@ -40,6 +73,9 @@ Examples:
 
 func init() {
 	addCmd.Flags().BoolVar(&addNoSync, "no-sync", false, "Don't clone and sync skills immediately")
+	addCmd.Flags().StringVar(&addRef, "ref", "", "Branch or tag to follow instead of the default branch")
+	addCmd.Flags().StringVar(&addPath, "path", "", "Only scan this directory of the repository for skills")
+	addCmd.Flags().BoolVar(&addFullHistory, "full-history", false, "Clone every commit so 'skulto log' can show skill history")
 }
 
 func runAdd(cmd *cobra.Command, args []string) error {
@@ -65,6 +101,15 @@ func runAdd(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("invalid repository URL: %w", err)
 	}
+	if err := applyAddTarget(source, addRef, addPath); err != nil {
+		return err
+	}
+	if addFullHistory {
+		if source.LocalDir() != "" {
+			return fmt.Errorf("--full-history can't be used with a local directory; its own git history is read in place")
+		}
+		source.FullHistory = true
+	}
 
 	existing, err := database.GetSource(source.ID)
 	if err != nil {
@@ -79,16 +124,24 @@ func runAdd(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to add source: %w", err)
 	}
 	fmt.Println("   \u2713 Repository added")
+	if source.Ref != "" {
+		fmt.Printf("   Following %s\n", source.Ref)
+	}
+	if source.SkillPath != "" {
+		fmt.Printf("   Scanning %s/ only\n", source.SkillPath)
+	}
+	if source.FullHistory {
+		fmt.Println("   Keeping full history")
+	}
 
 	if !addNoSync {
-		fmt.Println("\nCloning and syncing repository...")
-
-		scraperCfg := scraper.ScraperConfig{
-			Token:        cfg.GitHub.Token,
-			DataDir:      cfg.BaseDir,
-			RepoCacheTTL: cfg.GitHub.RepoCacheTTL,
-			UseGitClone:  cfg.GitHub.UseGitClone,
+		if dir := source.LocalDir(); dir != "" {
+			fmt.Printf("\nScanning %s...\n", dir)
+		} else {
+			fmt.Println("\nCloning and syncing repository...")
 		}
+
+		scraperCfg := scraper.ScraperConfigFromConfig(cfg)
 		s := scraper.NewScraperWithConfig(scraperCfg, database)
 
 		syncCtx, cancel := context.WithTimeout(ctx, 5*time.Minute)
@@ -122,3 +175,19 @@ func runAdd(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// applyAddTarget records the branch or tag and subdirectory a new source
+// follows. Local directories are read as they are, so they take no ref.
+func applyAddTarget(source *models.Source, ref, skillPath string) error {
+	ref = strings.TrimSpace(ref)
+	if ref != "" && source.LocalDir() != "" {
+		return fmt.Errorf("--ref can't be used with a local directory")
+	}
+	p, err := scraper.NormalizeSkillPath(skillPath)
+	if err != nil {
+		return fmt.Errorf("invalid --path: %w", err)
+	}
+	source.Ref = ref
+	source.SkillPath = p
+	return nil
+}
@@ -41,6 +41,8 @@ func TestAddCmd_ValidURLFormats(t *testing.T) {
 		{"https URL", "https://github.com/owner/repo", "owner", "repo"},
 		{"https URL with .git", "https://github.com/owner/repo.git", "owner", "repo"},
 		{"ssh URL", "git@github.com:owner/repo.git", "owner", "repo"},
+		{"gitlab URL", "https://gitlab.example.com/team/repo.git", "gitlab.example.com/team", "repo"},
+		{"bitbucket ssh URL", "git@bitbucket.org:team/repo.git", "bitbucket.org/team", "repo"},
 	}
 
 	for _, tc := range testCases {
@@ -57,3 +59,26 @@ func TestAddCmd_InvalidURL(t *testing.T) {
 	_, err := scraper.ParseRepositoryURL("invalid-url")
 	assert.Error(t, err)
 }
+
+func TestAddCmd_RefAndPathFlags(t *testing.T) {
+	assert.NotNil(t, addCmd.Flags().Lookup("ref"))
+	assert.NotNil(t, addCmd.Flags().Lookup("path"))
+	assert.NotNil(t, addCmd.Flags().Lookup("full-history"))
+}
+
+func TestApplyAddTarget(t *testing.T) {
+	source, err := scraper.ParseRepositoryURL("owner/repo")
+	require.NoError(t, err)
+	require.NoError(t, applyAddTarget(source, "release", "./skills/"))
+	assert.Equal(t, "release", source.Ref)
+	assert.Equal(t, "skills", source.SkillPath)
+
+	err = applyAddTarget(source, "", "../elsewhere")
+	assert.Error(t, err)
+
+	local, err := scraper.ParseRepositoryURL(t.TempDir())
+	require.NoError(t, err)
+	assert.Error(t, applyAddTarget(local, "main", ""), "local directories take no ref")
+	require.NoError(t, applyAddTarget(local, "", "skills"))
+	assert.Equal(t, "skills", local.SkillPath)
+}
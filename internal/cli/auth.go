@@ -0,0 +1,75 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/asteroid-belt/skulto/internal/config"
+	"github.com/asteroid-belt/skulto/internal/scraper"
+	"github.com/spf13/cobra"
+)
+
+var authCmd = &cobra.Command{
+	Use:   "auth",
+	Short: "Check credentials for git remotes",
+	Long: `Check which credentials skulto uses for a git remote.
+
+For https remotes skulto tries, in order:
+  1. A token for the host in SKULTO_GIT_TOKENS (GITHUB_TOKEN on github.com)
+  2. git's credential helpers (git credential fill, without prompting)
+  3. ~/.git-credentials
+  4. ~/.netrc (or $NETRC)
+  5. No credentials
+
+For ssh remotes it tries ssh-agent, then SKULTO_SSH_KEY, then
+~/.ssh/id_ed25519, id_ecdsa and id_rsa.
+
+Subcommands:
+  test <url>   Report which method a remote accepts`,
+	Args: cobra.NoArgs,
+}
+
+var authTestCmd = &cobra.Command{
+	Use:   "test <url>",
+	Short: "Report which credentials a remote accepts",
+	Long: `Connect to a remote with each configured authentication method, in the
+order skulto uses them, and report which one succeeded. Nothing is cloned.
+
+Examples:
+  skulto auth test https://gitlab.example.com/platform/skills
+  skulto auth test git@github.com:acme/private-skills.git`,
+	Args: cobra.ExactArgs(1),
+	RunE: runAuthTest,
+}
+
+func init() {
+	authCmd.AddCommand(authTestCmd)
+}
+
+func runAuthTest(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return trackCLIError("auth test", fmt.Errorf("load config: %w", err))
+	}
+
+	rm := scraper.NewRepositoryManagerFromConfig(cfg)
+
+	fmt.Printf("Testing authentication for %s\n\n", args[0])
+	attempts, err := rm.TestAuth(cmd.Context(), args[0])
+	if err != nil {
+		return trackCLIError("auth test", err)
+	}
+
+	for _, a := range attempts {
+		label := a.Method
+		if a.Detail != "" {
+			label = fmt.Sprintf("%s (%s)", a.Method, a.Detail)
+		}
+		if a.Err == nil {
+			fmt.Printf("   %s %s\n", cleanStyle.Render("✓"), label)
+			fmt.Printf("\nAuthenticated with %s.\n", a.Method)
+			return nil
+		}
+		fmt.Printf("   %s %s: %v\n", errorStyle.Render("✗"), label, a.Err)
+	}
+	return trackCLIError("auth test", fmt.Errorf("no authentication method was accepted by %s", args[0]))
+}
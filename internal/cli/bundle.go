@@ -0,0 +1,172 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/asteroid-belt/skulto/internal/bundle"
+	"github.com/asteroid-belt/skulto/internal/config"
+	"github.com/asteroid-belt/skulto/internal/db"
+	"github.com/asteroid-belt/skulto/internal/models"
+	"github.com/asteroid-belt/skulto/internal/scraper"
+	"github.com/spf13/cobra"
+)
+
+var bundleOutput string
+
+var bundleCmd = &cobra.Command{
+	Use:   "bundle",
+	Short: "Carry sources to machines without network access",
+	Long: `Pack sources into a single file and register them on another machine
+without network access.
+
+A bundle holds each source's clone (with its git history as fetched) along
+with the skills, tags, auxiliary files and security scan results skulto
+recorded for it, so search, scan and install work on the other machine
+exactly as they do here.
+
+Subcommands:
+  create [source...]  Pack sources into a bundle file
+  import <file>       Register the sources in a bundle`,
+	Args: cobra.NoArgs,
+}
+
+var bundleCreateCmd = &cobra.Command{
+	Use:   "create [source...]",
+	Short: "Pack sources into a bundle file",
+	Long: `Pack sources into a bundle file. With no arguments every source is
+bundled except local directory sources, which are read in place and have no
+clone to carry.
+
+Sources must have been pulled; run 'skulto pull' first to bundle the latest
+commits.
+
+Examples:
+  skulto bundle create -o skills.tar.gz
+  skulto bundle create asteroid-belt/skills gitlab.com/team/skills -o team.tar.gz`,
+	RunE: runBundleCreate,
+}
+
+var bundleImportCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Register the sources in a bundle",
+	Long: `Register the sources in a bundle made with 'skulto bundle create'. Clones
+are unpacked into skulto's repository directory, replacing any clone of the
+same source, and the sources, skills and scan results are recorded as they
+were on the machine that made the bundle. No network access is needed.
+
+Import again with a newer bundle to update.
+
+Examples:
+  skulto bundle import skills.tar.gz`,
+	Args: cobra.ExactArgs(1),
+	RunE: runBundleImport,
+}
+
+func init() {
+	bundleCreateCmd.Flags().StringVarP(&bundleOutput, "output", "o", "skulto-bundle.tar.gz", "Bundle file to write")
+	bundleCmd.AddCommand(bundleCreateCmd)
+	bundleCmd.AddCommand(bundleImportCmd)
+}
+
+func runBundleCreate(cmd *cobra.Command, args []string) error {
+	cfg, database, err := openCommandDB("bundle create")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = database.Close() }()
+
+	sources, err := bundleSources(database, args)
+	if err != nil {
+		return trackCLIError("bundle create", err)
+	}
+	if len(sources) == 0 {
+		return trackCLIError("bundle create", errors.New("no sources to bundle; add one with 'skulto add'"))
+	}
+
+	f, err := os.Create(bundleOutput)
+	if err != nil {
+		return trackCLIError("bundle create", fmt.Errorf("create %s: %w", bundleOutput, err))
+	}
+	manifest, err := bundle.Create(f, database, config.GetPaths(cfg).Repositories, sources)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(bundleOutput)
+		return trackCLIError("bundle create", err)
+	}
+
+	skills := 0
+	for _, e := range manifest.Sources {
+		skills += len(e.Skills)
+		fmt.Printf("  %s %s (%d skills)\n", cleanStyle.Render("✓"), e.Source.ID, len(e.Skills))
+	}
+	fmt.Printf("%s %s: %d source(s), %d skill(s)\n", cleanStyle.Render("✓ Bundled"), bundleOutput, len(manifest.Sources), skills)
+	return nil
+}
+
+// bundleSources resolves source arguments (IDs or URLs), or returns every
+// source that can be bundled when there are none.
+func bundleSources(database *db.DB, args []string) ([]models.Source, error) {
+	if len(args) == 0 {
+		all, err := database.ListSources()
+		if err != nil {
+			return nil, fmt.Errorf("list sources: %w", err)
+		}
+		var sources []models.Source
+		for _, s := range all {
+			if s.LocalDir() == "" {
+				sources = append(sources, s)
+			}
+		}
+		return sources, nil
+	}
+
+	sources := make([]models.Source, 0, len(args))
+	for _, arg := range args {
+		source, err := database.GetSource(arg)
+		if err != nil {
+			return nil, fmt.Errorf("check source %s: %w", arg, err)
+		}
+		if source == nil {
+			parsed, err := scraper.ParseRepositoryURL(arg)
+			if err != nil {
+				return nil, fmt.Errorf("invalid repository %q: %w", arg, err)
+			}
+			if source, err = database.GetSource(parsed.ID); err != nil {
+				return nil, fmt.Errorf("check source %s: %w", parsed.ID, err)
+			}
+		}
+		if source == nil {
+			return nil, fmt.Errorf("source %s not found; see 'skulto list'", arg)
+		}
+		sources = append(sources, *source)
+	}
+	return sources, nil
+}
+
+func runBundleImport(cmd *cobra.Command, args []string) error {
+	cfg, database, err := openCommandDB("bundle import")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = database.Close() }()
+
+	f, err := os.Open(args[0])
+	if err != nil {
+		return trackCLIError("bundle import", fmt.Errorf("open %s: %w", args[0], err))
+	}
+	defer func() { _ = f.Close() }()
+
+	result, err := bundle.Import(f, database, config.GetPaths(cfg).Repositories)
+	if err != nil {
+		return trackCLIError("bundle import", err)
+	}
+	for _, e := range result.Manifest.Sources {
+		fmt.Printf("  %s %s (%d skills)\n", cleanStyle.Render("✓"), e.Source.ID, len(e.Skills))
+	}
+	fmt.Printf("%s %s: %d source(s), %d skill(s)\n", cleanStyle.Render("✓ Imported"), args[0], len(result.Manifest.Sources), result.Skills)
+	return nil
+}
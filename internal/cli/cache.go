@@ -0,0 +1,251 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/asteroid-belt/skulto/internal/cache"
+	"github.com/asteroid-belt/skulto/internal/config"
+	"github.com/asteroid-belt/skulto/internal/db"
+	"github.com/asteroid-belt/skulto/internal/favorites"
+	"github.com/asteroid-belt/skulto/internal/installer"
+	"github.com/asteroid-belt/skulto/internal/scraper"
+	"github.com/spf13/cobra"
+)
+
+var (
+	cacheGCBudget  string
+	cacheGCDryRun  bool
+	cacheGCBackups bool
+)
+
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Show and reclaim the disk space skulto uses",
+	Long: `Show and reclaim the disk space skulto uses for cloned repositories, its
+database, the vector store and backups of replaced skill directories.
+
+Clones with an installed or favorited skill are always kept; the rest are a
+cache that the next pull fills again. Set SKULTO_CACHE_BUDGET (e.g. "2GB")
+to keep clones under a size limit; 'skulto pull' then trims them after
+pulling.
+
+Subcommands:
+  stats   Show disk usage per repository
+  gc      Remove unneeded clones and compact the database`,
+	Args: cobra.NoArgs,
+}
+
+var cacheStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show disk usage per repository",
+	Long: `Show each cloned repository's size, when it was last fetched, and how many
+of its skills are installed, along with the size of the database, the vector
+store and any orphaned backups.
+
+Examples:
+  skulto cache stats`,
+	Args: cobra.NoArgs,
+	RunE: runCacheStats,
+}
+
+var cacheGCCmd = &cobra.Command{
+	Use:   "gc",
+	Short: "Remove unneeded clones and compact the database",
+	Long: `Remove cloned repositories with no installed or favorited skills, drop
+embeddings of deleted skills, and compact the database.
+
+With --backups, also delete the backups skulto made of skill directories it
+replaced with a symlink (name.backup, name.skulto-backup). Only backups
+whose original is now skulto's symlink are touched.
+
+With a budget (--budget, or SKULTO_CACHE_BUDGET), unneeded clones are
+removed least recently fetched first until the rest fit, so recently used
+repositories stay cached. Sources and their skills are kept; 'skulto pull'
+clones them again.
+
+Examples:
+  skulto cache gc
+  skulto cache gc --dry-run
+  skulto cache gc --backups
+  skulto cache gc --budget 1GB`,
+	Args: cobra.NoArgs,
+	RunE: runCacheGC,
+}
+
+func init() {
+	cacheGCCmd.Flags().StringVar(&cacheGCBudget, "budget", "", `Size to keep clones under, e.g. "500MB" or "2GB" (default SKULTO_CACHE_BUDGET)`)
+	cacheGCCmd.Flags().BoolVar(&cacheGCDryRun, "dry-run", false, "Show what would be removed without removing it")
+	cacheGCCmd.Flags().BoolVar(&cacheGCBackups, "backups", false, "Also delete backups of skill directories skulto replaced")
+
+	cacheCmd.AddCommand(cacheStatsCmd)
+	cacheCmd.AddCommand(cacheGCCmd)
+}
+
+func runCacheStats(cmd *cobra.Command, args []string) error {
+	cfg, database, err := openCommandDB("cache stats")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = database.Close() }()
+
+	c, err := newCache(cfg, database)
+	if err != nil {
+		return trackCLIError("cache stats", err)
+	}
+	stats, err := c.Stats()
+	if err != nil {
+		return trackCLIError("cache stats", err)
+	}
+
+	fmt.Println(planHeaderStyle.Render("Repositories"))
+	if len(stats.Repos) == 0 {
+		fmt.Println("  No clones. Run 'skulto pull' to fetch sources.")
+	}
+	for _, r := range stats.Repos {
+		line := fmt.Sprintf("  %-40s %9s  %-10s  %d skills, %d installed", truncateName(r.ID(), 40), formatSize(r.Size), formatAccess(r), r.Skills, r.Installed)
+		if r.Favorited > 0 {
+			line += fmt.Sprintf(", %d favorited", r.Favorited)
+		}
+		if r.Source == nil {
+			line += " (no source)"
+		}
+		fmt.Println(line)
+	}
+
+	fmt.Println()
+	fmt.Printf("Repositories:  %s", formatSize(stats.ReposSize))
+	if cfg.Cache.Budget > 0 {
+		fmt.Printf(" of %s budget", formatSize(cfg.Cache.Budget))
+	}
+	fmt.Println()
+	fmt.Printf("Database:      %s\n", formatSize(stats.DatabaseSize))
+	fmt.Printf("Vector store:  %s\n", formatSize(stats.VectorsSize))
+	if len(stats.Backups) > 0 {
+		fmt.Printf("Backups:       %s in %d orphaned backup(s); remove with 'skulto cache gc --backups'\n", formatSize(stats.BackupsSize), len(stats.Backups))
+	}
+	fmt.Printf("Total:         %s\n", formatSize(stats.Total()))
+	return nil
+}
+
+func runCacheGC(cmd *cobra.Command, args []string) error {
+	cfg, database, err := openCommandDB("cache gc")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = database.Close() }()
+
+	budget := cfg.Cache.Budget
+	if cacheGCBudget != "" {
+		if budget, err = config.ParseSize(cacheGCBudget); err != nil {
+			return trackCLIError("cache gc", fmt.Errorf("--budget: %w", err))
+		}
+	}
+
+	c, err := newCache(cfg, database)
+	if err != nil {
+		return trackCLIError("cache gc", err)
+	}
+	result, err := c.GC(cmd.Context(), cache.GCOptions{Budget: budget, Backups: cacheGCBackups, DryRun: cacheGCDryRun})
+	if err != nil {
+		return trackCLIError("cache gc", err)
+	}
+
+	verb := "Removed"
+	if cacheGCDryRun {
+		verb = "Would remove"
+	}
+	// Backups are the user's own files; list them before the clones
+	for _, b := range result.Backups {
+		fmt.Printf("   %s %s backup %s (%s)\n", planRemoveStyle.Render("-"), verb, b.Path, formatSize(b.Size))
+	}
+	for _, r := range result.Removed {
+		fmt.Printf("   %s %s %s (%s)\n", planRemoveStyle.Render("-"), verb, r.ID(), formatSize(r.Size))
+	}
+	if cacheGCDryRun {
+		fmt.Printf("\nWould free %s. The database and vector store are compacted too.\n", formatSize(result.Freed()))
+		return nil
+	}
+	if result.EmbeddingsRemoved > 0 {
+		fmt.Printf("   %s Dropped %d stale embedding(s)\n", cleanStyle.Render("✓"), result.EmbeddingsRemoved)
+	}
+	fmt.Printf("   %s Compacted the database (%s → %s)\n", cleanStyle.Render("✓"), formatSize(result.Before.DatabaseSize), formatSize(result.After.DatabaseSize))
+	fmt.Printf("\nFreed %s; skulto now uses %s.\n", formatSize(result.Freed()), formatSize(result.After.Total()))
+	if result.OverBudget {
+		fmt.Printf("Clones still use %s, over the %s budget, because their skills are installed or favorited.\n",
+			formatSize(result.After.ReposSize), formatSize(budget))
+	}
+	return nil
+}
+
+// newCache sets up a cache.Cache over cfg's directories, looking for
+// backups in every platform's global and project skill directories.
+func newCache(cfg *config.Config, database *db.DB) (*cache.Cache, error) {
+	paths := config.GetPaths(cfg)
+	favs := favorites.NewStore(paths.Favorites)
+	if err := favs.Load(); err != nil {
+		return nil, fmt.Errorf("load favorites: %w", err)
+	}
+
+	var skillDirs []string
+	for _, p := range installer.AllPlatforms() {
+		for _, scope := range installer.AllScopes() {
+			if path, err := p.GetSkillPathForScope("x", scope); err == nil && path != "" {
+				skillDirs = append(skillDirs, filepath.Dir(path))
+			}
+		}
+	}
+
+	repoManager := scraper.NewRepositoryManagerFromConfig(cfg)
+	return cache.New(database, repoManager, favs, cache.Paths{
+		Database:     paths.Database,
+		Vectors:      cfg.Embedding.DataDir,
+		SkillDirs:    skillDirs,
+		Repositories: paths.Repositories,
+		LocalSkills:  paths.Skills,
+	}), nil
+}
+
+// trimCacheToBudget removes unneeded clones after a pull until they fit
+// cfg.Cache.Budget. Failures are warnings.
+func trimCacheToBudget(ctx context.Context, cfg *config.Config, database *db.DB) {
+	if cfg.Cache.Budget <= 0 {
+		return
+	}
+	c, err := newCache(cfg, database)
+	if err == nil {
+		var result *cache.GCResult
+		result, err = c.GC(ctx, cache.GCOptions{Budget: cfg.Cache.Budget, ReposOnly: true})
+		if err == nil {
+			if len(result.Removed) > 0 {
+				fmt.Printf("\nTrimmed %d clone(s) to fit the %s cache budget, freeing %s\n",
+					len(result.Removed), formatSize(cfg.Cache.Budget), formatSize(result.Freed()))
+			}
+			return
+		}
+	}
+	fmt.Printf("\n   Cache budget warning: %v\n", err)
+}
+
+// formatAccess describes when a clone was last fetched.
+func formatAccess(r cache.Repo) string {
+	if r.LastAccess.IsZero() {
+		return "unknown"
+	}
+	return r.LastAccess.Format("2006-01-02")
+}
+
+// formatSize formats a byte count with binary units.
+func formatSize(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit && exp < 3; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMGT"[exp])
+}
@@ -0,0 +1,25 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCacheCmd_Structure(t *testing.T) {
+	assert.Equal(t, "cache", cacheCmd.Use)
+	var names []string
+	for _, sub := range cacheCmd.Commands() {
+		names = append(names, sub.Name())
+	}
+	assert.ElementsMatch(t, []string{"stats", "gc"}, names)
+	assert.NotNil(t, cacheGCCmd.Flags().Lookup("budget"))
+	assert.NotNil(t, cacheGCCmd.Flags().Lookup("dry-run"))
+}
+
+func TestFormatSize(t *testing.T) {
+	assert.Equal(t, "512 B", formatSize(512))
+	assert.Equal(t, "1.5 KB", formatSize(1536))
+	assert.Equal(t, "2.0 GB", formatSize(2<<30))
+	assert.Equal(t, "3.0 TB", formatSize(3<<40))
+}
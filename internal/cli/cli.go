@@ -57,19 +57,31 @@ Telemetry:
 
 func init() {
 	rootCmd.AddCommand(addCmd)
+	rootCmd.AddCommand(authCmd)
+	rootCmd.AddCommand(bundleCmd)
+	rootCmd.AddCommand(cacheCmd)
 	rootCmd.AddCommand(checkCmd)
 	rootCmd.AddCommand(discoverCmd)
+	rootCmd.AddCommand(exportCmd)
 	rootCmd.AddCommand(favoritesCmd)
 	rootCmd.AddCommand(feedbackCmd)
+	rootCmd.AddCommand(historyCmd)
+	rootCmd.AddCommand(importCmd)
 	rootCmd.AddCommand(infoCmd)
 	rootCmd.AddCommand(ingestCmd)
 	rootCmd.AddCommand(installCmd)
 	rootCmd.AddCommand(listCmd)
+	rootCmd.AddCommand(logCmd)
+	rootCmd.AddCommand(manifestCmd)
+	rootCmd.AddCommand(prefsCmd)
+	rootCmd.AddCommand(profileCmd)
 	rootCmd.AddCommand(pullCmd)
+	rootCmd.AddCommand(registryCmd)
 	rootCmd.AddCommand(removeCmd)
 	rootCmd.AddCommand(saveCmd)
 	rootCmd.AddCommand(scanCmd)
 	rootCmd.AddCommand(syncCmd)
+	rootCmd.AddCommand(undoCmd)
 	rootCmd.AddCommand(uninstallCmd)
 	rootCmd.AddCommand(updateCmd)
 }
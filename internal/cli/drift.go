@@ -0,0 +1,227 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/asteroid-belt/skulto/internal/db"
+	"github.com/asteroid-belt/skulto/internal/manifest"
+	"github.com/asteroid-belt/skulto/internal/models"
+)
+
+// DriftItem is one skill that differs from skulto.json.
+type DriftItem struct {
+	Slug      string   `json:"slug"`
+	Source    string   `json:"source,omitempty"`
+	Platforms []string `json:"platforms,omitempty"`
+	Reason    string   `json:"reason"`
+}
+
+// DriftReport compares a project's installed skills with its manifest.
+type DriftReport struct {
+	Manifest  string      `json:"manifest"`
+	InSync    bool        `json:"in_sync"`
+	Missing   []DriftItem `json:"missing"`     // In the manifest, not installed
+	Extra     []DriftItem `json:"extra"`       // Installed, not in the manifest or ignored
+	OutOfDate []DriftItem `json:"out_of_date"` // Installed, but not what the manifest or lock asks for
+}
+
+// Count returns the number of drifted skills.
+func (r *DriftReport) Count() int {
+	return len(r.Missing) + len(r.Extra) + len(r.OutOfDate)
+}
+
+// detectDrift compares the manifest (and lockfile, if any) against the
+// project's installation records and the symlinks on disk. It changes nothing.
+// Installations whose symlink is gone or dangling do not count as installed.
+// Optional skills are never reported missing.
+func detectDrift(database *db.DB, cwd string, mf *manifest.ManifestFile, lf *manifest.LockFile) (*DriftReport, error) {
+	report := &DriftReport{
+		Manifest:  manifest.Path(cwd),
+		Missing:   []DriftItem{},
+		Extra:     []DriftItem{},
+		OutOfDate: []DriftItem{},
+	}
+
+	rows, err := database.GetProjectInstallations(cwd)
+	if err != nil {
+		return nil, fmt.Errorf("query installations: %w", err)
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		all, err := database.GetAllInstallations()
+		if err != nil {
+			return nil, fmt.Errorf("query installations: %w", err)
+		}
+		for _, row := range all {
+			if row.Scope == "global" && row.BasePath == home {
+				rows = append(rows, row)
+			}
+		}
+	}
+
+	// Group healthy installations by slug
+	type installed struct {
+		skill     *models.Skill
+		rows      []models.SkillInstallation
+		platforms map[string]bool
+	}
+	bySlug := make(map[string]*installed)
+	var broken = make(map[string][]string) // slug -> dangling symlink paths
+	for _, row := range rows {
+		skill, err := database.GetSkill(row.SkillID)
+		if err != nil || skill == nil {
+			continue
+		}
+		if !symlinkHealthy(row.SymlinkPath) {
+			broken[skill.Slug] = append(broken[skill.Slug], row.SymlinkPath)
+			continue
+		}
+		entry := bySlug[skill.Slug]
+		if entry == nil {
+			entry = &installed{skill: skill, platforms: make(map[string]bool)}
+			bySlug[skill.Slug] = entry
+		}
+		entry.rows = append(entry.rows, row)
+		entry.platforms[row.Platform] = true
+	}
+
+	for _, slug := range mf.SortedSlugs() {
+		source := mf.Skills[slug]
+		opts := mf.SkillOptions(slug)
+		entry := bySlug[slug]
+
+		if entry == nil {
+			if opts.Optional {
+				continue
+			}
+			reason := "not installed"
+			if paths := broken[slug]; len(paths) > 0 {
+				reason = "symlink missing or broken: " + strings.Join(paths, ", ")
+			}
+			report.Missing = append(report.Missing, DriftItem{Slug: slug, Source: source, Platforms: opts.Platforms, Reason: reason})
+			continue
+		}
+
+		var absent []string
+		for _, p := range opts.Platforms {
+			if !entry.platforms[p] {
+				absent = append(absent, p)
+			}
+		}
+		if len(absent) > 0 && !opts.Optional {
+			report.Missing = append(report.Missing, DriftItem{Slug: slug, Source: source, Platforms: absent, Reason: "not installed for " + strings.Join(absent, ", ")})
+		}
+
+		if reason := outOfDateReason(entry.skill, entry.rows, source, opts, mf, lf); reason != "" {
+			report.OutOfDate = append(report.OutOfDate, DriftItem{Slug: slug, Source: source, Reason: reason})
+		}
+	}
+
+	ignored := make(map[string]bool, len(mf.Ignored))
+	for _, slug := range mf.Ignored {
+		ignored[slug] = true
+	}
+	for slug, entry := range bySlug {
+		if _, listed := mf.Skills[slug]; listed || ignored[slug] {
+			continue
+		}
+		// Only project installs in this directory belong to the manifest
+		var platforms []string
+		for _, row := range entry.rows {
+			if row.Scope == "project" {
+				platforms = append(platforms, row.Platform)
+			}
+		}
+		if len(platforms) == 0 {
+			continue
+		}
+		sort.Strings(platforms)
+		item := DriftItem{Slug: slug, Platforms: platforms, Reason: "not in skulto.json"}
+		if entry.skill.Source != nil {
+			item.Source = entry.skill.Source.FullName
+		}
+		report.Extra = append(report.Extra, item)
+	}
+	sort.Slice(report.Extra, func(i, j int) bool { return report.Extra[i].Slug < report.Extra[j].Slug })
+
+	report.InSync = report.Count() == 0
+	return report, nil
+}
+
+// outOfDateReason explains why an installed skill does not match its
+// manifest entry or lock entry, or returns "".
+func outOfDateReason(skill *models.Skill, rows []models.SkillInstallation, source string, opts manifest.SkillOptions, mf *manifest.ManifestFile, lf *manifest.LockFile) string {
+	if skill.Source != nil && skill.Source.FullName != source {
+		return fmt.Sprintf("installed from %s", skill.Source.FullName)
+	}
+	if err := checkSkillVersion(skill, opts.Version); err != nil {
+		return err.Error()
+	}
+	locked, ok := lockedEntry(mf, lf, skill.Slug)
+	if !ok {
+		return ""
+	}
+	for _, row := range rows {
+		target, err := filepath.EvalSymlinks(row.SymlinkPath)
+		if err != nil {
+			continue
+		}
+		if err := locked.Verify(skill.Slug, target); err != nil {
+			return fmt.Sprintf("content differs from skulto.lock (locked %s)", shortSHA(locked.Commit))
+		}
+	}
+	return ""
+}
+
+// symlinkHealthy reports whether path is a symlink whose target exists.
+func symlinkHealthy(path string) bool {
+	if path == "" {
+		return false
+	}
+	info, err := os.Lstat(path)
+	if err != nil || info.Mode()&os.ModeSymlink == 0 {
+		return false
+	}
+	_, err = os.Stat(path)
+	return err == nil
+}
+
+// printDriftReport renders a drift report as text, or as indented JSON on stdout.
+func printDriftReport(report *DriftReport, asJSON bool) error {
+	if asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	}
+
+	fmt.Printf("%s (%s)\n", planHeaderStyle.Render("CHECK"), report.Manifest)
+	fmt.Println(strings.Repeat("─", 50))
+	if report.InSync {
+		fmt.Println(cleanStyle.Render("✓ Installed skills match skulto.json"))
+		return nil
+	}
+
+	section := func(title, marker string, render func(...string) string, items []DriftItem) {
+		if len(items) == 0 {
+			return
+		}
+		fmt.Printf("\n%s (%d):\n", title, len(items))
+		for _, item := range items {
+			fmt.Printf("  %s %-28s %s\n", render(marker), item.Slug, planMutedStyle.Render(item.Reason))
+		}
+	}
+	section("Missing", "+", planCreateStyle.Render, report.Missing)
+	section("Extra", "-", planRemoveStyle.Render, report.Extra)
+	section("Out of date", "~", planChangeStyle.Render, report.OutOfDate)
+
+	fmt.Printf("\n%d skill(s) out of sync. Run 'skulto sync' to install missing skills", report.Count())
+	if len(report.Extra) > 0 {
+		fmt.Print(", or 'skulto save' to add extras to the manifest")
+	}
+	fmt.Println(".")
+	return nil
+}
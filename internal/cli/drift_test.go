@@ -0,0 +1,98 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/asteroid-belt/skulto/internal/manifest"
+	"github.com/asteroid-belt/skulto/internal/models"
+)
+
+func TestDetectDrift(t *testing.T) {
+	database := testDB(t)
+	project := t.TempDir()
+	store := t.TempDir()
+
+	require.NoError(t, database.CreateSource(&models.Source{ID: "acme/skills", Owner: "acme", Repo: "skills", FullName: "acme/skills"}))
+	sourceID := "acme/skills"
+
+	// install creates a skill, its source directory and a project symlink to it.
+	install := func(slug string, link bool) string {
+		require.NoError(t, database.CreateSkill(&models.Skill{ID: slug + "-id", Slug: slug, Title: slug, SourceID: &sourceID, Version: "1.0.0"}))
+		dir := filepath.Join(store, slug)
+		require.NoError(t, os.MkdirAll(dir, 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte("# "+slug), 0644))
+		symlink := filepath.Join(project, ".claude", "skills", slug)
+		if link {
+			require.NoError(t, os.MkdirAll(filepath.Dir(symlink), 0755))
+			require.NoError(t, os.Symlink(dir, symlink))
+		}
+		require.NoError(t, database.AddInstallation(&models.SkillInstallation{
+			SkillID: slug + "-id", Platform: "claude", Scope: "project", BasePath: project, SymlinkPath: symlink,
+		}))
+		return dir
+	}
+
+	install("ok", true)
+	install("broken", false)
+	install("extra", true)
+	install("ignored", true)
+	install("old", true)
+	lockedDir := install("locked", true)
+
+	mf := manifest.New()
+	for _, slug := range []string{"ok", "broken", "old", "locked", "absent", "maybe"} {
+		mf.Skills[slug] = "acme/skills"
+	}
+	mf.Options["old"] = manifest.SkillOptions{Version: ">=2.0.0"}
+	mf.Options["maybe"] = manifest.SkillOptions{Optional: true}
+	mf.Ignored = []string{"ignored"}
+
+	hash, err := manifest.HashSkillDir(lockedDir)
+	require.NoError(t, err)
+	lf := manifest.NewLock()
+	lf.Skills["locked"] = manifest.LockedSkill{Source: "acme/skills", Hash: hash}
+
+	report, err := detectDrift(database, project, mf, lf)
+	require.NoError(t, err)
+	assert.False(t, report.InSync)
+	assert.Equal(t, []string{"absent", "broken"}, driftSlugs(report.Missing))
+	assert.Contains(t, report.Missing[1].Reason, "broken")
+	assert.Equal(t, []string{"extra"}, driftSlugs(report.Extra))
+	assert.Equal(t, []string{"old"}, driftSlugs(report.OutOfDate))
+
+	// Changing a locked skill's files makes it out of date
+	require.NoError(t, os.WriteFile(filepath.Join(lockedDir, "SKILL.md"), []byte("changed"), 0644))
+	report, err = detectDrift(database, project, mf, lf)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"locked", "old"}, driftSlugs(report.OutOfDate))
+}
+
+func TestDetectDrift_InSync(t *testing.T) {
+	database := testDB(t)
+	report, err := detectDrift(database, t.TempDir(), manifest.New(), nil)
+	require.NoError(t, err)
+	assert.True(t, report.InSync)
+	assert.Equal(t, 0, report.Count())
+}
+
+func TestExitCode(t *testing.T) {
+	assert.Equal(t, ExitOK, ExitCode(nil))
+	assert.Equal(t, ExitError, ExitCode(errors.New("boom")))
+	drift := &exitError{code: ExitDrift, err: errors.New("drift")}
+	assert.Equal(t, ExitDrift, ExitCode(fmt.Errorf("wrapped: %w", drift)))
+}
+
+func driftSlugs(items []DriftItem) []string {
+	slugs := make([]string, 0, len(items))
+	for _, item := range items {
+		slugs = append(slugs, item.Slug)
+	}
+	return slugs
+}
@@ -0,0 +1,32 @@
+package cli
+
+import "errors"
+
+// Exit codes returned by the skulto binary.
+const (
+	ExitOK    = 0 // Success
+	ExitError = 1 // Any failure
+	ExitDrift = 2 // sync --check found skills out of sync with skulto.json
+)
+
+// exitError carries a specific process exit code out of Execute.
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e *exitError) Error() string { return e.err.Error() }
+
+func (e *exitError) Unwrap() error { return e.err }
+
+// ExitCode returns the process exit code for an error returned by Execute.
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+	var ee *exitError
+	if errors.As(err, &ee) {
+		return ee.code
+	}
+	return ExitError
+}
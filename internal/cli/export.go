@@ -0,0 +1,203 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/asteroid-belt/skulto/internal/config"
+	"github.com/asteroid-belt/skulto/internal/db"
+	"github.com/asteroid-belt/skulto/internal/favorites"
+	"github.com/asteroid-belt/skulto/internal/models"
+	"github.com/spf13/cobra"
+)
+
+// SetupExportVersion is the current `skulto export` format version.
+const SetupExportVersion = 1
+
+// SetupExport is everything `skulto import` needs to recreate a user's setup
+// on another machine. Paths under the home directory are stored as "~/...".
+type SetupExport struct {
+	Version            int                        `json:"version"`
+	ExportedAt         time.Time                  `json:"exported_at"`
+	Sources            []ExportedSource           `json:"sources"`
+	Favorites          []favorites.Favorite       `json:"favorites"`
+	Agents             []models.AgentPreference   `json:"agents"`
+	Settings           ExportedSettings           `json:"settings"`
+	ProjectPreferences []models.ProjectPreference `json:"project_preferences"`
+	Profiles           []models.Profile           `json:"profiles"`
+	GlobalInstalls     []ExportedInstall          `json:"global_installs"`
+}
+
+// ExportedSource is a source repository to re-add.
+type ExportedSource struct {
+	ID       string `json:"id"`                  // owner/repo
+	URL      string `json:"url,omitempty"`       // Repository URL, if known
+	CloneURL string `json:"clone_url,omitempty"` // Clone URL, e.g. an ssh remote
+	Ref      string `json:"ref,omitempty"`       // Branch or tag followed instead of the default
+	Path     string `json:"path,omitempty"`      // Subdirectory scanned for skills
+}
+
+// ExportedSettings are the user_state toggles worth carrying over.
+type ExportedSettings struct {
+	SkipUninstallConfirm     bool `json:"skip_uninstall_confirm"`
+	RememberInstallLocations bool `json:"remember_install_locations"`
+}
+
+// ExportedInstall is a globally installed skill on one platform.
+type ExportedInstall struct {
+	Slug     string `json:"slug"`
+	Source   string `json:"source,omitempty"` // owner/repo; empty for local skills
+	Platform string `json:"platform"`
+}
+
+var exportCmd = &cobra.Command{
+	Use:   "export [file]",
+	Short: "Export sources, favorites, preferences and global installs",
+	Long: `Export your whole skulto setup to a single JSON file: source repositories,
+favorites, agent preferences, remembered install locations, profiles,
+settings and globally installed skills.
+
+Replay it on another machine with 'skulto import'. Without a file argument
+the export is written to stdout.
+
+Examples:
+  skulto export skulto-setup.json
+  skulto export > skulto-setup.json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runExport,
+}
+
+func runExport(cmd *cobra.Command, args []string) error {
+	cfg, database, err := openCommandDB("export")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = database.Close() }()
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return trackCLIError("export", fmt.Errorf("get home directory: %w", err))
+	}
+	store := favorites.NewStore(config.GetPaths(cfg).Favorites)
+	if err := store.Load(); err != nil {
+		return trackCLIError("export", fmt.Errorf("load favorites: %w", err))
+	}
+
+	setup, err := buildSetupExport(database, store, home)
+	if err != nil {
+		return trackCLIError("export", err)
+	}
+	data, err := json.MarshalIndent(setup, "", "  ")
+	if err != nil {
+		return trackCLIError("export", fmt.Errorf("marshal export: %w", err))
+	}
+	data = append(data, '\n')
+
+	if len(args) == 0 {
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+	if err := os.WriteFile(args[0], data, 0600); err != nil {
+		return trackCLIError("export", fmt.Errorf("write %s: %w", args[0], err))
+	}
+	fmt.Printf("%s %s: %d source(s), %d favorite(s), %d global install(s)\n",
+		cleanStyle.Render("✓ Exported"), args[0], len(setup.Sources), len(setup.Favorites), len(setup.GlobalInstalls))
+	return nil
+}
+
+// buildSetupExport collects the exportable state from the database and the
+// favorites store.
+func buildSetupExport(database *db.DB, store *favorites.Store, home string) (*SetupExport, error) {
+	setup := &SetupExport{
+		Version:    SetupExportVersion,
+		ExportedAt: time.Now().UTC(),
+		Favorites:  store.List(),
+	}
+
+	sources, err := database.ListSources()
+	if err != nil {
+		return nil, fmt.Errorf("list sources: %w", err)
+	}
+	for _, s := range sources {
+		// Local directory sources don't exist on other machines
+		if s.LocalDir() != "" {
+			continue
+		}
+		setup.Sources = append(setup.Sources, ExportedSource{ID: s.ID, URL: s.URL, CloneURL: s.CloneURL, Ref: s.Ref, Path: s.SkillPath})
+	}
+
+	if setup.Agents, err = database.GetAgentPreferences(); err != nil {
+		return nil, fmt.Errorf("get agent preferences: %w", err)
+	}
+
+	state, err := database.GetUserState()
+	if err != nil {
+		return nil, fmt.Errorf("get user state: %w", err)
+	}
+	setup.Settings = ExportedSettings{
+		SkipUninstallConfirm:     state.SkipUninstallConfirm,
+		RememberInstallLocations: state.RememberInstallLocations,
+	}
+
+	prefs, err := database.ListProjectPreferences()
+	if err != nil {
+		return nil, fmt.Errorf("list project preferences: %w", err)
+	}
+	for _, p := range prefs {
+		p.Path = collapseHome(p.Path, home)
+		setup.ProjectPreferences = append(setup.ProjectPreferences, p)
+	}
+
+	if setup.Profiles, err = database.ListProfiles(); err != nil {
+		return nil, fmt.Errorf("list profiles: %w", err)
+	}
+
+	rows, err := database.GetAllInstallations()
+	if err != nil {
+		return nil, fmt.Errorf("get installations: %w", err)
+	}
+	seen := make(map[ExportedInstall]bool)
+	for _, row := range rows {
+		if row.Scope != "global" || row.BasePath != home {
+			continue
+		}
+		skill, err := database.GetSkill(row.SkillID)
+		if err != nil || skill == nil {
+			continue
+		}
+		inst := ExportedInstall{Slug: skill.Slug, Platform: row.Platform}
+		if skill.SourceID != nil {
+			inst.Source = *skill.SourceID
+		}
+		if !seen[inst] {
+			seen[inst] = true
+			setup.GlobalInstalls = append(setup.GlobalInstalls, inst)
+		}
+	}
+	sort.Slice(setup.GlobalInstalls, func(i, j int) bool {
+		a, b := setup.GlobalInstalls[i], setup.GlobalInstalls[j]
+		if a.Slug != b.Slug {
+			return a.Slug < b.Slug
+		}
+		return a.Platform < b.Platform
+	})
+
+	return setup, nil
+}
+
+// collapseHome rewrites a path under home as "~/...", so exports move
+// between machines with different home directories.
+func collapseHome(path, home string) string {
+	if path == home {
+		return "~"
+	}
+	if rel, err := filepath.Rel(home, path); err == nil && rel != ".." && !strings.HasPrefix(rel, "../") {
+		return "~/" + filepath.ToSlash(rel)
+	}
+	return path
+}
@@ -0,0 +1,146 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/asteroid-belt/skulto/internal/favorites"
+	"github.com/asteroid-belt/skulto/internal/installer"
+	"github.com/asteroid-belt/skulto/internal/models"
+	"github.com/asteroid-belt/skulto/internal/scraper"
+)
+
+func TestSetupExportImport_RoundTrip(t *testing.T) {
+	setupTestTelemetry()
+	home := "/home/old"
+
+	// Source machine
+	src := testDB(t)
+	require.NoError(t, src.UpsertSource(&models.Source{ID: "acme/skills", Owner: "acme", Repo: "skills", URL: "https://github.com/acme/skills"}))
+	require.NoError(t, src.UpsertAgentPreference(&models.AgentPreference{AgentID: "claude", Enabled: true, Detected: true, PreferredScope: "project"}))
+	require.NoError(t, src.SetRememberInstallLocations(true))
+	pref := &models.ProjectPreference{Path: filepath.Join(home, "code", "app"), Scope: "project"}
+	pref.SetPlatforms([]string{"claude", "cursor"})
+	require.NoError(t, src.SetProjectPreference(pref))
+	profile := &models.Profile{Name: "backend", Scope: "project"}
+	profile.SetSkills([]string{"go-patterns"})
+	require.NoError(t, src.UpsertProfile(profile))
+
+	sourceID := "acme/skills"
+	skill := &models.Skill{ID: "skill-1", Slug: "go-patterns", Title: "Go Patterns", SourceID: &sourceID}
+	require.NoError(t, src.CreateSkill(skill))
+	require.NoError(t, src.AddInstallation(&models.SkillInstallation{SkillID: skill.ID, Platform: "claude", Scope: "global", BasePath: home}))
+	require.NoError(t, src.AddInstallation(&models.SkillInstallation{SkillID: skill.ID, Platform: "cursor", Scope: "project", BasePath: "/work"}))
+
+	srcFavs := favorites.NewStore(filepath.Join(t.TempDir(), "favorites.json"))
+	require.NoError(t, srcFavs.Add("go-patterns"))
+
+	setup, err := buildSetupExport(src, srcFavs, home)
+	require.NoError(t, err)
+	assert.Equal(t, "~/code/app", setup.ProjectPreferences[0].Path)
+	assert.Equal(t, []ExportedInstall{{Slug: "go-patterns", Source: "acme/skills", Platform: "claude"}}, setup.GlobalInstalls)
+
+	data, err := json.Marshal(setup)
+	require.NoError(t, err)
+	var decoded SetupExport
+	require.NoError(t, json.Unmarshal(data, &decoded))
+
+	// Destination machine
+	dst := testDB(t)
+	cfg := testConfig(t)
+	dstFavs := favorites.NewStore(filepath.Join(t.TempDir(), "favorites.json"))
+	service := installer.NewInstallService(dst, cfg, telemetryClient)
+	newHome := "/home/new"
+
+	result, err := applySetupImport(context.Background(), cfg, dst, dstFavs, service, &decoded, newHome, false)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.SourcesAdded)
+	assert.Equal(t, 1, result.Favorites)
+
+	source, err := dst.GetSource("acme/skills")
+	require.NoError(t, err)
+	require.NotNil(t, source)
+	assert.True(t, dstFavs.IsFavorite("go-patterns"))
+
+	agents, err := dst.GetAgentPreferences()
+	require.NoError(t, err)
+	require.Len(t, agents, 1)
+	assert.True(t, agents[0].Enabled)
+	assert.False(t, agents[0].Detected, "detection state is per machine")
+
+	remember, err := dst.GetRememberInstallLocations()
+	require.NoError(t, err)
+	assert.True(t, remember)
+
+	got, err := dst.GetProjectPreference(filepath.Join(newHome, "code", "app"))
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, []string{"claude", "cursor"}, got.GetPlatforms())
+
+	gotProfile, err := dst.GetProfile("backend")
+	require.NoError(t, err)
+	require.NotNil(t, gotProfile)
+
+	// A second import changes nothing
+	result, err = applySetupImport(context.Background(), cfg, dst, dstFavs, service, &decoded, newHome, false)
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.SourcesAdded)
+	assert.Equal(t, 0, result.Favorites)
+	assert.Equal(t, 1, result.AlreadyPresent)
+	assert.Len(t, dstFavs.List(), 1)
+}
+
+func TestCollapseExpandHome(t *testing.T) {
+	home := "/home/me"
+	assert.Equal(t, "~", collapseHome(home, home))
+	assert.Equal(t, "~/code/app", collapseHome("/home/me/code/app", home))
+	assert.Equal(t, "/home/meow/app", collapseHome("/home/meow/app", home))
+	assert.Equal(t, "/srv/app", collapseHome("/srv/app", home))
+
+	assert.Equal(t, "/home/you/code/app", expandHome("~/code/app", "/home/you"))
+	assert.Equal(t, "/home/you", expandHome("~", "/home/you"))
+	assert.Equal(t, "/srv/app", expandHome("/srv/app", "/home/you"))
+}
+
+func TestApplySetupImport_RetriesSourcesWithoutSkills(t *testing.T) {
+	setupTestTelemetry()
+
+	upstreamDir := t.TempDir()
+	upstream, err := git.PlainInit(upstreamDir, false)
+	require.NoError(t, err)
+	commitSkill(t, upstream, upstreamDir, "---\nname: teach\ndescription: Teach things\n---\n# Teach\n")
+
+	// A local directory source scrapes without the network
+	local, err := scraper.ParseLocalSource(upstreamDir)
+	require.NoError(t, err)
+	setup := &SetupExport{Version: SetupExportVersion, Sources: []ExportedSource{{ID: local.ID, URL: upstreamDir}}}
+	database := testDB(t)
+	cfg := testConfig(t)
+	favs := favorites.NewStore(filepath.Join(t.TempDir(), "favorites.json"))
+	service := installer.NewInstallService(database, cfg, telemetryClient)
+
+	// --no-sync only records the source
+	result, err := applySetupImport(context.Background(), cfg, database, favs, service, setup, t.TempDir(), false)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.SourcesAdded)
+
+	// Importing again with sync scrapes it instead of skipping it
+	result, err = applySetupImport(context.Background(), cfg, database, favs, service, setup, t.TempDir(), true)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.SourcesAdded)
+	assert.Equal(t, 0, result.AlreadyPresent)
+	skills, err := database.GetSkillsBySourceID(local.ID)
+	require.NoError(t, err)
+	assert.NotEmpty(t, skills)
+
+	// Once it has skills, it is present
+	result, err = applySetupImport(context.Background(), cfg, database, favs, service, setup, t.TempDir(), true)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.AlreadyPresent)
+}
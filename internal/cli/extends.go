@@ -0,0 +1,202 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/asteroid-belt/skulto/internal/config"
+	"github.com/asteroid-belt/skulto/internal/db"
+	"github.com/asteroid-belt/skulto/internal/manifest"
+	"github.com/asteroid-belt/skulto/internal/scraper"
+)
+
+// maxExtendsDepth bounds chains of manifests extending each other.
+const maxExtendsDepth = 8
+
+// resolveExtends returns mf merged over the manifest it extends (and that
+// manifest's own base, recursively). Local entries and ignore rules win over
+// inherited ones. Repository bases are read from the shared clone cache;
+// with fetch, the clone is refreshed first, falling back to the cached copy
+// when offline. Without fetch, only the cache is used. Bases pinned to a ref
+// are read from the clone's git objects, leaving its working tree alone.
+func resolveExtends(ctx context.Context, cfg *config.Config, dir string, mf *manifest.ManifestFile, fetch bool) (*manifest.ManifestFile, error) {
+	if mf == nil || mf.Extends == "" {
+		return mf, nil
+	}
+	repoManager := scraper.NewRepositoryManagerFromConfig(cfg)
+	// Bases in registered sources are cloned the way the source is
+	if database, err := db.New(db.DefaultConfig(config.GetPaths(cfg).Database)); err == nil {
+		if sources, err := database.ListSources(); err == nil {
+			for i := range sources {
+				repoManager.ConfigureSource(&sources[i])
+			}
+		}
+		_ = database.Close()
+	}
+	return resolveExtendsChain(ctx, repoManager, extendsDir{path: dir}, mf, fetch, make(map[string]bool))
+}
+
+// extendsDir is where the relative extends in a manifest resolve: a
+// directory on disk or, for a manifest read from a commit, a directory in
+// that commit.
+type extendsDir struct {
+	path string               // Directory on disk, or slash path within the repository
+	at   *manifest.ExtendsRef // Repository and commit the manifest was read from
+}
+
+func resolveExtendsChain(
+	ctx context.Context,
+	repoManager *scraper.RepositoryManager,
+	dir extendsDir,
+	mf *manifest.ManifestFile,
+	fetch bool,
+	seen map[string]bool,
+) (*manifest.ManifestFile, error) {
+	if mf.Extends == "" {
+		return mf, nil
+	}
+	if len(seen) >= maxExtendsDepth {
+		return nil, fmt.Errorf("extends chain is deeper than %d manifests", maxExtendsDepth)
+	}
+
+	ref, err := manifest.ParseExtends(mf.Extends)
+	if err != nil {
+		return nil, err
+	}
+	base, baseDir, key, err := loadExtended(ctx, repoManager, dir, ref, fetch)
+	if err != nil {
+		return nil, fmt.Errorf("extends %s: %w", mf.Extends, err)
+	}
+	if seen[key] {
+		return nil, fmt.Errorf("extends %s: cycle detected", mf.Extends)
+	}
+	seen[key] = true
+
+	base, err = resolveExtendsChain(ctx, repoManager, baseDir, base, fetch, seen)
+	if err != nil {
+		return nil, err
+	}
+	merged := manifest.Inherit(base, mf)
+	merged.Workspace = mf.Workspace
+	merged.Extends = mf.Extends
+	return merged, nil
+}
+
+// loadExtended reads the manifest named by ref. It returns the manifest,
+// where relative references inside it resolve, and a key identifying it
+// for cycle detection.
+func loadExtended(ctx context.Context, repoManager *scraper.RepositoryManager, dir extendsDir, ref manifest.ExtendsRef, fetch bool) (*manifest.ManifestFile, extendsDir, string, error) {
+	// Relative paths in a manifest read from a commit stay in that commit
+	if ref.IsLocal() && dir.at != nil && strings.HasPrefix(ref.Path, ".") {
+		p := path.Join(dir.path, ref.Path)
+		if p == ".." || strings.HasPrefix(p, "../") {
+			return nil, extendsDir{}, "", fmt.Errorf("%s is outside %s/%s", ref.Path, dir.at.Owner, dir.at.Repo)
+		}
+		ref = manifest.ExtendsRef{Owner: dir.at.Owner, Repo: dir.at.Repo, Path: p, Ref: dir.at.Ref}
+	}
+	if !ref.IsLocal() && ref.Ref != "" {
+		return loadExtendedAt(ctx, repoManager, ref, fetch)
+	}
+
+	var file string
+	if ref.IsLocal() {
+		file = ref.Path
+		if strings.HasPrefix(file, "~") {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				return nil, extendsDir{}, "", err
+			}
+			file = filepath.Join(home, strings.TrimPrefix(file, "~"))
+		} else if !filepath.IsAbs(file) {
+			file = filepath.Join(dir.path, file)
+		}
+	} else {
+		clone, err := extendsClone(ctx, repoManager, ref, fetch)
+		if err != nil {
+			return nil, extendsDir{}, "", err
+		}
+		file = filepath.Join(clone, filepath.FromSlash(ref.Path))
+	}
+
+	file, err := filepath.Abs(file)
+	if err != nil {
+		return nil, extendsDir{}, "", err
+	}
+	base, err := manifest.ReadFile(file)
+	if err != nil {
+		return nil, extendsDir{}, "", err
+	}
+	if base == nil {
+		return nil, extendsDir{}, "", fmt.Errorf("%s not found", file)
+	}
+	return base, extendsDir{path: filepath.Dir(file)}, file, nil
+}
+
+// loadExtendedAt reads a repository manifest as of ref from the clone's git
+// objects (like 'git show ref:path'), so the shared clone stays on the
+// revision other installs use. With fetch, the ref is fetched first; a
+// failed fetch of an existing clone is a warning, so syncs keep working
+// offline.
+func loadExtendedAt(ctx context.Context, repoManager *scraper.RepositoryManager, ref manifest.ExtendsRef, fetch bool) (*manifest.ManifestFile, extendsDir, string, error) {
+	_, statErr := os.Stat(filepath.Join(repoManager.GetRepoPath(ref.Owner, ref.Repo), ".git"))
+	cached := statErr == nil
+	if !fetch && !cached {
+		return nil, extendsDir{}, "", fmt.Errorf("%s/%s is not cloned yet; run 'skulto sync'", ref.Owner, ref.Repo)
+	}
+
+	if fetch {
+		sha, err := func() (string, error) {
+			if !cached {
+				if _, err := repoManager.CloneOrUpdate(ctx, ref.Owner, ref.Repo); err != nil {
+					return "", err
+				}
+			}
+			return repoManager.ResolveRef(ctx, ref.Owner, ref.Repo, ref.Ref)
+		}()
+		if err != nil {
+			if !cached {
+				return nil, extendsDir{}, "", err
+			}
+			fmt.Printf("  Warning: could not refresh %s/%s, using cached copy: %v\n", ref.Owner, ref.Repo, err)
+		} else {
+			ref.Ref = sha
+		}
+	}
+
+	data, err := repoManager.ReadFileAt(ref.Owner, ref.Repo, ref.Ref, ref.Path)
+	if err != nil {
+		return nil, extendsDir{}, "", err
+	}
+	base, err := manifest.Parse(data)
+	if err != nil {
+		return nil, extendsDir{}, "", err
+	}
+	return base, extendsDir{path: path.Dir(ref.Path), at: &ref}, ref.String(), nil
+}
+
+// extendsClone makes the repository named by ref available in the clone
+// cache and returns its path. A failed refresh of an existing clone is a
+// warning, so syncs keep working offline.
+func extendsClone(ctx context.Context, repoManager *scraper.RepositoryManager, ref manifest.ExtendsRef, fetch bool) (string, error) {
+	clone := repoManager.GetRepoPath(ref.Owner, ref.Repo)
+	_, statErr := os.Stat(filepath.Join(clone, ".git"))
+	cached := statErr == nil
+	if !fetch {
+		if !cached {
+			return "", fmt.Errorf("%s/%s is not cloned yet; run 'skulto sync'", ref.Owner, ref.Repo)
+		}
+		return clone, nil
+	}
+
+	if _, err := repoManager.CloneOrUpdate(ctx, ref.Owner, ref.Repo); err != nil {
+		if !cached {
+			return "", err
+		}
+		fmt.Printf("  Warning: could not refresh %s/%s, using cached copy: %v\n", ref.Owner, ref.Repo, err)
+	}
+	return clone, nil
+}
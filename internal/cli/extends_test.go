@@ -0,0 +1,187 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/asteroid-belt/skulto/internal/config"
+	"github.com/asteroid-belt/skulto/internal/db"
+	"github.com/asteroid-belt/skulto/internal/manifest"
+	"github.com/asteroid-belt/skulto/internal/models"
+)
+
+func writeManifest(t *testing.T, dir string, mf *manifest.ManifestFile) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(dir, 0755))
+	require.NoError(t, manifest.Write(dir, mf))
+}
+
+func TestResolveExtends_LocalChain(t *testing.T) {
+	cfg := testConfig(t)
+	root := t.TempDir()
+
+	org := manifest.New()
+	org.Skills["security"] = "acme/skills"
+	org.Skills["legacy"] = "acme/skills"
+	writeManifest(t, filepath.Join(root, "org"), org)
+
+	team := manifest.New()
+	team.Extends = "../org/skulto.json"
+	team.Skills["frontend"] = "acme/skills"
+	writeManifest(t, filepath.Join(root, "team"), team)
+
+	project := manifest.New()
+	project.Extends = "../team/skulto.json"
+	project.Skills["frontend"] = "acme/fork"
+	project.Ignored = []string{"legacy"}
+
+	got, err := resolveExtends(context.Background(), cfg, filepath.Join(root, "project"), project, false)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"security": "acme/skills", "frontend": "acme/fork"}, got.Skills)
+	assert.Equal(t, "../team/skulto.json", got.Extends)
+}
+
+func TestResolveExtends_Cycle(t *testing.T) {
+	cfg := testConfig(t)
+	root := t.TempDir()
+
+	a := manifest.New()
+	a.Extends = "../b/skulto.json"
+	writeManifest(t, filepath.Join(root, "a"), a)
+	b := manifest.New()
+	b.Extends = "../a/skulto.json"
+	writeManifest(t, filepath.Join(root, "b"), b)
+
+	_, err := resolveExtends(context.Background(), cfg, filepath.Join(root, "a"), a, false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cycle")
+}
+
+func TestResolveExtends_RepositoryCache(t *testing.T) {
+	cfg := testConfig(t)
+
+	upstreamDir := t.TempDir()
+	upstream, err := git.PlainInit(upstreamDir, false)
+	require.NoError(t, err)
+	base := manifest.New()
+	base.Skills["baseline"] = "acme/skills"
+	writeManifest(t, filepath.Join(upstreamDir, "teams", "web"), base)
+	w, err := upstream.Worktree()
+	require.NoError(t, err)
+	_, err = w.Add("teams/web/skulto.json")
+	require.NoError(t, err)
+	commitSkill(t, upstream, upstreamDir, "# Teach\n")
+
+	clone := filepath.Join(cfg.BaseDir, "repositories", "acme", "baseline")
+	_, err = git.PlainClone(clone, false, &git.CloneOptions{URL: upstreamDir})
+	require.NoError(t, err)
+
+	project := manifest.New()
+	project.Extends = "acme/baseline//teams/web"
+
+	// Refreshing updates the clone from its origin
+	got, err := resolveExtends(context.Background(), cfg, t.TempDir(), project, true)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"baseline": "acme/skills"}, got.Skills)
+
+	// With the origin gone, the cached clone is still used
+	require.NoError(t, os.RemoveAll(upstreamDir))
+	got, err = resolveExtends(context.Background(), cfg, t.TempDir(), project, true)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"baseline": "acme/skills"}, got.Skills)
+
+	// A repository never synced is an error offline
+	project.Extends = "acme/missing"
+	_, err = resolveExtends(context.Background(), cfg, t.TempDir(), project, false)
+	assert.Error(t, err)
+}
+
+func TestResolveExtends_RegisteredSource(t *testing.T) {
+	cfg := testConfig(t)
+
+	// The default branch and a "stable" branch list different skills
+	upstreamDir := t.TempDir()
+	upstream, err := git.PlainInit(upstreamDir, false)
+	require.NoError(t, err)
+	w, err := upstream.Worktree()
+	require.NoError(t, err)
+	commitManifest := func(slug string) {
+		mf := manifest.New()
+		mf.Skills[slug] = "acme/skills"
+		writeManifest(t, upstreamDir, mf)
+		_, err := w.Add(manifest.FileName)
+		require.NoError(t, err)
+		_, err = w.Commit(slug, &git.CommitOptions{Author: &object.Signature{Name: "test", Email: "test@example.com"}})
+		require.NoError(t, err)
+	}
+	commitManifest("edge")
+	require.NoError(t, w.Checkout(&git.CheckoutOptions{Branch: plumbing.NewBranchReferenceName("stable"), Create: true}))
+	commitManifest("steady")
+
+	// Registered with its own clone URL and ref, as 'skulto add --ref' does
+	database, err := db.New(db.DefaultConfig(config.GetPaths(cfg).Database))
+	require.NoError(t, err)
+	require.NoError(t, database.CreateSource(&models.Source{
+		ID: "acme/baseline", Owner: "acme", Repo: "baseline", FullName: "acme/baseline",
+		CloneURL: upstreamDir, Ref: "stable",
+	}))
+	require.NoError(t, database.Close())
+
+	project := manifest.New()
+	project.Extends = "acme/baseline"
+	got, err := resolveExtends(context.Background(), cfg, t.TempDir(), project, true)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"steady": "acme/skills"}, got.Skills)
+}
+
+func TestResolveExtends_RefLeavesCloneAlone(t *testing.T) {
+	cfg := testConfig(t)
+
+	// The default branch and a "stable" branch list different skills
+	upstreamDir := t.TempDir()
+	upstream, err := git.PlainInit(upstreamDir, false)
+	require.NoError(t, err)
+	w, err := upstream.Worktree()
+	require.NoError(t, err)
+	commitManifest := func(slug string) {
+		mf := manifest.New()
+		mf.Skills[slug] = "acme/skills"
+		writeManifest(t, upstreamDir, mf)
+		_, err := w.Add(manifest.FileName)
+		require.NoError(t, err)
+		_, err = w.Commit(slug, &git.CommitOptions{Author: &object.Signature{Name: "test", Email: "test@example.com"}})
+		require.NoError(t, err)
+	}
+	commitManifest("edge")
+	require.NoError(t, w.Checkout(&git.CheckoutOptions{Branch: plumbing.NewBranchReferenceName("stable"), Create: true}))
+	commitManifest("steady")
+	require.NoError(t, w.Checkout(&git.CheckoutOptions{Branch: plumbing.Master}))
+
+	clone := filepath.Join(cfg.BaseDir, "repositories", "acme", "baseline")
+	_, err = git.PlainClone(clone, false, &git.CloneOptions{URL: upstreamDir})
+	require.NoError(t, err)
+
+	project := manifest.New()
+	project.Extends = "acme/baseline@stable"
+	got, err := resolveExtends(context.Background(), cfg, t.TempDir(), project, true)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"steady": "acme/skills"}, got.Skills)
+
+	// The shared clone still has the default branch checked out
+	onDisk, err := manifest.Read(clone)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"edge": "acme/skills"}, onDisk.Skills)
+
+	// Once fetched, the ref resolves offline too
+	got, err = resolveExtends(context.Background(), cfg, t.TempDir(), project, false)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"steady": "acme/skills"}, got.Skills)
+}
@@ -0,0 +1,259 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/asteroid-belt/skulto/internal/config"
+	"github.com/asteroid-belt/skulto/internal/db"
+	"github.com/asteroid-belt/skulto/internal/favorites"
+	"github.com/asteroid-belt/skulto/internal/installer"
+	"github.com/asteroid-belt/skulto/internal/scraper"
+	"github.com/spf13/cobra"
+)
+
+var importNoSync bool
+
+var importCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Import a setup written by 'skulto export'",
+	Long: `Recreate a setup exported with 'skulto export': re-add source repositories,
+restore favorites, agent preferences, remembered install locations, profiles
+and settings, and reinstall globally installed skills.
+
+Import is idempotent: sources that already exist are not re-added, and skills
+already installed at a location are left alone, so it is safe to run again
+after a partial import.
+
+Examples:
+  skulto import skulto-setup.json
+
+  # Restore preferences only; don't clone sources or install skills
+  skulto import skulto-setup.json --no-sync`,
+	Args: cobra.ExactArgs(1),
+	RunE: runImport,
+}
+
+func init() {
+	importCmd.Flags().BoolVar(&importNoSync, "no-sync", false, "Don't clone sources or install skills")
+}
+
+// importResult counts what an import changed.
+type importResult struct {
+	SourcesAdded   int
+	Favorites      int
+	Installed      int
+	AlreadyPresent int
+	Failed         int
+}
+
+func runImport(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	data, err := os.ReadFile(args[0])
+	if err != nil {
+		return trackCLIError("import", fmt.Errorf("read %s: %w", args[0], err))
+	}
+	var setup SetupExport
+	if err := json.Unmarshal(data, &setup); err != nil {
+		return trackCLIError("import", fmt.Errorf("parse %s: %w", args[0], err))
+	}
+	if setup.Version > SetupExportVersion {
+		return trackCLIError("import", fmt.Errorf("export version %d is newer than supported version %d; upgrade skulto", setup.Version, SetupExportVersion))
+	}
+
+	cfg, database, err := openCommandDB("import")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = database.Close() }()
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return trackCLIError("import", fmt.Errorf("get home directory: %w", err))
+	}
+	store := favorites.NewStore(config.GetPaths(cfg).Favorites)
+	if err := store.Load(); err != nil {
+		return trackCLIError("import", fmt.Errorf("load favorites: %w", err))
+	}
+	service := installer.NewInstallService(database, cfg, telemetryClient)
+
+	fmt.Printf("%s %s\n", planHeaderStyle.Render("IMPORTING"), args[0])
+	fmt.Println(strings.Repeat("─", 50))
+
+	result, err := applySetupImport(ctx, cfg, database, store, service, &setup, home, !importNoSync)
+	if err != nil {
+		return trackCLIError("import", err)
+	}
+
+	fmt.Println()
+	fmt.Println(strings.Repeat("─", 50))
+	fmt.Printf("Done! Sources added: %d, Favorites: %d, Installed: %d, Already present: %d",
+		result.SourcesAdded, result.Favorites, result.Installed, result.AlreadyPresent)
+	if result.Failed > 0 {
+		fmt.Printf(", Failed: %d", result.Failed)
+	}
+	fmt.Println()
+	if result.Failed > 0 {
+		return trackCLIError("import", fmt.Errorf("%d item(s) failed to import; fix them and re-run 'skulto import'", result.Failed))
+	}
+	return nil
+}
+
+// applySetupImport replays setup into the database and favorites store.
+// With fetch, new sources and sources without skills (left by an earlier
+// failed or --no-sync import) are cloned, and global installs are
+// reinstalled; without it, sources are only recorded. Individual source and install
+// failures are reported and counted rather than aborting the import.
+func applySetupImport(
+	ctx context.Context,
+	cfg *config.Config,
+	database *db.DB,
+	store *favorites.Store,
+	service *installer.InstallService,
+	setup *SetupExport,
+	home string,
+	fetch bool,
+) (*importResult, error) {
+	result := &importResult{}
+
+	var sc *scraper.Scraper
+	if fetch {
+		sc = scraper.NewScraperWithConfig(scraper.ScraperConfigFromConfig(cfg), database)
+	}
+	for _, s := range setup.Sources {
+		source, err := database.GetSource(s.ID)
+		if err != nil {
+			return nil, fmt.Errorf("check source %s: %w", s.ID, err)
+		}
+		if source != nil {
+			// A source left without skills by a failed or --no-sync import
+			// is scraped again rather than counted as present
+			skills, err := database.GetSkillsBySourceID(source.ID)
+			if err != nil {
+				return nil, fmt.Errorf("check source %s: %w", s.ID, err)
+			}
+			if sc == nil || len(skills) > 0 {
+				result.AlreadyPresent++
+				continue
+			}
+		} else {
+			url := s.CloneURL
+			if url == "" {
+				url = s.URL
+			}
+			if url == "" {
+				url = s.ID
+			}
+			source, err = scraper.ParseRepositoryURL(url)
+			if err != nil {
+				fmt.Printf("  %s source %s: %v\n", errorStyle.Render("✗"), s.ID, err)
+				result.Failed++
+				continue
+			}
+			source.Ref = s.Ref
+			source.SkillPath = s.Path
+			if err := database.UpsertSource(source); err != nil {
+				return nil, fmt.Errorf("add source %s: %w", s.ID, err)
+			}
+		}
+		if sc != nil {
+			syncCtx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+			_, err := sc.ScrapeRepository(syncCtx, source.Owner, source.Repo)
+			cancel()
+			if err != nil {
+				fmt.Printf("  %s source %s: %v\n", errorStyle.Render("✗"), s.ID, err)
+				result.Failed++
+				continue
+			}
+		}
+		fmt.Printf("  %s source %s\n", cleanStyle.Render("✓"), s.ID)
+		result.SourcesAdded++
+	}
+
+	for _, f := range setup.Favorites {
+		if store.IsFavorite(f.Slug) {
+			continue
+		}
+		if err := store.Add(f.Slug); err != nil {
+			return nil, fmt.Errorf("add favorite %s: %w", f.Slug, err)
+		}
+		result.Favorites++
+	}
+
+	for _, pref := range setup.Agents {
+		// Detection is per machine; keep whatever this machine detected
+		pref.Detected = false
+		pref.DetectedAt = nil
+		if err := database.UpsertAgentPreference(&pref); err != nil {
+			return nil, fmt.Errorf("set agent preference %s: %w", pref.AgentID, err)
+		}
+	}
+
+	if err := database.SetSkipUninstallConfirm(setup.Settings.SkipUninstallConfirm); err != nil {
+		return nil, fmt.Errorf("set settings: %w", err)
+	}
+	if err := database.SetRememberInstallLocations(setup.Settings.RememberInstallLocations); err != nil {
+		return nil, fmt.Errorf("set settings: %w", err)
+	}
+
+	for _, pref := range setup.ProjectPreferences {
+		pref.Path = expandHome(pref.Path, home)
+		if err := database.SetProjectPreference(&pref); err != nil {
+			return nil, fmt.Errorf("set project preference %s: %w", pref.Path, err)
+		}
+	}
+
+	for _, profile := range setup.Profiles {
+		if err := database.UpsertProfile(&profile); err != nil {
+			return nil, fmt.Errorf("save profile %s: %w", profile.Name, err)
+		}
+	}
+
+	if !fetch {
+		return result, nil
+	}
+	for _, inst := range setup.GlobalInstalls {
+		skill, err := database.GetSkillBySlugAndSource(inst.Slug, inst.Source)
+		if err == nil && skill == nil {
+			skill, err = database.GetSkillBySlug(inst.Slug)
+		}
+		if err != nil || skill == nil {
+			fmt.Printf("  %s %s (%s): skill not found\n", errorStyle.Render("✗"), inst.Slug, inst.Platform)
+			result.Failed++
+			continue
+		}
+		if ok, _ := database.IsInstalledAt(skill.ID, inst.Platform, string(installer.ScopeGlobal), home); ok {
+			result.AlreadyPresent++
+			continue
+		}
+		_, err = service.Install(ctx, inst.Slug, installer.InstallOptions{
+			Platforms: []string{inst.Platform},
+			Scopes:    []installer.InstallScope{installer.ScopeGlobal},
+			Confirm:   true,
+		})
+		if err != nil {
+			fmt.Printf("  %s %s (%s): %v\n", errorStyle.Render("✗"), inst.Slug, inst.Platform, err)
+			result.Failed++
+			continue
+		}
+		fmt.Printf("  %s %s (%s)\n", cleanStyle.Render("✓"), inst.Slug, inst.Platform)
+		result.Installed++
+	}
+	return result, nil
+}
+
+// expandHome reverses collapseHome for the current machine.
+func expandHome(path, home string) string {
+	if path == "~" {
+		return home
+	}
+	if strings.HasPrefix(path, "~/") {
+		return home + path[1:]
+	}
+	return path
+}
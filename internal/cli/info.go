@@ -6,6 +6,9 @@ import (
 
 	"github.com/asteroid-belt/skulto/internal/config"
 	"github.com/asteroid-belt/skulto/internal/db"
+	"github.com/asteroid-belt/skulto/internal/installer"
+	"github.com/asteroid-belt/skulto/internal/scraper"
+	"github.com/asteroid-belt/skulto/pkg/version"
 	"github.com/spf13/cobra"
 )
 
@@ -69,6 +72,32 @@ func runInfo(cmd *cobra.Command, args []string) error {
 
 	if skill.Source != nil {
 		fmt.Printf("\nSource: %s/%s\n", skill.Source.Owner, skill.Source.Repo)
+		if last, _ := scraper.NewRepositoryManagerFromConfig(cfg).LastChange(skill.Source, skill.FilePath); last != nil {
+			fmt.Printf("Last changed: %s %s by %s: %s\n", last.ShortSHA(), last.Date.Format("2006-01-02"), last.Author, last.Message)
+		}
+	}
+
+	// Compatibility declared in frontmatter
+	compat := installer.CheckCompatibility(skill)
+	if names := compat.PlatformNames(); len(names) > 0 {
+		fmt.Printf("\nPlatforms: %s\n", strings.Join(names, ", "))
+	} else {
+		fmt.Printf("\nPlatforms: all\n")
+	}
+	if compat.MinVersion != "" {
+		if compat.VersionOK {
+			fmt.Printf("Min skulto version: %s\n", compat.MinVersion)
+		} else {
+			fmt.Printf("Min skulto version: %s %s\n", compat.MinVersion,
+				errorStyle.Render(fmt.Sprintf("(running %s, upgrade required)", version.Short())))
+		}
+	}
+	if requires := scraper.SkillMeta(skill).Requires; len(requires) > 0 {
+		names := make([]string, len(requires))
+		for i, req := range requires {
+			names[i] = req.String()
+		}
+		fmt.Printf("Requires: %s\n", strings.Join(names, ", "))
 	}
 
 	// Use skill_installations as source of truth for installed status
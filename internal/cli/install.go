@@ -12,6 +12,7 @@ import (
 	"github.com/asteroid-belt/skulto/internal/db"
 	"github.com/asteroid-belt/skulto/internal/detect"
 	"github.com/asteroid-belt/skulto/internal/installer"
+	"github.com/asteroid-belt/skulto/internal/manifest"
 	"github.com/asteroid-belt/skulto/internal/models"
 	"github.com/asteroid-belt/skulto/internal/scraper"
 	"github.com/asteroid-belt/skulto/internal/security"
@@ -23,6 +24,8 @@ var (
 	installPlatforms []string
 	installScope     string
 	installYes       bool
+	installDryRun    bool
+	installJSON      bool
 )
 
 var installCmd = &cobra.Command{
@@ -46,6 +49,11 @@ Non-Interactive Mode (-y):
   Installs to detected platforms with global scope.
   Use -p and -s flags to override defaults.
 
+Dependencies:
+  Skills listed under requires: in a skill's frontmatter are
+  installed first at the same locations. Interactive runs ask
+  before installing them; -y installs them automatically.
+
 URL Mode:
   When given a URL or owner/repo format, auto-adds the repository
   and shows a skill picker to select which skills to install.
@@ -80,7 +88,11 @@ Examples:
   skulto install owner/skills
 
   # Install all from manifest (no arguments)
-  skulto install`,
+  skulto install
+
+  # Preview what would change without installing
+  skulto install docker-expert -p claude --dry-run
+  skulto install docker-expert --dry-run --json`,
 	Args: cobra.RangeArgs(0, 1),
 	RunE: runInstall,
 }
@@ -92,6 +104,10 @@ func init() {
 		"Installation scope: global or project")
 	installCmd.Flags().BoolVarP(&installYes, "yes", "y", false,
 		"Skip interactive prompts, use defaults")
+	installCmd.Flags().BoolVar(&installDryRun, "dry-run", false,
+		"Show what would be installed without making changes")
+	installCmd.Flags().BoolVar(&installJSON, "json", false,
+		"Print the --dry-run plan as JSON")
 }
 
 func runInstall(cmd *cobra.Command, args []string) error {
@@ -126,6 +142,10 @@ func runInstall(cmd *cobra.Command, args []string) error {
 	// Create install service
 	service := installer.NewInstallService(database, cfg, telemetryClient)
 
+	if installDryRun {
+		return planInstall(ctx, service, database, input)
+	}
+
 	// Check if input is URL or slug
 	if isURL(input) {
 		return runInstallFromURL(ctx, service, database, cfg, input)
@@ -187,6 +207,9 @@ func selectPlatformsAndScope(service *installer.InstallService, ctx context.Cont
 	// fallback, `skulto install <owner/repo> -y` aborts with "No platforms
 	// selected" immediately after the scan report, which is confusing.
 	if installYes && len(selectedPlatforms) == 0 {
+		if opts, ok := projectPreferenceOptions(service); ok {
+			return &opts, nil
+		}
 		selectedPlatforms = getDetectedPlatformIDs()
 	}
 
@@ -239,10 +262,21 @@ func runInstallBySlug(ctx context.Context, service *installer.InstallService, sl
 }
 
 // runInstallBySlugNonInteractive handles the -y path when no -p flags are given.
-// It checks remembered install locations first, then falls back to detected platforms.
+// It checks the skulto.json entry, then the project's saved locations, then
+// remembered install locations, and finally falls back to detected platforms.
 func runInstallBySlugNonInteractive(ctx context.Context, service *installer.InstallService, slug string) error {
 	database := service.DB()
 
+	// A skulto.json entry with platforms or scope decides where the skill goes
+	if opts, ok := manifestInstallOptions(slug); ok {
+		return executeInstall(ctx, service, slug, opts)
+	}
+
+	// Then the locations saved for this project
+	if opts, ok := projectPreferenceOptions(service); ok {
+		return executeInstall(ctx, service, slug, opts)
+	}
+
 	// Check if user has opted into remembered locations
 	remember, _ := database.GetRememberInstallLocations()
 	if remember {
@@ -272,6 +306,44 @@ func runInstallBySlugNonInteractive(ctx context.Context, service *installer.Inst
 	return executeInstall(ctx, service, slug, opts)
 }
 
+// manifestInstallOptions returns install options from the current directory's
+// skulto.json entry for slug, if it sets platforms or scope. A -s flag still
+// overrides the manifest scope.
+func manifestInstallOptions(slug string) (installer.InstallOptions, bool) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return installer.InstallOptions{}, false
+	}
+	mf, err := manifest.Read(cwd)
+	if err != nil || mf == nil {
+		return installer.InstallOptions{}, false
+	}
+	if _, listed := mf.Skills[slug]; !listed {
+		return installer.InstallOptions{}, false
+	}
+	entry := mf.SkillOptions(slug)
+	if len(entry.Platforms) == 0 && entry.Scope == "" {
+		return installer.InstallOptions{}, false
+	}
+
+	platforms := entry.Platforms
+	if len(platforms) == 0 {
+		platforms = getDetectedPlatformIDs()
+	}
+	scope := installer.ScopeProject
+	if entry.Scope != "" {
+		scope = installer.InstallScope(entry.Scope)
+	}
+	if installScope != "" {
+		scope = installer.InstallScope(installScope)
+	}
+	return installer.InstallOptions{
+		Platforms: platforms,
+		Scopes:    []installer.InstallScope{scope},
+		Confirm:   true,
+	}, true
+}
+
 // installToRememberedLocations installs a skill using saved platform-scope pairs.
 // Each pair is installed individually to handle mixed scopes correctly
 // (e.g., claude=global + cursor=project).
@@ -323,6 +395,15 @@ func executeInstall(ctx context.Context, service *installer.InstallService, slug
 	// Get current install locations for comparison
 	installedLocations, _ := service.GetInstallLocations(ctx, slug)
 
+	// Resolve requires: before scanning so missing deps and cycles fail early
+	if !opts.SkipDependencies {
+		installDeps, err := confirmDependencies(service, slug)
+		if err != nil {
+			return trackCLIError("install", err)
+		}
+		opts.SkipDependencies = !installDeps
+	}
+
 	// Scan before install
 	scanInfo, _ := service.ScanSkill(slug)
 	if scanInfo != nil && scanInfo.HasWarning {
@@ -351,6 +432,13 @@ func executeInstall(ctx context.Context, service *installer.InstallService, slug
 		return trackCLIError("install", fmt.Errorf("install failed: %w", err))
 	}
 
+	for _, dep := range result.Dependencies {
+		fmt.Printf("  ✓ %s (required by %s)\n", dep, slug)
+	}
+	for _, loc := range result.Skipped {
+		fmt.Printf("  %s %s (%s) - skipped, not in the skill's platforms\n", highStyle.Render("!"), loc.Platform, loc.Scope)
+	}
+
 	newInstalls := 0
 	alreadyInstalledCount := 0
 	for _, loc := range result.Locations {
@@ -384,6 +472,37 @@ func executeInstall(ctx context.Context, service *installer.InstallService, slug
 	return nil
 }
 
+// confirmDependencies lists the skills slug requires and asks whether to
+// install them too. Returns true to install them; -y and non-interactive
+// runs install them without asking.
+func confirmDependencies(service *installer.InstallService, slug string) (bool, error) {
+	deps, err := service.ResolveDependencies(slug)
+	if err != nil {
+		return false, err
+	}
+	if len(deps) == 0 {
+		return true, nil
+	}
+
+	fmt.Printf("%s requires %d skill(s):\n", slug, len(deps))
+	for _, dep := range deps {
+		if scanInfo, _ := service.ScanSkill(dep.Slug); scanInfo != nil && scanInfo.HasWarning {
+			style := threatStyle(scanInfo.ThreatLevel)
+			fmt.Printf("  %s %s — %s\n", style.Render(fmt.Sprintf("⚠ %-8s", scanInfo.ThreatLevel)), dep.Slug, scanInfo.ThreatSummary)
+		} else {
+			fmt.Printf("  • %s\n", dep.Slug)
+		}
+	}
+
+	if installYes || !isInteractive() {
+		return true, nil
+	}
+	fmt.Print("  Install required skills too? [Y/n] ")
+	var answer string
+	_, _ = fmt.Scanln(&answer)
+	return answer != "n" && answer != "N", nil
+}
+
 func runInstallFromURL(ctx context.Context, service *installer.InstallService, database *db.DB, cfg *config.Config, url string) error {
 	fmt.Printf("Fetching skills from %s...\n", url)
 
@@ -416,12 +535,7 @@ func runInstallFromURL(ctx context.Context, service *installer.InstallService, d
 			return trackCLIError("install", fmt.Errorf("add repository: %w", err))
 		}
 
-		scraperCfg := scraper.ScraperConfig{
-			Token:        cfg.GitHub.Token,
-			DataDir:      cfg.BaseDir,
-			RepoCacheTTL: cfg.GitHub.RepoCacheTTL,
-			UseGitClone:  cfg.GitHub.UseGitClone,
-		}
+		scraperCfg := scraper.ScraperConfigFromConfig(cfg)
 		s := scraper.NewScraperWithConfig(scraperCfg, database)
 
 		_, err = s.ScrapeRepository(ctx, source.Owner, source.Repo)
@@ -668,6 +782,91 @@ func runInstallFromURL(ctx context.Context, service *installer.InstallService, d
 	return nil
 }
 
+// planInstall prints what `install --dry-run` would do. Platforms and scopes
+// are resolved the same way as the non-interactive (-y) path so no prompts run.
+func planInstall(ctx context.Context, service *installer.InstallService, database *db.DB, input string) error {
+	plan := installer.NewPlan("install")
+
+	var slugs []string
+	if isURL(input) {
+		parsed, err := scraper.ParseRepositoryURL(input)
+		if err != nil {
+			return trackCLIError("install", fmt.Errorf("invalid repository URL: %w", err))
+		}
+		skills, _ := database.GetSkillsBySourceID(parsed.ID)
+		if len(skills) == 0 {
+			// Skills are unknown until the repository is cloned and indexed.
+			plan.AddClone(parsed.ID)
+		}
+		for _, skill := range skills {
+			slugs = append(slugs, skill.Slug)
+		}
+	} else {
+		slugs = []string{input}
+	}
+
+	for _, opts := range resolveNonInteractiveInstallOptions(service, database) {
+		for _, slug := range slugs {
+			p, err := service.PlanInstall(ctx, slug, opts)
+			if err != nil {
+				plan.AddError("%s: %v", slug, err)
+				continue
+			}
+			plan.Merge(p)
+		}
+	}
+
+	return printPlan(plan, installJSON)
+}
+
+// resolveNonInteractiveInstallOptions returns the install options used when no
+// prompts can be shown: explicit -p/-s flags, then the project's saved
+// locations, then remembered install locations, then detected platforms at
+// global scope.
+func resolveNonInteractiveInstallOptions(service *installer.InstallService, database *db.DB) []installer.InstallOptions {
+	scope := installer.ScopeGlobal
+	if installScope != "" {
+		scope = installer.InstallScope(installScope)
+	}
+
+	if len(installPlatforms) > 0 {
+		return []installer.InstallOptions{{
+			Platforms: installPlatforms,
+			Scopes:    []installer.InstallScope{scope},
+			Confirm:   true,
+		}}
+	}
+
+	if opts, ok := projectPreferenceOptions(service); ok {
+		return []installer.InstallOptions{opts}
+	}
+
+	if remember, _ := database.GetRememberInstallLocations(); remember {
+		saved, err := database.GetEnabledAgentScopes()
+		if err == nil && len(saved) > 0 {
+			var plan []installer.InstallOptions
+			for platformID, scopeStr := range saved {
+				s := installer.InstallScope(scopeStr)
+				if !s.IsValid() {
+					s = installer.ScopeGlobal
+				}
+				plan = append(plan, installer.InstallOptions{
+					Platforms: []string{platformID},
+					Scopes:    []installer.InstallScope{s},
+					Confirm:   true,
+				})
+			}
+			return plan
+		}
+	}
+
+	return []installer.InstallOptions{{
+		Platforms: getDetectedPlatformIDs(),
+		Scopes:    []installer.InstallScope{scope},
+		Confirm:   true,
+	}}
+}
+
 // formatOptLocations formats platform+scope pairs for display.
 func formatOptLocations(platforms []string, scopes []installer.InstallScope) string {
 	var parts []string
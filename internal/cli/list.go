@@ -2,6 +2,7 @@ package cli
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/asteroid-belt/skulto/internal/config"
@@ -85,6 +86,21 @@ func runList(cmd *cobra.Command, args []string) error {
 		}
 
 		fmt.Printf("  ✓ %s\n", source.FullName)
+		if source.Ref != "" {
+			fmt.Printf("    Ref: %s\n", source.Ref)
+		}
+		if source.SkillPath != "" {
+			fmt.Printf("    Path: %s/\n", source.SkillPath)
+		}
+		if source.FullHistory {
+			fmt.Println("    History: full")
+		}
+		if source.Registry != "" {
+			fmt.Printf("    Registry: %s (%s)\n", source.Registry, sourceTier(source.IsOfficial, source.IsCurated))
+		}
+		if source.Tags != "" {
+			fmt.Printf("    Tags: %s\n", strings.ReplaceAll(source.Tags, ",", ", "))
+		}
 		fmt.Printf("    %d installed, %d not installed\n", installedCount, notInstalledCount)
 		fmt.Printf("    Last synced: %s\n", syncStatus)
 		fmt.Println()
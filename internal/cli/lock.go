@@ -0,0 +1,238 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/asteroid-belt/skulto/internal/config"
+	"github.com/asteroid-belt/skulto/internal/db"
+	"github.com/asteroid-belt/skulto/internal/manifest"
+	"github.com/asteroid-belt/skulto/internal/models"
+	"github.com/asteroid-belt/skulto/internal/scraper"
+)
+
+// pinnedRevision is a commit of a skill's source checked out on its own
+// (scraper.RepositoryManager.PinCommit). Pinned skills are installed from
+// Dir, leaving the shared clone on its source's latest revision.
+type pinnedRevision struct {
+	Commit string
+	Dir    string // Root of the checkout
+}
+
+// lockSkill resolves a skill's revision: its pin, if any, otherwise the
+// current revision of its source clone.
+func lockSkill(repoManager *scraper.RepositoryManager, skill *models.Skill, pin *pinnedRevision) (manifest.LockedSkill, error) {
+	if skill.Source == nil {
+		return manifest.LockedSkill{}, fmt.Errorf("%s has no source repository", skill.Slug)
+	}
+	if dir := skill.Source.LocalDir(); dir != "" {
+		return manifest.LockedSkill{}, fmt.Errorf("%s comes from local directory %s, which can't be locked", skill.Slug, dir)
+	}
+	var commit, root string
+	if pin != nil {
+		commit, root = pin.Commit, pin.Dir
+	} else {
+		root = repoManager.GetRepoPath(skill.Source.Owner, skill.Source.Repo)
+		var err error
+		if commit, err = repoManager.GetCommitSHA(root); err != nil {
+			return manifest.LockedSkill{}, fmt.Errorf("%s: read commit of %s: %w", skill.Slug, skill.Source.FullName, err)
+		}
+	}
+
+	skillPath := path.Dir(filepath.ToSlash(skill.FilePath))
+	hash, err := manifest.HashSkillDir(filepath.Join(root, filepath.FromSlash(skillPath)))
+	if err != nil {
+		return manifest.LockedSkill{}, fmt.Errorf("%s: %w", skill.Slug, err)
+	}
+
+	return manifest.LockedSkill{
+		Source: skill.Source.FullName,
+		Commit: commit,
+		Path:   skillPath,
+		Hash:   hash,
+	}, nil
+}
+
+// buildLock pins every manifest skill to the revision it is installed from:
+// its pin in pins, the pinned checkout its installs link to, or else the
+// current revision of its clone. Skills that cannot be resolved keep their
+// previous entry, if any, and are returned as warnings.
+func buildLock(database *db.DB, cfg *config.Config, mf *manifest.ManifestFile, previous *manifest.LockFile, pins map[string]pinnedRevision) (*manifest.LockFile, []string) {
+	repoManager := scraper.NewRepositoryManagerFromConfig(cfg)
+
+	lf := manifest.NewLock()
+	var warnings []string
+	for _, slug := range mf.SortedSlugs() {
+		// Vendored skills are versioned with the project itself
+		if manifest.IsPathSource(mf.Skills[slug]) {
+			continue
+		}
+		skill, err := database.GetSkillBySlugAndSource(slug, mf.Skills[slug])
+		if err == nil && skill != nil {
+			pin := installedPin(database, repoManager, skill)
+			if p, ok := pins[slug]; ok {
+				pin = &p
+			}
+			locked, lockErr := lockSkill(repoManager, skill, pin)
+			if lockErr == nil {
+				locked.Ref = mf.SkillOptions(slug).Ref
+				lf.Skills[slug] = locked
+				continue
+			}
+			err = lockErr
+		} else if err == nil {
+			err = fmt.Errorf("%s not found in %s", slug, mf.Skills[slug])
+		}
+		warnings = append(warnings, err.Error())
+		if previous != nil {
+			if old, ok := lockedEntry(mf, previous, slug); ok {
+				lf.Skills[slug] = old
+			}
+		}
+	}
+	return lf, warnings
+}
+
+// installedPin returns the pinned checkout a skill's installs link to, or
+// nil if they link to its clone.
+func installedPin(database *db.DB, repoManager *scraper.RepositoryManager, skill *models.Skill) *pinnedRevision {
+	if skill.Source == nil {
+		return nil
+	}
+	rows, err := database.GetInstallations(skill.ID)
+	if err != nil {
+		return nil
+	}
+	pinsDir := repoManager.PinPath(skill.Source.Owner, skill.Source.Repo, "")
+	for _, row := range rows {
+		target, err := os.Readlink(row.SymlinkPath)
+		if err != nil {
+			continue
+		}
+		rel, err := filepath.Rel(pinsDir, target)
+		if err != nil || !filepath.IsLocal(rel) {
+			continue
+		}
+		sha, _, _ := strings.Cut(filepath.ToSlash(rel), "/")
+		return &pinnedRevision{Commit: sha, Dir: filepath.Join(pinsDir, sha)}
+	}
+	return nil
+}
+
+// writeLock writes skulto.lock if it differs from the existing file.
+// Returns true if the file was written.
+func writeLock(dir string, lf, existing *manifest.LockFile) (bool, error) {
+	if existing != nil && manifest.LocksEqual(existing, lf) {
+		return false, nil
+	}
+	if err := manifest.WriteLock(dir, lf); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// lockedEntry returns the lock entry for slug if it still matches the
+// manifest's source and ref. Entries for changed manifest entries are stale.
+func lockedEntry(mf *manifest.ManifestFile, lf *manifest.LockFile, slug string) (manifest.LockedSkill, bool) {
+	if lf == nil {
+		return manifest.LockedSkill{}, false
+	}
+	locked, ok := lf.Skills[slug]
+	if !ok || locked.Source != mf.Skills[slug] || locked.Ref != mf.SkillOptions(slug).Ref {
+		return manifest.LockedSkill{}, false
+	}
+	return locked, true
+}
+
+// pinLocked checks out each locked skill's commit on its own, verifies the
+// skill's files against the locked hash and records the checkout in pins.
+// Every mismatch is reported; any mismatch is an error so nothing
+// unverified gets installed. Skills whose manifest source or ref changed
+// since locking are skipped.
+func pinLocked(ctx context.Context, cfg *config.Config, mf *manifest.ManifestFile, lf *manifest.LockFile, skills []*models.Skill, pins map[string]pinnedRevision) error {
+	repoManager := scraper.NewRepositoryManagerFromConfig(cfg)
+
+	var problems []string
+	for _, skill := range skills {
+		locked, ok := lockedEntry(mf, lf, skill.Slug)
+		if !ok || skill.Source == nil {
+			continue
+		}
+		if locked.Source != skill.Source.FullName {
+			problems = append(problems, fmt.Sprintf("%s: locked to %s but indexed from %s", skill.Slug, locked.Source, skill.Source.FullName))
+			continue
+		}
+		if current := path.Dir(filepath.ToSlash(skill.FilePath)); current != locked.Path {
+			problems = append(problems, fmt.Sprintf("%s: locked at %s but indexed at %s", skill.Slug, locked.Path, current))
+			continue
+		}
+		repoManager.ConfigureSource(skill.Source)
+		dir, err := repoManager.PinCommit(ctx, skill.Source.Owner, skill.Source.Repo, locked.Commit)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: check out %s: %v", skill.Slug, shortSHA(locked.Commit), err))
+			continue
+		}
+		if err := locked.Verify(skill.Slug, filepath.Join(dir, filepath.FromSlash(locked.Path))); err != nil {
+			problems = append(problems, err.Error())
+			continue
+		}
+		pins[skill.Slug] = pinnedRevision{Commit: locked.Commit, Dir: dir}
+	}
+
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return fmt.Errorf("skulto.lock does not match the available skills; run 'skulto sync --update' to refresh it:\n  %s",
+			strings.Join(problems, "\n  "))
+	}
+	return nil
+}
+
+// pinRefs checks out the ref pinned in the manifest, for skills that have
+// no current lock entry, and records the checkouts in pins. Each source
+// and ref is resolved once; skills from one source may pin different refs.
+func pinRefs(ctx context.Context, cfg *config.Config, mf *manifest.ManifestFile, lf *manifest.LockFile, skills []*models.Skill, pins map[string]pinnedRevision) error {
+	repoManager := scraper.NewRepositoryManagerFromConfig(cfg)
+
+	resolved := make(map[string]pinnedRevision) // source@ref -> checkout
+	for _, skill := range skills {
+		ref := mf.SkillOptions(skill.Slug).Ref
+		if ref == "" || skill.Source == nil {
+			continue
+		}
+		if _, ok := lockedEntry(mf, lf, skill.Slug); ok {
+			continue
+		}
+		key := skill.Source.FullName + "@" + ref
+		pin, ok := resolved[key]
+		if !ok {
+			source := skill.Source
+			repoManager.ConfigureSource(source)
+			sha, err := repoManager.ResolveRef(ctx, source.Owner, source.Repo, ref)
+			if err != nil {
+				return fmt.Errorf("check out %s: %w", key, err)
+			}
+			dir, err := repoManager.PinCommit(ctx, source.Owner, source.Repo, sha)
+			if err != nil {
+				return fmt.Errorf("check out %s: %w", key, err)
+			}
+			pin = pinnedRevision{Commit: sha, Dir: dir}
+			resolved[key] = pin
+			fmt.Printf("  %s at %s (%s)\n", source.FullName, ref, shortSHA(sha))
+		}
+		pins[skill.Slug] = pin
+	}
+	return nil
+}
+
+// shortSHA abbreviates a commit SHA for display.
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
@@ -0,0 +1,155 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/asteroid-belt/skulto/internal/manifest"
+	"github.com/asteroid-belt/skulto/internal/models"
+	"github.com/asteroid-belt/skulto/internal/scraper"
+)
+
+// commitSkill writes SKILL.md for a skill in a local repository and commits it.
+func commitSkill(t *testing.T, r *git.Repository, dir, content string) plumbing.Hash {
+	t.Helper()
+	rel := filepath.Join("skills", "teach", "SKILL.md")
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "skills", "teach"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, rel), []byte(content), 0644))
+	w, err := r.Worktree()
+	require.NoError(t, err)
+	_, err = w.Add(filepath.ToSlash(rel))
+	require.NoError(t, err)
+	hash, err := w.Commit("update teach", &git.CommitOptions{
+		Author: &object.Signature{Name: "test", Email: "test@example.com", When: time.Now()},
+	})
+	require.NoError(t, err)
+	return hash
+}
+
+func TestLock_BuildAndCheckout(t *testing.T) {
+	database := testDB(t)
+	cfg := testConfig(t)
+
+	upstreamDir := t.TempDir()
+	upstream, err := git.PlainInit(upstreamDir, false)
+	require.NoError(t, err)
+	first := commitSkill(t, upstream, upstreamDir, "# Teach v1\n")
+
+	clone := filepath.Join(cfg.BaseDir, "repositories", "owner", "repo")
+	_, err = git.PlainClone(clone, false, &git.CloneOptions{URL: upstreamDir})
+	require.NoError(t, err)
+
+	source := &models.Source{ID: "owner/repo", Owner: "owner", Repo: "repo", FullName: "owner/repo"}
+	require.NoError(t, database.CreateSource(source))
+	sourceID := source.ID
+	require.NoError(t, database.CreateSkill(&models.Skill{
+		ID: "teach-id", Slug: "teach", Title: "Teach", SourceID: &sourceID, FilePath: "skills/teach/SKILL.md",
+	}))
+
+	mf := manifest.New()
+	mf.Skills["teach"] = "owner/repo"
+
+	lf, warnings := buildLock(database, cfg, mf, nil, nil)
+	assert.Empty(t, warnings)
+	require.Contains(t, lf.Skills, "teach")
+	locked := lf.Skills["teach"]
+	assert.Equal(t, first.String(), locked.Commit)
+	assert.Equal(t, "skills/teach", locked.Path)
+	assert.Equal(t, "owner/repo", locked.Source)
+
+	// Move the clone forward; the locked commit is checked out on its own
+	second := commitSkill(t, upstream, upstreamDir, "# Teach v2\n")
+	r, err := git.PlainOpen(clone)
+	require.NoError(t, err)
+	require.NoError(t, r.Fetch(&git.FetchOptions{}))
+	w, err := r.Worktree()
+	require.NoError(t, err)
+	require.NoError(t, w.Reset(&git.ResetOptions{Commit: second, Mode: git.HardReset}))
+
+	skill, err := database.GetSkillBySlugAndSource("teach", "owner/repo")
+	require.NoError(t, err)
+	pins := make(map[string]pinnedRevision)
+	require.NoError(t, pinLocked(context.Background(), cfg, mf, lf, []*models.Skill{skill}, pins))
+	require.Contains(t, pins, "teach")
+	assert.Equal(t, first.String(), pins["teach"].Commit)
+	content, err := os.ReadFile(filepath.Join(pins["teach"].Dir, "skills", "teach", "SKILL.md"))
+	require.NoError(t, err)
+	assert.Equal(t, "# Teach v1\n", string(content))
+
+	// The shared clone stays where it was, for every other install
+	content, err = os.ReadFile(filepath.Join(clone, "skills", "teach", "SKILL.md"))
+	require.NoError(t, err)
+	assert.Equal(t, "# Teach v2\n", string(content))
+
+	// Installs linking to the pin keep the lock at the pinned commit
+	link := filepath.Join(t.TempDir(), "teach")
+	require.NoError(t, os.Symlink(filepath.Join(pins["teach"].Dir, "skills", "teach"), link))
+	require.NoError(t, database.AddInstallation(&models.SkillInstallation{
+		SkillID: "teach-id", Platform: "claude", Scope: "project", BasePath: filepath.Dir(link), SymlinkPath: link,
+	}))
+	relocked, warnings := buildLock(database, cfg, mf, nil, nil)
+	assert.Empty(t, warnings)
+	assert.Equal(t, locked, relocked.Skills["teach"])
+
+	// A lock whose hash does not match the commit fails loudly
+	tampered := manifest.NewLock()
+	locked.Hash = "sha256:deadbeef"
+	tampered.Skills["teach"] = locked
+	err = pinLocked(context.Background(), cfg, mf, tampered, []*models.Skill{skill}, make(map[string]pinnedRevision))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "teach")
+	assert.Contains(t, err.Error(), "sync --update")
+}
+
+func TestLock_PinRefs(t *testing.T) {
+	database := testDB(t)
+	cfg := testConfig(t)
+
+	upstreamDir := t.TempDir()
+	upstream, err := git.PlainInit(upstreamDir, false)
+	require.NoError(t, err)
+	tagged := commitSkill(t, upstream, upstreamDir, "# Teach v1\n")
+	_, err = upstream.CreateTag("v1", tagged, nil)
+	require.NoError(t, err)
+	head := commitSkill(t, upstream, upstreamDir, "# Teach v2\n")
+
+	clone := filepath.Join(cfg.BaseDir, "repositories", "owner", "repo")
+	_, err = git.PlainClone(clone, false, &git.CloneOptions{URL: upstreamDir})
+	require.NoError(t, err)
+
+	source := &models.Source{ID: "owner/repo", Owner: "owner", Repo: "repo", FullName: "owner/repo", CloneURL: upstreamDir}
+	require.NoError(t, database.CreateSource(source))
+	sourceID := source.ID
+	require.NoError(t, database.CreateSkill(&models.Skill{
+		ID: "teach-id", Slug: "teach", Title: "Teach", SourceID: &sourceID, FilePath: "skills/teach/SKILL.md",
+	}))
+	skill, err := database.GetSkillBySlugAndSource("teach", "owner/repo")
+	require.NoError(t, err)
+
+	mf := manifest.New()
+	mf.Skills["teach"] = "owner/repo"
+	mf.Options = map[string]manifest.SkillOptions{"teach": {Ref: "v1"}}
+
+	pins := make(map[string]pinnedRevision)
+	require.NoError(t, pinRefs(context.Background(), cfg, mf, nil, []*models.Skill{skill}, pins))
+	require.Contains(t, pins, "teach")
+	assert.Equal(t, tagged.String(), pins["teach"].Commit)
+
+	sha, err := scraper.NewRepositoryManagerFromConfig(cfg).GetCommitSHA(clone)
+	require.NoError(t, err)
+	assert.Equal(t, head.String(), sha, "pinning must not move the shared clone")
+
+	lf, warnings := buildLock(database, cfg, mf, nil, pins)
+	assert.Empty(t, warnings)
+	assert.Equal(t, tagged.String(), lf.Skills["teach"].Commit)
+	assert.Equal(t, "v1", lf.Skills["teach"].Ref)
+}
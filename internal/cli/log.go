@@ -0,0 +1,133 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/asteroid-belt/skulto/internal/db"
+	"github.com/asteroid-belt/skulto/internal/models"
+	"github.com/asteroid-belt/skulto/internal/scraper"
+	"github.com/spf13/cobra"
+)
+
+var (
+	logLimit       int
+	logFullHistory bool
+)
+
+var logCmd = &cobra.Command{
+	Use:   "log <skill-slug>",
+	Short: "Show the commits that changed a skill",
+	Long: `List the commits that changed a skill's directory, newest first, with
+their authors, dates and messages.
+
+Sources are cloned without history unless they were added with
+'skulto add --full-history'. --full-history turns it on for the skill's
+source and fetches the history now; later pulls keep it up to date. Skills
+from local directories are read from the git repository they live in.
+
+Examples:
+  skulto log commit-message-generator
+  skulto log commit-message-generator -n 5
+  skulto log commit-message-generator --full-history`,
+	Args: cobra.ExactArgs(1),
+	RunE: runLog,
+}
+
+func init() {
+	logCmd.Flags().IntVarP(&logLimit, "limit", "n", 20, "Commits to show (0 for all)")
+	logCmd.Flags().BoolVar(&logFullHistory, "full-history", false, "Keep the source's full history and fetch it now")
+}
+
+func runLog(cmd *cobra.Command, args []string) error {
+	slug := args[0]
+	cfg, database, err := openCommandDB("log")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = database.Close() }()
+
+	skill, err := database.GetSkillBySlug(slug)
+	if err != nil {
+		return trackCLIError("log", fmt.Errorf("find skill: %w", err))
+	}
+	if skill == nil {
+		return trackCLIError("log", fmt.Errorf("skill '%s' not found", slug))
+	}
+	if skill.Source == nil {
+		return trackCLIError("log", fmt.Errorf("%s has no source repository", slug))
+	}
+
+	repoManager := scraper.NewRepositoryManagerFromConfig(cfg)
+	repoManager.ConfigureSource(skill.Source)
+	if logFullHistory {
+		if err := enableFullHistory(cmd, database, repoManager, skill.Source); err != nil {
+			return trackCLIError("log", err)
+		}
+	}
+
+	commits, err := repoManager.SkillLog(skill.Source, skill.FilePath, logLimit)
+	switch {
+	case errors.Is(err, scraper.ErrShallowHistory):
+		return trackCLIError("log", fmt.Errorf("%s is cloned without history; run 'skulto log %s --full-history' to fetch it", skill.Source.ID, slug))
+	case errors.Is(err, scraper.ErrNoHistory):
+		return trackCLIError("log", fmt.Errorf("%s is not in a git repository", skill.Source.LocalDir()))
+	case err != nil:
+		return trackCLIError("log", err)
+	}
+
+	dir := path.Dir(skill.FilePath)
+	if dir == "." {
+		dir = ""
+	}
+	fmt.Println(planHeaderStyle.Render(fmt.Sprintf("%s (%s/%s)", skill.Slug, skill.Source.ID, dir)))
+	if len(commits) == 0 {
+		fmt.Println("  No commits changed this skill.")
+		return nil
+	}
+	for _, c := range commits {
+		fmt.Printf("  %s  %s  %-20s  %s\n", cleanStyle.Render(c.ShortSHA()), c.Date.Format("2006-01-02"), truncateName(c.Author, 20), c.Message)
+	}
+	return nil
+}
+
+// enableFullHistory records that source keeps its full history and fetches
+// it into the clone. An existing clone is only deepened; it stays on the
+// revision that was scanned and installed until the next pull.
+func enableFullHistory(cmd *cobra.Command, database *db.DB, repoManager *scraper.RepositoryManager, source *models.Source) error {
+	if source.LocalDir() != "" {
+		// Read in place; the directory's own history is all there is
+		return nil
+	}
+	if !source.FullHistory {
+		source.FullHistory = true
+		if err := database.UpsertSource(source); err != nil {
+			return fmt.Errorf("save source %s: %w", source.ID, err)
+		}
+	}
+	fmt.Printf("Fetching the history of %s...\n", source.ID)
+	repoManager.ConfigureSource(source)
+	var err error
+	if _, statErr := os.Stat(filepath.Join(repoManager.GetRepoPath(source.Owner, source.Repo), ".git")); statErr == nil {
+		err = repoManager.DeepenHistory(cmd.Context(), source.Owner, source.Repo)
+	} else {
+		_, err = repoManager.CloneOrUpdate(cmd.Context(), source.Owner, source.Repo)
+	}
+	if err != nil {
+		return fmt.Errorf("fetch %s: %w", source.ID, err)
+	}
+	fmt.Println()
+	return nil
+}
+
+// truncateName shortens s to at most n runes.
+func truncateName(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n-1]) + "…"
+}
@@ -0,0 +1,21 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogCmd_Structure(t *testing.T) {
+	assert.Equal(t, "log <skill-slug>", logCmd.Use)
+	assert.NotEmpty(t, logCmd.Short)
+	assert.NotNil(t, logCmd.Flags().Lookup("limit"))
+	assert.NotNil(t, logCmd.Flags().Lookup("full-history"))
+	assert.Error(t, logCmd.Args(logCmd, nil))
+}
+
+func TestTruncateName(t *testing.T) {
+	assert.Equal(t, "Ada", truncateName("Ada", 5))
+	assert.Equal(t, "Ada L…", truncateName("Ada Lovelace", 6))
+	assert.Equal(t, "Zoë", truncateName("Zoë", 3))
+}
@@ -0,0 +1,103 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"github.com/asteroid-belt/skulto/internal/manifest"
+	"github.com/spf13/cobra"
+)
+
+var manifestMergeOutput string
+
+var manifestCmd = &cobra.Command{
+	Use:   "manifest",
+	Short: "Work with skulto.json files",
+	Long: `Tools for working with skulto.json manifest files.
+
+Subcommands:
+  merge   Three-way merge skulto.json files (git merge driver)`,
+	Args: cobra.NoArgs,
+}
+
+var manifestMergeCmd = &cobra.Command{
+	Use:   "merge <base> <ours> <theirs>",
+	Short: "Three-way merge skulto.json files",
+	Long: `Three-way merge two versions of skulto.json against their common ancestor.
+
+Skills added, removed or changed on only one side are merged cleanly, as are
+the ignored and workspace lists. A skill both sides changed differently (for
+example, the same slug pointing at different sources) is a conflict: our
+version is kept, the conflict is reported, and the command exits non-zero.
+
+The result is written to <ours> (or --output) as a canonical, sorted
+skulto.json, so the command works as a git merge driver:
+
+  # .gitattributes
+  skulto.json merge=skulto
+
+  git config merge.skulto.name "skulto manifest merge"
+  git config merge.skulto.driver "skulto manifest merge %O %A %B"
+
+A missing or empty <base> is treated as an empty manifest.
+
+Examples:
+  skulto manifest merge base.json ours.json theirs.json
+  skulto manifest merge base.json ours.json theirs.json -o merged.json`,
+	Args: cobra.ExactArgs(3),
+	RunE: runManifestMerge,
+}
+
+func init() {
+	manifestMergeCmd.Flags().StringVarP(&manifestMergeOutput, "output", "o", "", "Write the result here instead of <ours>")
+	manifestCmd.AddCommand(manifestMergeCmd)
+}
+
+func runManifestMerge(cmd *cobra.Command, args []string) error {
+	var inputs [3]*manifest.ManifestFile
+	for i, path := range args {
+		mf, err := readMergeInput(path)
+		if err != nil {
+			return trackCLIError("manifest merge", err)
+		}
+		inputs[i] = mf
+	}
+
+	merged, conflicts := manifest.Merge(inputs[0], inputs[1], inputs[2])
+
+	out := manifestMergeOutput
+	if out == "" {
+		out = args[1]
+	}
+	if err := manifest.WriteFile(out, merged); err != nil {
+		return trackCLIError("manifest merge", fmt.Errorf("write %s: %w", out, err))
+	}
+
+	if len(conflicts) > 0 {
+		fmt.Fprintf(os.Stderr, "%s %d conflict(s) in %s, kept our side:\n", errorStyle.Render("✗"), len(conflicts), out)
+		for _, c := range conflicts {
+			fmt.Fprintf(os.Stderr, "  %s\n", c)
+		}
+		return trackCLIError("manifest merge", fmt.Errorf("%d unresolved conflict(s)", len(conflicts)))
+	}
+	return nil
+}
+
+// readMergeInput reads one side of a merge. Git passes an empty file as the
+// base when both sides added skulto.json, so missing and empty files read as
+// empty manifests.
+func readMergeInput(path string) (*manifest.ManifestFile, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) || (err == nil && len(bytes.TrimSpace(data)) == 0) {
+		return manifest.New(), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", path, err)
+	}
+	mf, err := manifest.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return mf, nil
+}
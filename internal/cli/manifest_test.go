@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/asteroid-belt/skulto/internal/manifest"
+)
+
+func TestRunManifestMerge(t *testing.T) {
+	setupTestTelemetry()
+	dir := t.TempDir()
+	base := filepath.Join(dir, "base.json")
+	ours := filepath.Join(dir, "ours.json")
+	theirs := filepath.Join(dir, "theirs.json")
+
+	// Empty base, as git passes when both branches added skulto.json
+	require.NoError(t, os.WriteFile(base, nil, 0644))
+
+	mf := manifest.New()
+	mf.Skills["ours-only"] = "acme/skills"
+	mf.Skills["shared"] = "acme/skills"
+	require.NoError(t, manifest.WriteFile(ours, mf))
+
+	mf = manifest.New()
+	mf.Skills["theirs-only"] = "acme/skills"
+	mf.Skills["shared"] = "acme/skills"
+	require.NoError(t, manifest.WriteFile(theirs, mf))
+
+	manifestMergeOutput = ""
+	require.NoError(t, runManifestMerge(manifestMergeCmd, []string{base, ours, theirs}))
+
+	merged, err := manifest.ReadFile(ours)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ours-only", "shared", "theirs-only"}, merged.SortedSlugs())
+}
+
+func TestRunManifestMerge_Conflict(t *testing.T) {
+	setupTestTelemetry()
+	dir := t.TempDir()
+	ours := filepath.Join(dir, "ours.json")
+	theirs := filepath.Join(dir, "theirs.json")
+	out := filepath.Join(dir, "merged.json")
+
+	mf := manifest.New()
+	mf.Skills["shared"] = "acme/skills"
+	require.NoError(t, manifest.WriteFile(ours, mf))
+
+	mf = manifest.New()
+	mf.Skills["shared"] = "other/skills"
+	require.NoError(t, manifest.WriteFile(theirs, mf))
+
+	manifestMergeOutput = out
+	defer func() { manifestMergeOutput = "" }()
+	err := runManifestMerge(manifestMergeCmd, []string{filepath.Join(dir, "missing.json"), ours, theirs})
+	require.Error(t, err)
+	assert.Equal(t, ExitError, ExitCode(err))
+
+	merged, err := manifest.ReadFile(out)
+	require.NoError(t, err)
+	assert.Equal(t, "acme/skills", merged.Skills["shared"])
+}
@@ -0,0 +1,117 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/asteroid-belt/skulto/internal/installer"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Styles for dry-run plan output
+var (
+	planHeaderStyle = lipgloss.NewStyle().Bold(true)
+	planCreateStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("82"))
+	planRemoveStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+	planChangeStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
+	planMutedStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
+)
+
+// printPlan renders a dry-run plan as a table, or as indented JSON on stdout.
+func printPlan(plan *installer.Plan, asJSON bool) error {
+	if asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(plan)
+	}
+
+	fmt.Printf("%s (%s)\n", planHeaderStyle.Render("DRY RUN"), plan.Command)
+	fmt.Println(strings.Repeat("─", 50))
+
+	if plan.IsEmpty() && len(plan.Blocked) == 0 && len(plan.Errors) == 0 && len(plan.Warnings) == 0 {
+		fmt.Println("Nothing to do.")
+		return nil
+	}
+
+	if len(plan.Clones) > 0 {
+		fmt.Printf("\nRepositories to clone (%d):\n", len(plan.Clones))
+		for _, r := range plan.Clones {
+			fmt.Printf("  %s %s\n", planCreateStyle.Render("+"), r)
+		}
+	}
+	if len(plan.Pulls) > 0 {
+		fmt.Printf("\nRepositories to pull (%d):\n", len(plan.Pulls))
+		for _, r := range plan.Pulls {
+			fmt.Printf("  %s %s\n", planChangeStyle.Render("~"), r)
+		}
+	}
+	if len(plan.Removals) > 0 {
+		fmt.Printf("\nRepository clones to delete (%d):\n", len(plan.Removals))
+		for _, r := range plan.Removals {
+			fmt.Printf("  %s %s\n", planRemoveStyle.Render("-"), r)
+		}
+	}
+
+	if len(plan.Changes) > 0 {
+		fmt.Printf("\nSymlinks (%d):\n", len(plan.Changes))
+		fmt.Printf("  %-10s %-28s %-12s %-8s %s\n", "ACTION", "SKILL", "PLATFORM", "SCOPE", "PATH")
+		for _, c := range plan.Changes {
+			fmt.Printf("  %s %-28s %-12s %-8s %s\n",
+				renderPlanAction(c.Action), c.Slug, c.Platform, c.Scope, c.Path)
+		}
+	}
+
+	fmt.Println("\nDatabase:")
+	fmt.Printf("  installations added:   %d\n", plan.DB.InstallationsAdded)
+	fmt.Printf("  installations removed: %d\n", plan.DB.InstallationsRemoved)
+	if plan.DB.SkillsDeleted > 0 || plan.DB.SourcesDeleted > 0 {
+		fmt.Printf("  skills deleted:        %d\n", plan.DB.SkillsDeleted)
+		fmt.Printf("  sources deleted:       %d\n", plan.DB.SourcesDeleted)
+	}
+	if plan.PendingScans > 0 {
+		fmt.Printf("  skills to scan:        %d\n", plan.PendingScans)
+	}
+
+	if len(plan.Blocked) > 0 {
+		fmt.Printf("\nBlocked pending confirmation (%d):\n", len(plan.Blocked))
+		for _, b := range plan.Blocked {
+			style := threatStyle(b.ThreatLevel)
+			fmt.Printf("  %s %s — %s\n", style.Render(fmt.Sprintf("⚠ %-8s", b.ThreatLevel)), b.Slug, b.ThreatSummary)
+		}
+	}
+
+	if len(plan.Warnings) > 0 {
+		fmt.Printf("\nWarnings (%d):\n", len(plan.Warnings))
+		for _, w := range plan.Warnings {
+			fmt.Printf("  %s %s\n", planChangeStyle.Render("!"), w)
+		}
+	}
+
+	if len(plan.Errors) > 0 {
+		fmt.Printf("\nWould fail (%d):\n", len(plan.Errors))
+		for _, e := range plan.Errors {
+			fmt.Printf("  %s %s\n", planRemoveStyle.Render("x"), e)
+		}
+	}
+
+	fmt.Println()
+	fmt.Println(planMutedStyle.Render("No changes were made. Re-run without --dry-run to apply."))
+	return nil
+}
+
+// renderPlanAction returns a fixed-width, colored label for a plan action.
+func renderPlanAction(action installer.PlanAction) string {
+	label := fmt.Sprintf("%-10s", action)
+	switch action {
+	case installer.PlanCreate:
+		return planCreateStyle.Render(label)
+	case installer.PlanRemove, installer.PlanConflict:
+		return planRemoveStyle.Render(label)
+	case installer.PlanReplace:
+		return planChangeStyle.Render(label)
+	default:
+		return planMutedStyle.Render(label)
+	}
+}
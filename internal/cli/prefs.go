@@ -0,0 +1,184 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/asteroid-belt/skulto/internal/installer"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+)
+
+var (
+	prefsPlatforms []string
+	prefsScope     string
+)
+
+var prefsCmd = &cobra.Command{
+	Use:   "prefs",
+	Short: "Show or set where this project's skills are installed",
+	Long: `Show the install locations saved for the current project.
+
+Non-interactive installs in a project — 'skulto sync -y', 'skulto install -y'
+and the MCP skulto_install tool — use the project's saved platforms and scope
+before falling back to remembered locations or detected platforms. An
+interactive 'skulto sync' saves the locations you pick.
+
+Preferences are stored in the skulto database, keyed by project directory.
+
+Subcommands:
+  set     Save platforms and scope for the current project
+  list    List saved preferences for all projects
+  clear   Forget the current project's preferences
+
+Examples:
+  skulto prefs
+  skulto prefs set -p claude -p cursor
+  skulto prefs set -p claude -s global
+  skulto prefs clear`,
+	Args: cobra.NoArgs,
+	RunE: runPrefsShow,
+}
+
+var prefsSetCmd = &cobra.Command{
+	Use:   "set",
+	Short: "Save platforms and scope for the current project",
+	Args:  cobra.NoArgs,
+	RunE:  runPrefsSet,
+}
+
+var prefsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List saved preferences for all projects",
+	Args:  cobra.NoArgs,
+	RunE:  runPrefsList,
+}
+
+var prefsClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Forget the current project's preferences",
+	Args:  cobra.NoArgs,
+	RunE:  runPrefsClear,
+}
+
+func init() {
+	prefsSetCmd.Flags().StringSliceVarP(&prefsPlatforms, "platform", "p", nil, "Platform to install to (repeatable)")
+	prefsSetCmd.Flags().StringVarP(&prefsScope, "scope", "s", "project", "Installation scope: global or project")
+	_ = prefsSetCmd.MarkFlagRequired("platform")
+
+	prefsCmd.AddCommand(prefsSetCmd)
+	prefsCmd.AddCommand(prefsListCmd)
+	prefsCmd.AddCommand(prefsClearCmd)
+}
+
+// projectPreferenceOptions returns install options from the current
+// directory's saved preference. A -s flag overrides the saved scope.
+func projectPreferenceOptions(service *installer.InstallService) (installer.InstallOptions, bool) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return installer.InstallOptions{}, false
+	}
+	opts, ok := service.ProjectPreferenceOptions(cwd)
+	if ok && installScope != "" {
+		opts.Scopes = []installer.InstallScope{installer.InstallScope(installScope)}
+	}
+	return opts, ok
+}
+
+func runPrefsShow(cmd *cobra.Command, args []string) error {
+	_, database, err := openCommandDB("prefs")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = database.Close() }()
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return trackCLIError("prefs", fmt.Errorf("get working directory: %w", err))
+	}
+	pref, err := database.GetProjectPreference(cwd)
+	if err != nil {
+		return trackCLIError("prefs", fmt.Errorf("get preferences: %w", err))
+	}
+	if pref == nil {
+		fmt.Println("No install preferences saved for this project.")
+		fmt.Println()
+		fmt.Println("Save some with:")
+		fmt.Println("  skulto prefs set -p <platform> [-s project|global]")
+		return nil
+	}
+
+	headerStyle := lipgloss.NewStyle().Bold(true)
+	fmt.Println(headerStyle.Render(cwd))
+	fmt.Printf("  Platforms: %s\n", strings.Join(pref.GetPlatforms(), ", "))
+	fmt.Printf("  Scope:     %s\n", pref.Scope)
+	return nil
+}
+
+func runPrefsSet(cmd *cobra.Command, args []string) error {
+	cfg, database, err := openCommandDB("prefs set")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = database.Close() }()
+
+	if err := validatePlatformFlags(prefsPlatforms); err != nil {
+		return trackCLIError("prefs set", err)
+	}
+	platforms := make([]string, 0, len(prefsPlatforms))
+	for _, p := range prefsPlatforms {
+		platforms = append(platforms, string(installer.PlatformFromStringOrAlias(p)))
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return trackCLIError("prefs set", fmt.Errorf("get working directory: %w", err))
+	}
+	service := installer.NewInstallService(database, cfg, telemetryClient)
+	if err := service.SaveProjectPreference(cwd, platforms, installer.InstallScope(prefsScope)); err != nil {
+		return trackCLIError("prefs set", fmt.Errorf("save preferences: %w", err))
+	}
+
+	fmt.Printf("%s %s (%s scope)\n", cleanStyle.Render("✓ Saved"), strings.Join(platforms, ", "), prefsScope)
+	return nil
+}
+
+func runPrefsList(cmd *cobra.Command, args []string) error {
+	_, database, err := openCommandDB("prefs list")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = database.Close() }()
+
+	prefs, err := database.ListProjectPreferences()
+	if err != nil {
+		return trackCLIError("prefs list", fmt.Errorf("list preferences: %w", err))
+	}
+	if len(prefs) == 0 {
+		fmt.Println("No project preferences saved.")
+		return nil
+	}
+	for _, p := range prefs {
+		fmt.Printf("%s\n  %s (%s scope)\n", p.Path, strings.Join(p.GetPlatforms(), ", "), p.Scope)
+	}
+	return nil
+}
+
+func runPrefsClear(cmd *cobra.Command, args []string) error {
+	_, database, err := openCommandDB("prefs clear")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = database.Close() }()
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return trackCLIError("prefs clear", fmt.Errorf("get working directory: %w", err))
+	}
+	if err := database.DeleteProjectPreference(cwd); err != nil {
+		return trackCLIError("prefs clear", fmt.Errorf("clear preferences: %w", err))
+	}
+	fmt.Println("Cleared install preferences for this project.")
+	return nil
+}
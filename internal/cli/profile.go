@@ -0,0 +1,567 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/asteroid-belt/skulto/internal/config"
+	"github.com/asteroid-belt/skulto/internal/db"
+	"github.com/asteroid-belt/skulto/internal/installer"
+	"github.com/asteroid-belt/skulto/internal/models"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+)
+
+var (
+	profileDescription string
+	profilePlatforms   []string
+	profileScope       string
+	profilePrune       bool
+	profileYes         bool
+	profileDryRun      bool
+	profileJSON        bool
+)
+
+var profileCmd = &cobra.Command{
+	Use:   "profile",
+	Short: "Manage reusable sets of skills for projects",
+	Long: `Manage install profiles: named sets of skills with target platforms
+and scope that can be applied to any project.
+
+Profiles are stored in the skulto database.
+
+Subcommands:
+  create <name> [slug...]  Create or replace a profile
+  list                     List profiles
+  diff <name>              Compare a profile with the current project
+  apply <name>             Install a profile's skills into the current project
+  delete <name>            Delete a profile`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return cmd.Help()
+	},
+}
+
+var profileCreateCmd = &cobra.Command{
+	Use:   "create <name> [slug...]",
+	Short: "Create or replace a profile",
+	Long: `Create a profile from the given skill slugs. With no slugs, the profile
+captures the skills installed in the current project (or globally with
+--scope global).
+
+Without --platform, applying the profile uses your remembered install
+locations, or the detected platforms if none are remembered.
+
+Examples:
+  # Capture the current project's skills
+  skulto profile create backend
+
+  # Name the skills and platforms explicitly
+  skulto profile create frontend react-patterns css-guide -p claude -p cursor`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runProfileCreate,
+}
+
+var profileListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List profiles",
+	Long:  `List all saved profiles with their skills, platforms and scope.`,
+	Args:  cobra.NoArgs,
+	RunE:  runProfileList,
+}
+
+var profileDiffCmd = &cobra.Command{
+	Use:   "diff <name>",
+	Short: "Compare a profile with the current project",
+	Long: `Show which of a profile's skills are missing from the current project
+and which installed skills are not in the profile.
+
+Examples:
+  skulto profile diff backend`,
+	Args: cobra.ExactArgs(1),
+	RunE: runProfileDiff,
+}
+
+var profileApplyCmd = &cobra.Command{
+	Use:   "apply <name>",
+	Short: "Install a profile's skills into the current project",
+	Long: `Install the skills in a profile that are missing from the current
+project. With --prune, skills installed at the profile's scope that are not
+in the profile are uninstalled.
+
+Examples:
+  skulto profile apply backend
+  skulto profile apply backend --prune -y
+  skulto profile apply backend --dry-run`,
+	Args: cobra.ExactArgs(1),
+	RunE: runProfileApply,
+}
+
+var profileDeleteCmd = &cobra.Command{
+	Use:   "delete <name>",
+	Short: "Delete a profile",
+	Long:  `Delete a saved profile. Installed skills are not affected.`,
+	Args:  cobra.ExactArgs(1),
+	RunE:  runProfileDelete,
+}
+
+func init() {
+	profileCreateCmd.Flags().StringVarP(&profileDescription, "description", "d", "", "Profile description")
+	profileCreateCmd.Flags().StringSliceVarP(&profilePlatforms, "platform", "p", nil,
+		"Platforms to install to (repeatable); default is remembered or detected platforms")
+	profileCreateCmd.Flags().StringVarP(&profileScope, "scope", "s", "project", "Installation scope: global or project")
+
+	profileApplyCmd.Flags().BoolVar(&profilePrune, "prune", false, "Uninstall skills that are not in the profile")
+	profileApplyCmd.Flags().BoolVarP(&profileYes, "yes", "y", false, "Skip confirmation prompts")
+	profileApplyCmd.Flags().BoolVar(&profileDryRun, "dry-run", false, "Show what would change without making changes")
+	profileApplyCmd.Flags().BoolVar(&profileJSON, "json", false, "Print the --dry-run plan as JSON")
+
+	profileCmd.AddCommand(profileCreateCmd)
+	profileCmd.AddCommand(profileListCmd)
+	profileCmd.AddCommand(profileDiffCmd)
+	profileCmd.AddCommand(profileApplyCmd)
+	profileCmd.AddCommand(profileDeleteCmd)
+}
+
+// openCommandDB loads config and opens the database for a CLI command.
+func openCommandDB(command string) (*config.Config, *db.DB, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, nil, trackCLIError(command, fmt.Errorf("load config: %w", err))
+	}
+	paths := config.GetPaths(cfg)
+	database, err := db.New(db.DefaultConfig(paths.Database))
+	if err != nil {
+		return nil, nil, trackCLIError(command, fmt.Errorf("initialize database: %w", err))
+	}
+	return cfg, database, nil
+}
+
+func runProfileCreate(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	scope := installer.InstallScope(profileScope)
+	if !scope.IsValid() {
+		return trackCLIError("profile create", fmt.Errorf("invalid scope %q: use global or project", profileScope))
+	}
+	if err := validatePlatformFlags(profilePlatforms); err != nil {
+		return trackCLIError("profile create", err)
+	}
+
+	_, database, err := openCommandDB("profile create")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = database.Close() }()
+
+	slugs := args[1:]
+	if len(slugs) == 0 {
+		basePath, err := profileBasePath(scope)
+		if err != nil {
+			return trackCLIError("profile create", err)
+		}
+		installed, err := installedAtScope(database, scope, basePath)
+		if err != nil {
+			return trackCLIError("profile create", err)
+		}
+		for slug := range installed {
+			slugs = append(slugs, slug)
+		}
+		sort.Strings(slugs)
+		if len(slugs) == 0 {
+			return trackCLIError("profile create", fmt.Errorf("no skills installed at %s scope; pass skill slugs explicitly", scope))
+		}
+	}
+
+	for _, slug := range slugs {
+		skill, err := database.GetSkillBySlug(slug)
+		if err != nil {
+			return trackCLIError("profile create", fmt.Errorf("lookup skill: %w", err))
+		}
+		if skill == nil {
+			return trackCLIError("profile create", fmt.Errorf("skill not found: %s", slug))
+		}
+	}
+
+	profile := &models.Profile{
+		Name:        name,
+		Description: profileDescription,
+		Scope:       string(scope),
+	}
+	profile.SetSkills(slugs)
+	profile.SetPlatforms(profilePlatforms)
+	if err := database.UpsertProfile(profile); err != nil {
+		return trackCLIError("profile create", fmt.Errorf("save profile: %w", err))
+	}
+
+	fmt.Printf("%s profile %s (%d skill(s), %s scope)\n", cleanStyle.Render("✓ Saved"), name, len(slugs), scope)
+	return nil
+}
+
+func runProfileList(cmd *cobra.Command, args []string) error {
+	_, database, err := openCommandDB("profile list")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = database.Close() }()
+
+	profiles, err := database.ListProfiles()
+	if err != nil {
+		return trackCLIError("profile list", fmt.Errorf("list profiles: %w", err))
+	}
+	if len(profiles) == 0 {
+		fmt.Println("No profiles. Create one with 'skulto profile create <name>'.")
+		return nil
+	}
+
+	headerStyle := lipgloss.NewStyle().Bold(true)
+	for _, p := range profiles {
+		platforms := "remembered or detected"
+		if len(p.GetPlatforms()) > 0 {
+			platforms = strings.Join(p.GetPlatforms(), ", ")
+		}
+		fmt.Printf("%s (%s scope, %s)\n", headerStyle.Render(p.Name), p.Scope, platforms)
+		if p.Description != "" {
+			fmt.Printf("  %s\n", planMutedStyle.Render(p.Description))
+		}
+		fmt.Printf("  %s\n", strings.Join(p.GetSkills(), ", "))
+	}
+	return nil
+}
+
+// ProfileDiff compares a profile's skills with what is installed at its scope.
+type ProfileDiff struct {
+	Missing []string // In the profile, not installed
+	Extra   []string // Installed, not in the profile
+	Present []string // In the profile and installed
+}
+
+// diffProfile compares a profile with the skills installed at its scope
+// under basePath. Extra maps each extra slug to the locations it occupies.
+func diffProfile(database *db.DB, profile *models.Profile, basePath string) (*ProfileDiff, map[string][]installer.InstallLocation, error) {
+	installed, err := installedAtScope(database, installer.InstallScope(profile.Scope), basePath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	diff := &ProfileDiff{}
+	wanted := make(map[string]bool)
+	for _, slug := range profile.GetSkills() {
+		wanted[slug] = true
+		if _, ok := installed[slug]; ok {
+			diff.Present = append(diff.Present, slug)
+		} else {
+			diff.Missing = append(diff.Missing, slug)
+		}
+	}
+	extra := make(map[string][]installer.InstallLocation)
+	for slug, locs := range installed {
+		if !wanted[slug] {
+			diff.Extra = append(diff.Extra, slug)
+			extra[slug] = locs
+		}
+	}
+	sort.Strings(diff.Extra)
+	return diff, extra, nil
+}
+
+// installedAtScope returns the slugs installed at scope under basePath,
+// with the locations each occupies.
+func installedAtScope(database *db.DB, scope installer.InstallScope, basePath string) (map[string][]installer.InstallLocation, error) {
+	rows, err := database.GetAllInstallations()
+	if err != nil {
+		return nil, fmt.Errorf("get installations: %w", err)
+	}
+	installed := make(map[string][]installer.InstallLocation)
+	for _, row := range rows {
+		if row.Scope != string(scope) || row.BasePath != basePath {
+			continue
+		}
+		skill, err := database.GetSkill(row.SkillID)
+		if err != nil || skill == nil {
+			continue
+		}
+		installed[skill.Slug] = append(installed[skill.Slug], installer.InstallLocation{
+			Platform: installer.Platform(row.Platform),
+			Scope:    scope,
+			BasePath: row.BasePath,
+		})
+	}
+	return installed, nil
+}
+
+// profileBasePath returns the base path a scope resolves to: the working
+// directory for project scope, the home directory for global scope.
+func profileBasePath(scope installer.InstallScope) (string, error) {
+	if scope == installer.ScopeGlobal {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("get home directory: %w", err)
+		}
+		return home, nil
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", fmt.Errorf("get working directory: %w", err)
+	}
+	return cwd, nil
+}
+
+// profileInstallOptions returns where a profile's skills are installed: its
+// own platforms, then remembered platforms, then detected platforms, all at
+// the profile's scope.
+func profileInstallOptions(database *db.DB, profile *models.Profile) ([]installer.InstallOptions, error) {
+	scope := installer.InstallScope(profile.Scope)
+	if !scope.IsValid() {
+		scope = installer.ScopeProject
+	}
+
+	platforms := profile.GetPlatforms()
+	if len(platforms) == 0 {
+		if remember, _ := database.GetRememberInstallLocations(); remember {
+			saved, err := database.GetEnabledAgentScopes()
+			if err == nil {
+				for platformID := range saved {
+					platforms = append(platforms, platformID)
+				}
+				sort.Strings(platforms)
+			}
+		}
+	}
+	if len(platforms) == 0 {
+		platforms = getDetectedPlatformIDs()
+	}
+	if len(platforms) == 0 {
+		return nil, fmt.Errorf("no platforms detected; recreate the profile with --platform")
+	}
+
+	return []installer.InstallOptions{{
+		Platforms: platforms,
+		Scopes:    []installer.InstallScope{scope},
+		Confirm:   true,
+	}}, nil
+}
+
+// loadProfile fetches a profile, returning an error if it does not exist.
+func loadProfile(database *db.DB, name string) (*models.Profile, error) {
+	profile, err := database.GetProfile(name)
+	if err != nil {
+		return nil, fmt.Errorf("load profile: %w", err)
+	}
+	if profile == nil {
+		return nil, fmt.Errorf("profile not found: %s", name)
+	}
+	return profile, nil
+}
+
+func runProfileDiff(cmd *cobra.Command, args []string) error {
+	_, database, err := openCommandDB("profile diff")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = database.Close() }()
+
+	profile, err := loadProfile(database, args[0])
+	if err != nil {
+		return trackCLIError("profile diff", err)
+	}
+	basePath, err := profileBasePath(installer.InstallScope(profile.Scope))
+	if err != nil {
+		return trackCLIError("profile diff", err)
+	}
+	diff, _, err := diffProfile(database, profile, basePath)
+	if err != nil {
+		return trackCLIError("profile diff", err)
+	}
+
+	fmt.Printf("%s %s (%s scope, %s)\n", planHeaderStyle.Render("PROFILE"), profile.Name, profile.Scope, basePath)
+	fmt.Println(strings.Repeat("─", 50))
+	for _, slug := range diff.Missing {
+		fmt.Printf("  %s %s %s\n", planCreateStyle.Render("+"), slug, planMutedStyle.Render("(missing)"))
+	}
+	for _, slug := range diff.Extra {
+		fmt.Printf("  %s %s %s\n", planRemoveStyle.Render("-"), slug, planMutedStyle.Render("(not in profile)"))
+	}
+	for _, slug := range diff.Present {
+		fmt.Printf("  %s %s\n", planMutedStyle.Render("="), planMutedStyle.Render(slug))
+	}
+	fmt.Printf("\nMissing: %d, Extra: %d, Installed: %d\n", len(diff.Missing), len(diff.Extra), len(diff.Present))
+	return nil
+}
+
+func runProfileDelete(cmd *cobra.Command, args []string) error {
+	_, database, err := openCommandDB("profile delete")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = database.Close() }()
+
+	if _, err := loadProfile(database, args[0]); err != nil {
+		return trackCLIError("profile delete", err)
+	}
+	if err := database.DeleteProfile(args[0]); err != nil {
+		return trackCLIError("profile delete", fmt.Errorf("delete profile: %w", err))
+	}
+	fmt.Printf("%s profile %s\n", cleanStyle.Render("✓ Deleted"), args[0])
+	return nil
+}
+
+func runProfileApply(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	cfg, database, err := openCommandDB("profile apply")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = database.Close() }()
+
+	profile, err := loadProfile(database, args[0])
+	if err != nil {
+		return trackCLIError("profile apply", err)
+	}
+	basePath, err := profileBasePath(installer.InstallScope(profile.Scope))
+	if err != nil {
+		return trackCLIError("profile apply", err)
+	}
+	diff, extra, err := diffProfile(database, profile, basePath)
+	if err != nil {
+		return trackCLIError("profile apply", err)
+	}
+	plan, err := profileInstallOptions(database, profile)
+	if err != nil {
+		return trackCLIError("profile apply", err)
+	}
+
+	service := installer.NewInstallService(database, cfg, telemetryClient)
+
+	if profileDryRun {
+		dry := installer.NewPlan("profile apply")
+		for _, slug := range diff.Missing {
+			for _, opts := range plan {
+				p, err := service.PlanInstall(ctx, slug, opts)
+				if err != nil {
+					dry.AddError("%s: %v", slug, err)
+					continue
+				}
+				dry.Merge(p)
+			}
+		}
+		if profilePrune {
+			for _, slug := range diff.Extra {
+				p, err := service.PlanUninstall(ctx, slug, extra[slug])
+				if err != nil {
+					dry.AddError("%s: %v", slug, err)
+					continue
+				}
+				dry.Merge(p)
+			}
+		}
+		return printPlan(dry, profileJSON)
+	}
+
+	fmt.Printf("%s %s (%d skill(s))\n", planHeaderStyle.Render("APPLYING profile"), profile.Name, len(profile.GetSkills()))
+	fmt.Println(strings.Repeat("─", 50))
+
+	installed, errored := 0, 0
+	for _, opts := range plan {
+		for _, result := range service.InstallBatch(ctx, diff.Missing, opts) {
+			slug := ""
+			if result.Skill != nil {
+				slug = result.Skill.Slug
+			}
+			if len(result.Errors) > 0 {
+				fmt.Printf("  %s %s: %v\n", errorStyle.Render("✗"), slug, result.Errors[0])
+				errored++
+				continue
+			}
+			fmt.Printf("  %s %s\n", cleanStyle.Render("✓"), slug)
+			installed++
+		}
+	}
+	for _, slug := range diff.Present {
+		fmt.Printf("  %s %s (already installed)\n", planMutedStyle.Render("o"), slug)
+	}
+
+	removed := 0
+	if profilePrune && len(diff.Extra) > 0 {
+		removed, err = pruneProfileExtras(ctx, cfg, service, database, profile, diff.Extra, extra)
+		if err != nil {
+			return trackCLIError("profile apply", err)
+		}
+	}
+
+	fmt.Println()
+	fmt.Println(strings.Repeat("─", 50))
+	fmt.Printf("Done! Installed: %d, Already installed: %d", installed, len(diff.Present))
+	if profilePrune {
+		fmt.Printf(", Removed: %d", removed)
+	}
+	if errored > 0 {
+		fmt.Printf(", Errors: %d", errored)
+	}
+	fmt.Println()
+	return nil
+}
+
+// pruneProfileExtras uninstalls skills that are not in the profile after
+// confirming with the user. Removals are recorded in the undo journal.
+func pruneProfileExtras(
+	ctx context.Context,
+	cfg *config.Config,
+	service *installer.InstallService,
+	database *db.DB,
+	profile *models.Profile,
+	slugs []string,
+	locations map[string][]installer.InstallLocation,
+) (int, error) {
+	fmt.Printf("\nSkills not in profile: %s\n", strings.Join(slugs, ", "))
+	summary := fmt.Sprintf("prune %d skill(s) for profile %s", len(slugs), profile.Name)
+	return pruneSkills(ctx, cfg, service, database, "profile", summary, slugs, locations, profileYes)
+}
+
+// pruneSkills uninstalls slugs from the given locations, asking for
+// confirmation unless yes is set. Removals are recorded in the undo journal
+// under command. Returns the number of skills removed.
+func pruneSkills(
+	ctx context.Context,
+	cfg *config.Config,
+	service *installer.InstallService,
+	database *db.DB,
+	command, summary string,
+	slugs []string,
+	locations map[string][]installer.InstallLocation,
+	yes bool,
+) (int, error) {
+	if !yes {
+		if !isInteractive() {
+			return 0, fmt.Errorf("refusing to prune without confirmation; pass -y")
+		}
+		fmt.Print("Uninstall them? [y/N] ")
+		answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+		answer = strings.TrimSpace(strings.ToLower(answer))
+		if answer != "y" && answer != "yes" {
+			fmt.Println("Skipped pruning.")
+			return 0, nil
+		}
+	}
+
+	rec := openJournal(cfg, database).Begin(command, summary)
+	removed := 0
+	for _, slug := range slugs {
+		skill, err := database.GetSkillBySlug(slug)
+		if err != nil || skill == nil {
+			continue
+		}
+		installer.RecordUninstall(rec, database, skill, locations[slug])
+		if err := service.Uninstall(ctx, slug, locations[slug]); err != nil {
+			fmt.Printf("  %s %s: %v\n", errorStyle.Render("✗"), slug, err)
+			continue
+		}
+		fmt.Printf("  %s %s\n", planRemoveStyle.Render("-"), slug)
+		removed++
+	}
+	commitJournal(rec)
+	return removed, nil
+}
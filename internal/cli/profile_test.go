@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/asteroid-belt/skulto/internal/installer"
+	"github.com/asteroid-belt/skulto/internal/models"
+)
+
+func TestProfileCmd_Structure(t *testing.T) {
+	assert.Equal(t, "profile", profileCmd.Use)
+	names := map[string]bool{}
+	for _, sub := range profileCmd.Commands() {
+		names[sub.Name()] = true
+	}
+	for _, want := range []string{"create", "list", "diff", "apply", "delete"} {
+		assert.True(t, names[want], "missing subcommand %s", want)
+	}
+
+	assert.NotNil(t, profileCreateCmd.Flags().Lookup("platform"))
+	assert.NotNil(t, profileCreateCmd.Flags().Lookup("scope"))
+	assert.NotNil(t, profileApplyCmd.Flags().Lookup("prune"))
+	assert.NotNil(t, profileApplyCmd.Flags().Lookup("yes"))
+	assert.NotNil(t, profileApplyCmd.Flags().Lookup("dry-run"))
+}
+
+func TestDiffProfile(t *testing.T) {
+	database := testDB(t)
+	base := t.TempDir()
+
+	for _, slug := range []string{"kept", "missing", "extra"} {
+		require.NoError(t, database.CreateSkill(&models.Skill{ID: slug + "-id", Slug: slug, Title: slug}))
+	}
+	for _, id := range []string{"kept-id", "extra-id"} {
+		inst := &models.SkillInstallation{SkillID: id, Platform: "claude", Scope: "project", BasePath: base}
+		require.NoError(t, database.AddInstallation(inst))
+	}
+	// Installed elsewhere: ignored
+	other := &models.SkillInstallation{SkillID: "missing-id", Platform: "claude", Scope: "project", BasePath: t.TempDir()}
+	require.NoError(t, database.AddInstallation(other))
+
+	profile := &models.Profile{Name: "p", Scope: "project"}
+	profile.SetSkills([]string{"kept", "missing"})
+
+	diff, extra, err := diffProfile(database, profile, base)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"missing"}, diff.Missing)
+	assert.Equal(t, []string{"kept"}, diff.Present)
+	assert.Equal(t, []string{"extra"}, diff.Extra)
+	assert.Equal(t, []installer.InstallLocation{{Platform: installer.PlatformClaude, Scope: installer.ScopeProject, BasePath: base}}, extra["extra"])
+}
+
+func TestProfileInstallOptions_UsesProfilePlatforms(t *testing.T) {
+	database := testDB(t)
+	profile := &models.Profile{Name: "p", Scope: "global"}
+	profile.SetPlatforms([]string{"claude", "cursor"})
+
+	opts, err := profileInstallOptions(database, profile)
+	require.NoError(t, err)
+	require.Len(t, opts, 1)
+	assert.Equal(t, []string{"claude", "cursor"}, opts[0].Platforms)
+	assert.Equal(t, []installer.InstallScope{installer.ScopeGlobal}, opts[0].Scopes)
+}
@@ -2,7 +2,11 @@ package cli
 
 import (
 	"fmt"
+	"io"
+	"os"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 )
@@ -93,3 +97,134 @@ func (p *ProgressBar) RenderScan() string {
 func ClearLine() {
 	fmt.Print("\r\033[K")
 }
+
+// MultiProgress shows an overall progress bar with one line per item in
+// flight, for work that runs in parallel. On a terminal the block is redrawn
+// in place and failures are printed above it as they happen; otherwise each
+// finished item is printed on its own line. Safe for concurrent use.
+type MultiProgress struct {
+	mu     sync.Mutex
+	out    io.Writer
+	live   bool
+	bar    *ProgressBar
+	done   int
+	active []progressItem
+	drawn  int // Lines in the block currently on screen
+}
+
+type progressItem struct {
+	name    string
+	started time.Time
+}
+
+// NewMultiProgress creates a progress display for total items. With live
+// false (output is not a terminal), nothing is redrawn.
+func NewMultiProgress(out io.Writer, total int, live bool) *MultiProgress {
+	return &MultiProgress{
+		out:  out,
+		live: live,
+		bar:  NewProgressBar(total, 15),
+	}
+}
+
+// Start marks an item as in flight.
+func (m *MultiProgress) Start(name string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.active = append(m.active, progressItem{name: name, started: time.Now()})
+	m.redraw("")
+}
+
+// Done marks an item as finished. A non-nil err is printed above the block.
+func (m *MultiProgress) Done(name string, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	elapsed := time.Duration(0)
+	for i, item := range m.active {
+		if item.name == name {
+			elapsed = time.Since(item.started)
+			m.active = append(m.active[:i], m.active[i+1:]...)
+			break
+		}
+	}
+	m.done++
+	m.bar.Update(m.done, "")
+
+	var line string
+	if err != nil {
+		line = fmt.Sprintf("   %s %s: %v", errorStyle.Render("x"), name, err)
+	} else if !m.live {
+		line = fmt.Sprintf("   ✓ %s (%s)", name, formatElapsed(elapsed))
+	}
+	m.redraw(line)
+}
+
+// Refresh redraws the block so elapsed times stay current.
+func (m *MultiProgress) Refresh() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.redraw("")
+}
+
+// Finish removes the block from the screen.
+func (m *MultiProgress) Finish() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.clear()
+}
+
+// redraw prints line (if any) permanently, then the block below it.
+func (m *MultiProgress) redraw(line string) {
+	if !m.live {
+		if line != "" {
+			_, _ = fmt.Fprintln(m.out, line)
+		}
+		return
+	}
+
+	m.clear()
+	if line != "" {
+		_, _ = fmt.Fprintln(m.out, line)
+	}
+
+	countStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B6B6B"))
+	lines := []string{"   " + m.bar.Render()}
+	for _, item := range m.active {
+		lines = append(lines, countStyle.Render(fmt.Sprintf("     ↻ %s  %s", item.name, formatElapsed(time.Since(item.started)))))
+	}
+	for _, l := range lines {
+		_, _ = fmt.Fprintln(m.out, l)
+	}
+	m.drawn = len(lines)
+}
+
+// clear erases the block drawn last.
+func (m *MultiProgress) clear() {
+	if m.live && m.drawn > 0 {
+		_, _ = fmt.Fprintf(m.out, "\033[%dF\033[J", m.drawn)
+	}
+	m.drawn = 0
+}
+
+// formatElapsed renders a duration for progress lines: "850ms", "12s", "2m05s".
+func formatElapsed(d time.Duration) string {
+	switch {
+	case d < time.Second:
+		return fmt.Sprintf("%dms", d.Milliseconds())
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	default:
+		return fmt.Sprintf("%dm%02ds", int(d.Minutes()), int(d.Seconds())%60)
+	}
+}
+
+// isTerminalOutput reports whether stdout is a terminal, so progress can be
+// redrawn in place.
+func isTerminalOutput() bool {
+	fi, err := os.Stdout.Stat()
+	if err != nil {
+		return false
+	}
+	return (fi.Mode() & os.ModeCharDevice) != 0
+}
@@ -0,0 +1,59 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMultiProgress_PlainOutput(t *testing.T) {
+	var out bytes.Buffer
+	p := NewMultiProgress(&out, 2, false)
+
+	p.Start("acme/one")
+	p.Start("acme/two")
+	p.Done("acme/one", nil)
+	p.Done("acme/two", errors.New("clone failed"))
+	p.Finish()
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	assert.Len(t, lines, 2, "one line per finished repository")
+	assert.Contains(t, lines[0], "✓ acme/one")
+	assert.Contains(t, lines[1], "acme/two: clone failed")
+	assert.NotContains(t, out.String(), "\033[", "no cursor movement when not a terminal")
+}
+
+func TestMultiProgress_LiveOutput(t *testing.T) {
+	var out bytes.Buffer
+	p := NewMultiProgress(&out, 2, true)
+
+	p.Start("acme/one")
+	assert.Contains(t, out.String(), "acme/one", "in-flight repositories are listed")
+	assert.Contains(t, out.String(), "0/2")
+
+	out.Reset()
+	p.Start("acme/two")
+	// The previous two-line block is erased before redrawing three lines
+	assert.True(t, strings.HasPrefix(out.String(), "\033[2F\033[J"), "got %q", out.String())
+	assert.Equal(t, 3, strings.Count(out.String(), "\n"))
+
+	out.Reset()
+	p.Done("acme/one", errors.New("timed out"))
+	assert.Contains(t, out.String(), "acme/one: timed out", "failures stay on screen")
+	assert.Contains(t, out.String(), "1/2")
+
+	out.Reset()
+	p.Done("acme/two", nil)
+	p.Finish()
+	assert.True(t, strings.HasSuffix(out.String(), "\033[1F\033[J"), "Finish erases the block, got %q", out.String())
+}
+
+func TestFormatElapsed(t *testing.T) {
+	assert.Equal(t, "850ms", formatElapsed(850*time.Millisecond))
+	assert.Equal(t, "12s", formatElapsed(12*time.Second))
+	assert.Equal(t, "2m05s", formatElapsed(125*time.Second))
+}
@@ -3,15 +3,19 @@ package cli
 import (
 	"context"
 	"fmt"
+	"os"
 	"time"
 
 	"github.com/asteroid-belt/skulto/internal/config"
 	"github.com/asteroid-belt/skulto/internal/db"
 	"github.com/asteroid-belt/skulto/internal/installer"
+	"github.com/asteroid-belt/skulto/internal/models"
 	"github.com/asteroid-belt/skulto/internal/scraper"
 	"github.com/spf13/cobra"
 )
 
+var pullJobs int
+
 var pullCmd = &cobra.Command{
 	Use:     "pull",
 	Aliases: []string{"p"},
@@ -19,17 +23,34 @@ var pullCmd = &cobra.Command{
 	Long: `Pull and sync all skill repositories, then reconcile installed skills.
 
 This command:
-  1. Clones/updates all registered skill repositories
+  1. Clones/updates all registered skill repositories, several at a time
   2. Scans AI tool directories to detect installed skills
   3. Reconciles database state with filesystem reality
 
+Repositories are pulled in parallel: up to --jobs at once (default 8, or
+SKULTO_PULL_CONCURRENCY), at most SKULTO_PULL_PER_HOST (default 4) from the
+same host, each limited to SKULTO_PULL_TIMEOUT (default 5m). Failures are
+listed together at the end.
+
+Registry indexes in SKULTO_REGISTRIES are synced first, so newly listed
+sources are cloned too (see 'skulto registry'). With SKULTO_CACHE_BUDGET
+set, clones no installed or favorited skill needs are then trimmed to fit
+it (see 'skulto cache').
+
 Examples:
   # Pull all repositories and sync install state
-  skulto pull`,
+  skulto pull
+
+  # Pull one repository at a time
+  skulto pull --jobs 1`,
 	Args: cobra.NoArgs,
 	RunE: runPull,
 }
 
+func init() {
+	pullCmd.Flags().IntVarP(&pullJobs, "jobs", "j", 0, "Repositories to pull at once (default from SKULTO_PULL_CONCURRENCY, or 8)")
+}
+
 func runPull(cmd *cobra.Command, args []string) error {
 	ctx := cmd.Context()
 
@@ -47,6 +68,8 @@ func runPull(cmd *cobra.Command, args []string) error {
 		_ = database.Close()
 	}()
 
+	syncConfiguredRegistries(ctx, cfg, database)
+
 	// Get all sources
 	sources, err := database.ListSources()
 	if err != nil {
@@ -62,49 +85,20 @@ func runPull(cmd *cobra.Command, args []string) error {
 	fmt.Println()
 
 	// Create scraper
-	scraperCfg := scraper.ScraperConfig{
-		Token:        cfg.GitHub.Token,
-		DataDir:      cfg.BaseDir,
-		RepoCacheTTL: cfg.GitHub.RepoCacheTTL,
-		UseGitClone:  cfg.GitHub.UseGitClone,
-	}
+	scraperCfg := scraper.ScraperConfigFromConfig(cfg)
 	s := scraper.NewScraperWithConfig(scraperCfg, database)
 
-	// Initialize progress bar
-	progress := NewProgressBar(len(sources), 15)
-	reposErrored := 0
-	totalThreats := 0
+	results := pullSources(ctx, cfg, s, sources, pullJobs)
 
-	// Sync each repository
-	for i, source := range sources {
-		repoName := fmt.Sprintf("%s/%s", source.Owner, source.Repo)
-		progress.Update(i+1, repoName)
-		ClearLine()
-		fmt.Print("   " + progress.Render())
-
-		syncCtx, cancel := context.WithTimeout(ctx, 5*time.Minute)
-		result, err := s.ScrapeRepository(syncCtx, source.Owner, source.Repo)
-		cancel()
-
-		if err != nil {
-			ClearLine()
-			fmt.Printf("   x %s: %v\n", repoName, err)
-			reposErrored++
+	totalThreats := 0
+	for _, r := range results {
+		if r.Err != nil {
 			continue
 		}
-
-		totalThreats += result.SkillsWithThreats
+		totalThreats += r.Result.SkillsWithThreats
 
 		// Track telemetry per repo
-		telemetryClient.TrackRepoSynced(source.ID, result.SkillsNew, 0, result.SkillsUpdated)
-	}
-
-	// Final progress
-	ClearLine()
-	if reposErrored > 0 {
-		fmt.Printf("   ✓ Pull complete (%d errors)\n", reposErrored)
-	} else {
-		fmt.Println("   ✓ Pull complete")
+		telemetryClient.TrackRepoSynced(r.Source.ID, r.Result.SkillsNew, 0, r.Result.SkillsUpdated)
 	}
 	if totalThreats > 0 {
 		fmt.Printf("   ⚠ %d skill(s) with security warnings\n", totalThreats)
@@ -122,7 +116,72 @@ func runPull(cmd *cobra.Command, args []string) error {
 		fmt.Println("   ✓ Install state reconciled")
 	}
 
+	trimCacheToBudget(ctx, cfg, database)
+
 	fmt.Println("\nPull complete!")
 
 	return nil
 }
+
+// pullSources pulls sources in parallel under cfg.Pull's limits (jobs, if
+// positive, overrides the concurrency), showing progress as it goes and
+// listing every failure at the end.
+func pullSources(ctx context.Context, cfg *config.Config, s *scraper.Scraper, sources []models.Source, jobs int) []scraper.SourceResult {
+	concurrency := cfg.Pull.Concurrency
+	if jobs > 0 {
+		concurrency = jobs
+	}
+
+	live := isTerminalOutput()
+	progress := NewMultiProgress(os.Stdout, len(sources), live)
+
+	// Keep elapsed times moving while slow repositories run
+	stop := make(chan struct{})
+	if live {
+		go func() {
+			ticker := time.NewTicker(500 * time.Millisecond)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ticker.C:
+					progress.Refresh()
+				case <-stop:
+					return
+				}
+			}
+		}()
+	}
+
+	results := s.PullSources(ctx, sources, scraper.PullOptions{
+		MaxConcurrency: concurrency,
+		MaxPerHost:     cfg.Pull.PerHost,
+		RepoTimeout:    cfg.Pull.Timeout,
+		OnStart: func(source *models.Source) {
+			progress.Start(source.ID)
+		},
+		OnDone: func(r scraper.SourceResult) {
+			progress.Done(r.Source.ID, r.Err)
+		},
+	})
+	close(stop)
+	progress.Finish()
+
+	var failed []scraper.SourceResult
+	for _, r := range results {
+		if r.Err != nil {
+			failed = append(failed, r)
+		}
+	}
+	if len(failed) == 0 {
+		fmt.Printf("   ✓ Pulled %d repositories\n", len(results))
+		return results
+	}
+
+	fmt.Printf("   ✓ Pulled %d of %d repositories\n", len(results)-len(failed), len(results))
+	fmt.Printf("\n   %s Failed (%d):\n", errorStyle.Render("x"), len(failed))
+	for _, r := range failed {
+		fmt.Printf("     %s: %v\n", r.Source.ID, r.Err)
+	}
+	fmt.Println()
+	return results
+}
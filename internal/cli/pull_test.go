@@ -27,3 +27,10 @@ func TestPullCmd_ArgsValidation(t *testing.T) {
 	err = validator(pullCmd, []string{"unexpected"})
 	assert.Error(t, err)
 }
+
+func TestPullCmd_JobsFlag(t *testing.T) {
+	flag := pullCmd.Flags().Lookup("jobs")
+	assert.NotNil(t, flag)
+	assert.Equal(t, "j", flag.Shorthand)
+	assert.NotNil(t, updateCmd.Flags().Lookup("jobs"))
+}
@@ -0,0 +1,229 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/asteroid-belt/skulto/internal/config"
+	"github.com/asteroid-belt/skulto/internal/db"
+	"github.com/asteroid-belt/skulto/internal/registry"
+	"github.com/spf13/cobra"
+)
+
+var registryCmd = &cobra.Command{
+	Use:   "registry",
+	Short: "Discover sources from registry indexes",
+	Long: `Discover sources from registry indexes: JSON files, served over https or
+read from disk, listing repositories with a trust tier, description and tags.
+Organizations can host their own index to publish the repositories their
+teams should use.
+
+Configure indexes with SKULTO_REGISTRIES (comma-separated URLs or paths);
+'skulto pull' syncs them before pulling.
+
+Subcommands:
+  list                 Show sources listed by registries
+  sync [location...]   Merge registry indexes into your sources`,
+	Args: cobra.NoArgs,
+}
+
+var registrySyncCmd = &cobra.Command{
+	Use:   "sync [location...]",
+	Short: "Merge registry indexes into your sources",
+	Long: `Fetch registry indexes and merge them into your sources. With no arguments
+the indexes in SKULTO_REGISTRIES are synced.
+
+Listed sources are added if new. Official and curated entries are marked as
+such and ranked by the index's priority. Sources an index no longer lists
+become ordinary sources; their skills and installs are kept.
+
+An index looks like:
+
+  {
+    "version": 1,
+    "name": "Acme skills",
+    "sources": [
+      {"url": "https://gitlab.acme.dev/platform/skills", "tier": "curated",
+       "description": "Platform team conventions", "tags": ["go"]}
+    ]
+  }
+
+Run 'skulto pull' afterwards to clone new sources.
+
+Examples:
+  skulto registry sync
+  skulto registry sync https://skills.acme.dev/index.json
+  skulto registry sync ./registry.json`,
+	RunE: runRegistrySync,
+}
+
+var registryListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Show sources listed by registries",
+	Long: `Show the sources each registry index lists, with their tier and tags.
+
+Examples:
+  skulto registry list`,
+	Args: cobra.NoArgs,
+	RunE: runRegistryList,
+}
+
+func init() {
+	registryCmd.AddCommand(registryListCmd)
+	registryCmd.AddCommand(registrySyncCmd)
+}
+
+func runRegistrySync(cmd *cobra.Command, args []string) error {
+	cfg, database, err := openCommandDB("registry sync")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = database.Close() }()
+
+	locations := args
+	if len(locations) == 0 {
+		locations = cfg.Registries
+	}
+	if len(locations) == 0 {
+		return trackCLIError("registry sync", errors.New("no registries configured; set SKULTO_REGISTRIES or pass an index URL or file"))
+	}
+
+	if failed := syncRegistries(cmd.Context(), database, locations); failed > 0 {
+		return trackCLIError("registry sync", fmt.Errorf("%d of %d registries failed", failed, len(locations)))
+	}
+	return nil
+}
+
+// syncRegistries fetches and merges each index, printing what changed. It
+// returns how many indexes failed; the rest are still merged.
+func syncRegistries(ctx context.Context, database *db.DB, locations []string) int {
+	failed := 0
+	for _, location := range locations {
+		location = registryLocation(location)
+		index, err := registry.Fetch(ctx, location)
+		if err == nil {
+			var result *registry.MergeResult
+			result, err = registry.Merge(database, location, index)
+			if err == nil {
+				printMergeResult(location, index, result)
+				continue
+			}
+		}
+		failed++
+		fmt.Printf("   %s %s: %v\n", errorStyle.Render("x"), location, err)
+	}
+	return failed
+}
+
+// registryLocation makes local index paths absolute, so the same file is
+// recognized as the same registry from any directory.
+func registryLocation(location string) string {
+	if strings.Contains(location, "://") {
+		return location
+	}
+	if abs, err := filepath.Abs(location); err == nil {
+		return abs
+	}
+	return location
+}
+
+// printMergeResult summarizes one merged index.
+func printMergeResult(location string, index *registry.Index, result *registry.MergeResult) {
+	name := location
+	if index.Name != "" {
+		name = fmt.Sprintf("%s (%s)", index.Name, location)
+	}
+	fmt.Printf("   %s %s: %d added, %d updated, %d removed\n",
+		cleanStyle.Render("✓"), name, len(result.Added), len(result.Updated), len(result.Removed))
+	for _, id := range result.Added {
+		fmt.Printf("     + %s\n", id)
+	}
+	for _, id := range result.Removed {
+		fmt.Printf("     - %s (no longer listed)\n", id)
+	}
+	for _, reason := range result.Skipped {
+		fmt.Printf("     ! skipped %s\n", reason)
+	}
+}
+
+func runRegistryList(cmd *cobra.Command, args []string) error {
+	cfg, database, err := openCommandDB("registry list")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = database.Close() }()
+
+	sources, err := database.ListSources()
+	if err != nil {
+		return trackCLIError("registry list", fmt.Errorf("list sources: %w", err))
+	}
+
+	byRegistry := make(map[string][]string)
+	for _, s := range sources {
+		if s.Registry == "" {
+			continue
+		}
+		line := fmt.Sprintf("%s [%s]", s.ID, sourceTier(s.IsOfficial, s.IsCurated))
+		if s.Tags != "" {
+			line += " " + strings.ReplaceAll(s.Tags, ",", ", ")
+		}
+		byRegistry[s.Registry] = append(byRegistry[s.Registry], line)
+	}
+
+	// Configured registries that haven't listed anything still show up
+	for _, location := range cfg.Registries {
+		location = registryLocation(location)
+		if _, ok := byRegistry[location]; !ok {
+			byRegistry[location] = nil
+		}
+	}
+	if len(byRegistry) == 0 {
+		fmt.Println("No registries. Set SKULTO_REGISTRIES or run 'skulto registry sync <index>'.")
+		return nil
+	}
+
+	locations := make([]string, 0, len(byRegistry))
+	for location := range byRegistry {
+		locations = append(locations, location)
+	}
+	sort.Strings(locations)
+	for _, location := range locations {
+		fmt.Println(planHeaderStyle.Render(location))
+		if len(byRegistry[location]) == 0 {
+			fmt.Println("  (not synced; run 'skulto registry sync')")
+		}
+		sort.Strings(byRegistry[location])
+		for _, line := range byRegistry[location] {
+			fmt.Printf("  %s\n", line)
+		}
+		fmt.Println()
+	}
+	return nil
+}
+
+// sourceTier names a source's trust tier.
+func sourceTier(official, curated bool) string {
+	switch {
+	case official:
+		return "official"
+	case curated:
+		return "curated"
+	default:
+		return "community"
+	}
+}
+
+// syncConfiguredRegistries merges cfg.Registries before a pull, so newly
+// listed sources are cloned with the rest. Failures are warnings.
+func syncConfiguredRegistries(ctx context.Context, cfg *config.Config, database *db.DB) {
+	if len(cfg.Registries) == 0 {
+		return
+	}
+	fmt.Println("Syncing registries...")
+	syncRegistries(ctx, database, cfg.Registries)
+	fmt.Println()
+}
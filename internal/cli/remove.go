@@ -3,6 +3,7 @@ package cli
 import (
 	"context"
 	"fmt"
+	"os"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -16,7 +17,11 @@ import (
 	"github.com/asteroid-belt/skulto/internal/tui/components"
 )
 
-var removeForce bool
+var (
+	removeForce  bool
+	removeDryRun bool
+	removeJSON   bool
+)
 
 var removeCmd = &cobra.Command{
 	Use:     "remove [repository]",
@@ -28,7 +33,10 @@ This will:
   - Uninstall all skills from the repository (remove symlinks)
   - Delete skill records from the database
   - Remove the repository from the database
-  - Delete the local git clone
+  - Move the local git clone into the undo journal (a local directory
+    source is left in place)
+
+Run 'skulto undo' to reverse the removal.
 
 Supports multiple formats:
   - owner/repo                    (short format)
@@ -48,6 +56,9 @@ Examples:
   # Remove with force (skip confirmation)
   skulto remove asteroid-belt/skills --force
 
+  # Preview what would be deleted
+  skulto remove asteroid-belt/skills --dry-run
+
   # Interactive selection
   skulto remove`,
 	Args: cobra.MaximumNArgs(1),
@@ -56,6 +67,8 @@ Examples:
 
 func init() {
 	removeCmd.Flags().BoolVarP(&removeForce, "force", "f", false, "Skip confirmation prompt")
+	removeCmd.Flags().BoolVar(&removeDryRun, "dry-run", false, "Show what would be removed without making changes")
+	removeCmd.Flags().BoolVar(&removeJSON, "json", false, "Print the --dry-run plan as JSON")
 }
 
 func runRemove(cmd *cobra.Command, args []string) error {
@@ -90,22 +103,40 @@ func runRemove(cmd *cobra.Command, args []string) error {
 	} else {
 		// Parse repository URL
 		repoURL := args[0]
-		fmt.Printf("Parsing repository URL: %s\n", repoURL)
-
-		parsed, err := scraper.ParseRepositoryURL(repoURL)
-		if err != nil {
-			return fmt.Errorf("invalid repository URL: %w", err)
+		if !removeJSON {
+			fmt.Printf("Parsing repository URL: %s\n", repoURL)
 		}
 
-		source, err = database.GetSource(parsed.ID)
+		// Source IDs are accepted as-is, so local sources whose directory
+		// is gone can still be removed
+		source, err = database.GetSource(repoURL)
 		if err != nil {
 			return fmt.Errorf("failed to check source: %w", err)
 		}
 		if source == nil {
-			return fmt.Errorf("repository %s not found in database", parsed.ID)
+			parsed, err := scraper.ParseRepositoryURL(repoURL)
+			if err != nil {
+				return fmt.Errorf("invalid repository URL: %w", err)
+			}
+
+			source, err = database.GetSource(parsed.ID)
+			if err != nil {
+				return fmt.Errorf("failed to check source: %w", err)
+			}
+			if source == nil {
+				return fmt.Errorf("repository %s not found in database", parsed.ID)
+			}
 		}
 	}
 
+	if removeDryRun {
+		plan, err := planRemoval(cfg, database, source)
+		if err != nil {
+			return err
+		}
+		return printPlan(plan, removeJSON)
+	}
+
 	// Show confirmation unless --force is set
 	if !removeForce {
 		confirmed := showRemoveConfirmation(source)
@@ -131,6 +162,12 @@ func executeRemoval(ctx context.Context, cfg *config.Config, database *db.DB, so
 	}
 	fmt.Printf("      Found %d skills\n", len(skills))
 
+	// Everything below is recorded so `skulto undo` can restore it
+	rec := openJournal(cfg, database).Begin("remove",
+		fmt.Sprintf("remove %s (%d skills)", source.ID, len(skills)))
+	rec.RecordSource(*source)
+	rec.RecordSkills(skills)
+
 	// Step 2: Uninstall all skills (remove symlinks)
 	if len(skills) > 0 {
 		fmt.Println("\n[2/4] Uninstalling skills...")
@@ -141,6 +178,9 @@ func executeRemoval(ctx context.Context, cfg *config.Config, database *db.DB, so
 			// Use skill_installations as source of truth for installed status
 			hasInstalls, _ := database.HasInstallations(skill.ID)
 			if hasInstalls {
+				if rows, err := database.GetInstallations(skill.ID); err == nil {
+					rec.RecordInstallations(rows)
+				}
 				if err := inst.UninstallAll(ctx, &skill); err != nil {
 					uninstallErrors = append(uninstallErrors, fmt.Errorf("%s: %w", skill.Slug, err))
 				} else {
@@ -172,17 +212,23 @@ func executeRemoval(ctx context.Context, cfg *config.Config, database *db.DB, so
 	}
 	fmt.Println("      Deleted source record")
 
-	// Step 4: Remove git clone from disk
+	// Step 4: Move git clone into the journal (deleted when the entry expires)
 	fmt.Println("\n[4/4] Removing local git clone...")
-	paths := config.GetPaths(cfg)
-	repoManager := scraper.NewRepositoryManager(paths.Repositories, cfg.GitHub.Token)
-	if err := repoManager.RemoveRepository(source.Owner, source.Repo); err != nil {
-		fmt.Printf("      Warning: failed to remove git clone: %v\n", err)
+	repoManager := scraper.NewRepositoryManagerFromConfig(cfg)
+	if dir := source.LocalDir(); dir != "" {
+		fmt.Printf("      Local source; %s left in place\n", dir)
+	} else if err := rec.Trash(repoManager.GetRepoPath(source.Owner, source.Repo)); err != nil {
+		fmt.Printf("      Warning: %v; deleting instead\n", err)
+		if err := repoManager.RemoveRepository(source.Owner, source.Repo); err != nil {
+			fmt.Printf("      Warning: failed to remove git clone: %v\n", err)
+		}
 	} else {
 		fmt.Println("      Removed git clone")
 	}
 
 	fmt.Printf("\nRepository %s removed successfully!\n", source.ID)
+	// Only a finished removal is recorded; a failed one returned above
+	commitJournal(rec)
 
 	// Track telemetry event
 	telemetryClient.TrackRepoRemoved(source.ID, int(source.SkillCount))
@@ -190,6 +236,37 @@ func executeRemoval(ctx context.Context, cfg *config.Config, database *db.DB, so
 	return nil
 }
 
+// planRemoval computes what executeRemoval would delete without touching anything.
+func planRemoval(cfg *config.Config, database *db.DB, source *models.Source) (*installer.Plan, error) {
+	plan := installer.NewPlan("remove")
+
+	skills, err := database.GetSkillsBySourceID(source.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get skills: %w", err)
+	}
+
+	inst := installer.New(database, cfg)
+	for i := range skills {
+		p, err := inst.PlanRemoveSkill(&skills[i])
+		if err != nil {
+			plan.AddError("%s: %v", skills[i].Slug, err)
+			continue
+		}
+		plan.Merge(p)
+	}
+
+	plan.DB.SkillsDeleted = len(skills)
+	plan.DB.SourcesDeleted = 1
+
+	repoManager := scraper.NewRepositoryManagerFromConfig(cfg)
+	repoPath := repoManager.GetRepoPath(source.Owner, source.Repo)
+	if _, err := os.Stat(repoPath); err == nil {
+		plan.Removals = append(plan.Removals, repoPath)
+	}
+
+	return plan, nil
+}
+
 // showRemoveConfirmation displays a confirmation prompt and returns true if confirmed.
 func showRemoveConfirmation(source *models.Source) bool {
 	fmt.Printf("\nYou are about to remove repository: %s\n", source.ID)
@@ -201,8 +278,12 @@ func showRemoveConfirmation(source *models.Source) bool {
 	fmt.Println("  - Uninstall all skills from this repository")
 	fmt.Println("  - Delete all skill records from the database")
 	fmt.Println("  - Remove the repository from the database")
-	fmt.Println("  - Delete the local git clone")
-	fmt.Println("\nThis action cannot be undone.")
+	if dir := source.LocalDir(); dir != "" {
+		fmt.Printf("  - Leave the local directory %s in place\n", dir)
+	} else {
+		fmt.Println("  - Move the local git clone into the undo journal")
+	}
+	fmt.Println("\nRun 'skulto undo' afterwards to reverse this.")
 	fmt.Print("\nAre you sure? [y/N]: ")
 
 	var response string
@@ -2,6 +2,7 @@ package cli
 
 import (
 	"context"
+	"errors"
 	"os"
 	"path/filepath"
 	"testing"
@@ -10,6 +11,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
 
 	"github.com/asteroid-belt/skulto/internal/config"
 	"github.com/asteroid-belt/skulto/internal/db"
@@ -228,6 +230,29 @@ func TestExecuteRemoval_NoGitClone(t *testing.T) {
 	assert.Nil(t, result)
 }
 
+func TestExecuteRemoval_FailureIsNotJournaled(t *testing.T) {
+	setupTestTelemetry()
+	database := testDB(t)
+	cfg := testConfig(t)
+
+	source := &models.Source{ID: "broken/repo", Owner: "broken", Repo: "repo", FullName: "broken/repo"}
+	require.NoError(t, database.CreateSource(source))
+
+	// Deleting the source row fails after the skills are gone
+	require.NoError(t, database.Callback().Delete().Before("gorm:delete").Register("test:fail_source_delete", func(tx *gorm.DB) {
+		if tx.Statement.Table == "sources" {
+			_ = tx.AddError(errors.New("disk full"))
+		}
+	}))
+
+	err := executeRemoval(context.Background(), cfg, database, source)
+	require.Error(t, err)
+
+	entries, err := database.ListJournalEntries(0, true)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
 func TestRepoSelectModel_Init(t *testing.T) {
 	sources := []models.Source{
 		{ID: "test/repo1", SkillCount: 5},
@@ -376,3 +401,42 @@ func TestRepoSelectDialog_WithInstalledCounts(t *testing.T) {
 	assert.Contains(t, view, "0 installed")
 	assert.Contains(t, view, "5 not installed")
 }
+
+func TestDryRunFlags(t *testing.T) {
+	for _, cmd := range []*cobra.Command{installCmd, uninstallCmd, syncCmd, removeCmd, updateCmd} {
+		t.Run(cmd.Name(), func(t *testing.T) {
+			dryRun := cmd.Flags().Lookup("dry-run")
+			require.NotNil(t, dryRun)
+			assert.Equal(t, "false", dryRun.DefValue)
+			assert.NotNil(t, cmd.Flags().Lookup("json"))
+		})
+	}
+}
+
+func TestPlanRemoval_NoSideEffects(t *testing.T) {
+	database := testDB(t)
+	cfg := &config.Config{BaseDir: t.TempDir()}
+
+	source := &models.Source{ID: "test/plan-repo", Owner: "test", Repo: "plan-repo", FullName: "test/plan-repo"}
+	require.NoError(t, database.CreateSource(source))
+	sourceID := source.ID
+	for _, slug := range []string{"plan-a", "plan-b"} {
+		require.NoError(t, database.CreateSkill(&models.Skill{
+			ID: "id-" + slug, Slug: slug, Title: slug, SourceID: &sourceID,
+		}))
+	}
+	repoPath := filepath.Join(cfg.BaseDir, "repositories", "test", "plan-repo")
+	require.NoError(t, os.MkdirAll(repoPath, 0755))
+
+	plan, err := planRemoval(cfg, database, source)
+	require.NoError(t, err)
+	assert.Equal(t, 2, plan.DB.SkillsDeleted)
+	assert.Equal(t, 1, plan.DB.SourcesDeleted)
+	assert.Equal(t, []string{repoPath}, plan.Removals)
+
+	// Nothing was deleted
+	skills, err := database.GetSkillsBySourceID(sourceID)
+	require.NoError(t, err)
+	assert.Len(t, skills, 2)
+	assert.DirExists(t, repoPath)
+}
@@ -18,6 +18,11 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var (
+	saveGlobal bool
+	saveVendor bool
+)
+
 var saveCmd = &cobra.Command{
 	Use:   "save",
 	Short: "Save project skills to skulto.json",
@@ -25,18 +30,38 @@ var saveCmd = &cobra.Command{
 
 This creates a manifest file that can be checked into version control,
 allowing teammates to sync the same skills with 'skulto sync'.
+It also writes skulto.lock, pinning each skill to the commit and content
+hash currently installed; commit both files.
 
 Only project-scope installations for the current directory are saved.
-Local-only skills (without a source repository) are skipped.
+Local-only skills (without a source repository) are skipped unless
+--vendor is given, which copies each one into .skulto/vendor/<slug> and
+lists it in skulto.json by that path. Commit the vendor directory too;
+'skulto sync' installs vendored skills straight from it.
+
+With --global, global-scope installations are saved to a user-level
+manifest in the skulto data directory instead; restore them on another
+machine with 'skulto sync --global'.
 
 Examples:
   skulto save
-  git add skulto.json && git commit -m "chore: add skulto manifest"`,
+  skulto save --vendor
+  skulto save --global
+  git add skulto.json skulto.lock && git commit -m "chore: add skulto manifest"`,
 	Args: cobra.NoArgs,
 	RunE: runSave,
 }
 
+func init() {
+	saveCmd.Flags().BoolVar(&saveGlobal, "global", false, "Save global-scope installs to the user-level manifest")
+	saveCmd.Flags().BoolVar(&saveVendor, "vendor", false, "Copy local-only skills into .skulto/vendor and include them")
+}
+
 func runSave(cmd *cobra.Command, args []string) error {
+	if saveGlobal && saveVendor {
+		return trackCLIError("save", fmt.Errorf("--vendor cannot be combined with --global"))
+	}
+
 	cwd, err := os.Getwd()
 	if err != nil {
 		return trackCLIError("save", fmt.Errorf("get working directory: %w", err))
@@ -54,6 +79,10 @@ func runSave(cmd *cobra.Command, args []string) error {
 	}
 	defer func() { _ = database.Close() }()
 
+	if saveGlobal {
+		return saveGlobalManifest(database, cfg)
+	}
+
 	service := installer.NewInstallService(database, cfg, telemetryClient)
 	_ = service.EnsurePathPolicy(context.Background(), cwd)
 
@@ -96,36 +125,9 @@ func runSave(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	warnStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
-
-	var entries []manifest.SkillEntry
-	seen := make(map[string]bool)
-	for _, inst := range installations {
-		if seen[inst.SkillID] {
-			continue
-		}
-		seen[inst.SkillID] = true
-
-		skill, err := database.GetSkill(inst.SkillID)
-		if err != nil || skill == nil {
-			continue
-		}
-
-		localOnly := skill.SourceID == nil || skill.Source == nil
-		if localOnly {
-			fmt.Printf("  %s %s (local-only, no source repository)\n",
-				warnStyle.Render("SKIP"), skill.Slug)
-		}
-
-		sourceName := ""
-		if skill.Source != nil {
-			sourceName = skill.Source.FullName
-		}
-		entries = append(entries, manifest.SkillEntry{
-			Slug:       skill.Slug,
-			SourceName: sourceName,
-			LocalOnly:  localOnly,
-		})
+	entries, err := saveEntries(database, installations, cwd, saveVendor)
+	if err != nil {
+		return trackCLIError("save", err)
 	}
 
 	mf, skippedLocal := manifest.BuildFromSkills(entries)
@@ -165,23 +167,217 @@ func runSave(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Keep per-skill settings (ref, platforms, scope, ...) for skills still saved
+	keepSkillOptions(mf, existing)
+
+	if existing != nil {
+		mf.Workspace = existing.Workspace
+		mf.Extends = existing.Extends
+	}
+
+	// Skills inherited from a workspace root or an extended manifest stay there
+	if rootDir, root, err := manifest.FindWorkspaceRoot(cwd); err == nil && root != nil {
+		if resolved, err := resolveExtends(cmd.Context(), cfg, rootDir, root, false); err == nil {
+			root = resolved
+		}
+		mf.DropInherited(root, existing)
+	}
+	if existing != nil && existing.Extends != "" {
+		base := manifest.New()
+		base.Extends = existing.Extends
+		if resolved, err := resolveExtends(cmd.Context(), cfg, cwd, base, false); err == nil {
+			mf.DropInherited(resolved, existing)
+		} else {
+			fmt.Printf("Warning: could not read extended manifest: %v\n", err)
+		}
+	}
+
+	if err := writeSavedManifest(database, cfg, cwd, mf, existing, skippedLocal); err != nil {
+		return trackCLIError("save", err)
+	}
+	if len(unsavedGlobal) > 0 {
+		printGlobalSkillsNotSavedWarning(unsavedGlobal)
+	}
+	return nil
+}
+
+// saveEntries turns installation rows into manifest entries, one per skill.
+// Local-only skills are skipped unless they are already vendored under dir
+// or vendor is set, in which case they are copied to .skulto/vendor/<slug>.
+func saveEntries(database *db.DB, installations []models.SkillInstallation, dir string, vendor bool) ([]manifest.SkillEntry, error) {
+	warnStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
+	vendorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("82"))
+
+	var entries []manifest.SkillEntry
+	seen := make(map[string]bool)
+	for _, inst := range installations {
+		if seen[inst.SkillID] {
+			continue
+		}
+		seen[inst.SkillID] = true
+
+		skill, err := database.GetSkill(inst.SkillID)
+		if err != nil || skill == nil {
+			continue
+		}
+
+		if skill.SourceID == nil || skill.Source == nil {
+			source, copied, err := vendoredSource(dir, skill, vendor)
+			if err != nil {
+				return nil, fmt.Errorf("vendor %s: %w", skill.Slug, err)
+			}
+			switch {
+			case source == "":
+				fmt.Printf("  %s %s (local-only, no source repository; use --vendor to include it)\n",
+					warnStyle.Render("SKIP"), skill.Slug)
+			case copied:
+				fmt.Printf("  %s %s -> %s\n", vendorStyle.Render("VENDOR"), skill.Slug, source)
+			}
+			entries = append(entries, manifest.SkillEntry{
+				Slug:       skill.Slug,
+				SourceName: source,
+				LocalOnly:  source == "",
+			})
+			continue
+		}
+
+		// A local directory source only exists on this machine
+		if dir := skill.Source.LocalDir(); dir != "" {
+			fmt.Printf("  %s %s (from local directory %s; push it to a git host to share it)\n",
+				warnStyle.Render("SKIP"), skill.Slug, dir)
+			entries = append(entries, manifest.SkillEntry{Slug: skill.Slug, LocalOnly: true})
+			continue
+		}
+
+		entries = append(entries, manifest.SkillEntry{
+			Slug:       skill.Slug,
+			SourceName: skill.Source.FullName,
+		})
+	}
+	return entries, nil
+}
+
+// vendoredSource returns the manifest source for a local-only skill. A skill
+// already installed from dir's vendor directory keeps its vendored source;
+// otherwise, with vendor, its files are copied there first. Returns "" if
+// the skill stays out of the manifest.
+func vendoredSource(dir string, skill *models.Skill, vendor bool) (string, bool, error) {
+	dest := manifest.VendorPath(dir, skill.Slug)
+	if skill.FilePath == dest {
+		return manifest.VendorSource(skill.Slug), false, nil
+	}
+	if !vendor || skill.FilePath == "" {
+		return "", false, nil
+	}
+	if err := discovery.CopySkill(skill.FilePath, dest); err != nil {
+		return "", false, err
+	}
+	return manifest.VendorSource(skill.Slug), true, nil
+}
+
+// saveGlobalManifest writes global-scope installs to the user-level
+// manifest (see globalManifestDir).
+func saveGlobalManifest(database *db.DB, cfg *config.Config) error {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return trackCLIError("save", fmt.Errorf("get home directory: %w", err))
+	}
+	rows, err := database.GetAllInstallations()
+	if err != nil {
+		return trackCLIError("save", fmt.Errorf("query installations: %w", err))
+	}
+	var installations []models.SkillInstallation
+	for _, row := range rows {
+		if row.Scope == string(installer.ScopeGlobal) && row.BasePath == home {
+			installations = append(installations, row)
+		}
+	}
+	if len(installations) == 0 {
+		fmt.Println("No global-scope skills installed.")
+		return nil
+	}
+
+	dir := globalManifestDir(cfg)
+	entries, err := saveEntries(database, installations, dir, false)
+	if err != nil {
+		return trackCLIError("save", err)
+	}
+	mf, skippedLocal := manifest.BuildFromSkills(entries)
+
+	existing, err := manifest.Read(dir)
+	if err != nil {
+		return trackCLIError("save", fmt.Errorf("read existing manifest: %w", err))
+	}
+	if existing != nil {
+		mf.Ignored = existing.Ignored
+	}
+	keepSkillOptions(mf, existing)
+
+	if err := writeSavedManifest(database, cfg, dir, mf, existing, skippedLocal); err != nil {
+		return trackCLIError("save", err)
+	}
+	return nil
+}
+
+// keepSkillOptions copies per-skill settings from existing for skills mf
+// still lists with the same source.
+func keepSkillOptions(mf, existing *manifest.ManifestFile) {
+	if existing == nil {
+		return
+	}
+	for slug, source := range mf.Skills {
+		if existing.Skills[slug] == source {
+			if opts := existing.SkillOptions(slug); !opts.IsZero() {
+				mf.Options[slug] = opts
+			}
+		}
+	}
+}
+
+// globalManifestDir is where `save --global` and `sync --global` keep the
+// user-level skulto.json: the skulto data directory.
+func globalManifestDir(cfg *config.Config) string {
+	return cfg.BaseDir
+}
+
+// writeSavedManifest writes mf and its lockfile to dir, recording both in
+// the undo journal, unless mf is unchanged from existing (then only a
+// changed lock is written).
+func writeSavedManifest(database *db.DB, cfg *config.Config, dir string, mf, existing *manifest.ManifestFile, skippedLocal int) error {
+	existingLock, err := manifest.ReadLock(dir)
+	if err != nil {
+		return err
+	}
+	lock, lockWarnings := buildLock(database, cfg, mf, existingLock, nil)
+
 	if existing != nil && manifest.ManifestEqual(existing, mf) {
 		infoStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
 		fmt.Printf("%s (version %d)\n", infoStyle.Render("No changes to skulto.json"), existing.Version)
-		if len(unsavedGlobal) > 0 {
-			printGlobalSkillsNotSavedWarning(unsavedGlobal)
+		if !manifest.LocksEqual(existingLock, lock) {
+			rec := openJournal(cfg, database).Begin("save", fmt.Sprintf("save %s", manifest.LockPath(dir)))
+			if err := rec.RecordManifest(manifest.LockPath(dir)); err != nil {
+				fmt.Printf("Warning: %v\n", err)
+			}
+			if err := saveLock(dir, lock, existingLock, lockWarnings); err != nil {
+				return err
+			}
+			commitJournal(rec)
 		}
 		return nil
 	}
 
-	if existing != nil {
-		mf.Version = existing.Version + 1
-	} else {
-		mf.Version = 1
+	rec := openJournal(cfg, database).Begin("save", fmt.Sprintf("save %s (version %d)", manifest.Path(dir), mf.Version))
+	for _, p := range []string{manifest.Path(dir), manifest.LockPath(dir)} {
+		if err := rec.RecordManifest(p); err != nil {
+			fmt.Printf("Warning: %v\n", err)
+		}
 	}
 
-	if err := manifest.Write(cwd, mf); err != nil {
-		return trackCLIError("save", fmt.Errorf("write manifest: %w", err))
+	if err := manifest.Write(dir, mf); err != nil {
+		return fmt.Errorf("write manifest: %w", err)
+	}
+	if err := saveLock(dir, lock, existingLock, lockWarnings); err != nil {
+		return err
 	}
 
 	fmt.Println()
@@ -189,7 +385,7 @@ func runSave(cmd *cobra.Command, args []string) error {
 	slugStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("141"))
 	sourceStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
 
-	fmt.Printf("%s Saved to %s (version %d)\n\n", successStyle.Render("SAVED"), manifest.FileName, mf.Version)
+	fmt.Printf("%s Saved to %s (version %d)\n\n", successStyle.Render("SAVED"), manifestDisplayPath(dir), mf.Version)
 
 	for _, slug := range mf.SortedSlugs() {
 		source := mf.Skills[slug]
@@ -202,12 +398,33 @@ func runSave(cmd *cobra.Command, args []string) error {
 	}
 	fmt.Println()
 
-	if len(unsavedGlobal) > 0 {
-		printGlobalSkillsNotSavedWarning(unsavedGlobal)
-	}
-
+	commitJournal(rec)
 	telemetryClient.TrackManifestSaved(mf.SkillCount(), "cli")
+	return nil
+}
 
+// manifestDisplayPath names the manifest in dir for messages: just the file
+// name when dir is the working directory.
+func manifestDisplayPath(dir string) string {
+	if cwd, err := os.Getwd(); err == nil && cwd == dir {
+		return manifest.FileName
+	}
+	return manifest.Path(dir)
+}
+
+// saveLock writes skulto.lock if it changed and reports skills that could not be locked.
+func saveLock(cwd string, lock, existing *manifest.LockFile, warnings []string) error {
+	warnStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
+	for _, w := range warnings {
+		fmt.Printf("  %s not locked: %s\n", warnStyle.Render("WARN"), w)
+	}
+	written, err := writeLock(cwd, lock, existing)
+	if err != nil {
+		return fmt.Errorf("write lockfile: %w", err)
+	}
+	if written {
+		fmt.Printf("Updated %s (%d skill(s) locked)\n", manifest.LockFileName, len(lock.Skills))
+	}
 	return nil
 }
 
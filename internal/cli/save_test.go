@@ -1,11 +1,15 @@
 package cli
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/asteroid-belt/skulto/internal/installer"
 	"github.com/asteroid-belt/skulto/internal/manifest"
+	"github.com/asteroid-belt/skulto/internal/models"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestFilterIgnored_FiltersCorrectly(t *testing.T) {
@@ -33,3 +37,52 @@ func TestFilterIgnored_EmptyIgnored(t *testing.T) {
 	got := filterIgnored(input, existing)
 	assert.Len(t, got, 1)
 }
+
+func TestSaveEntries_VendorsLocalSkills(t *testing.T) {
+	database := testDB(t)
+	cfg := testConfig(t)
+	project := t.TempDir()
+
+	localDir := filepath.Join(t.TempDir(), "team-notes")
+	require.NoError(t, os.MkdirAll(localDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(localDir, "SKILL.md"), []byte("---\nname: team-notes\ndescription: Team notes\n---\n\n# Team Notes\n"), 0644))
+	skill := &models.Skill{ID: "local-team-notes", Slug: "team-notes", Title: "Team Notes", IsLocal: true, FilePath: localDir}
+	require.NoError(t, database.CreateSkill(skill))
+	rows := []models.SkillInstallation{{SkillID: skill.ID, Platform: "claude", Scope: "project", BasePath: project}}
+
+	entries, err := saveEntries(database, rows, project, false)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.True(t, entries[0].LocalOnly, "without --vendor local skills are skipped")
+
+	entries, err = saveEntries(database, rows, project, true)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.False(t, entries[0].LocalOnly)
+	assert.Equal(t, "./.skulto/vendor/team-notes", entries[0].SourceName)
+	assert.FileExists(t, filepath.Join(project, ".skulto", "vendor", "team-notes", "SKILL.md"))
+
+	// Sync registers the vendored copy; a later save keeps it without --vendor
+	registered, err := registerPathSkill(database, cfg, project, "team-notes", entries[0].SourceName)
+	require.NoError(t, err)
+	assert.Equal(t, manifest.VendorPath(project, "team-notes"), registered.FilePath)
+
+	rows[0].SkillID = registered.ID
+	entries, err = saveEntries(database, rows, project, false)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "./.skulto/vendor/team-notes", entries[0].SourceName)
+}
+
+func TestRegisterPathSkill_SlugMismatch(t *testing.T) {
+	database := testDB(t)
+	cfg := testConfig(t)
+	project := t.TempDir()
+
+	dir := manifest.VendorPath(project, "expected")
+	require.NoError(t, os.MkdirAll(dir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte("---\nname: something-else\ndescription: x\n---\n"), 0644))
+
+	_, err := registerPathSkill(database, cfg, project, "expected", manifest.VendorSource("expected"))
+	assert.Error(t, err)
+}
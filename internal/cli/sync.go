@@ -5,11 +5,15 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
+	"sort"
 	"strings"
 
+	"github.com/Masterminds/semver/v3"
 	"github.com/asteroid-belt/skulto/internal/cli/prompts"
 	"github.com/asteroid-belt/skulto/internal/config"
 	"github.com/asteroid-belt/skulto/internal/db"
+	"github.com/asteroid-belt/skulto/internal/discovery"
 	"github.com/asteroid-belt/skulto/internal/installer"
 	"github.com/asteroid-belt/skulto/internal/manifest"
 	"github.com/asteroid-belt/skulto/internal/models"
@@ -18,7 +22,17 @@ import (
 	"github.com/spf13/cobra"
 )
 
-var syncYes bool
+var (
+	syncYes    bool
+	syncDryRun bool
+	syncJSON   bool
+	syncUpdate bool
+	syncCheck  bool
+	syncPrune  bool
+
+	syncWorkspace bool
+	syncGlobal    bool
+)
 
 var syncCmd = &cobra.Command{
 	Use:   "sync",
@@ -30,16 +44,58 @@ If a source repository is not in your database, you will be prompted to add it.
 
 This is equivalent to running 'skulto install' with no arguments.
 
+Sync writes skulto.lock next to skulto.json, pinning each skill to the
+commit and content hash it was installed from. When a lockfile exists,
+sync installs exactly the locked revisions and fails if a skill's files
+do not match the locked hash. Use --update to move to the latest
+revisions and rewrite the lock.
+
+Use --prune to also uninstall project-scope skills that skulto installed
+in this directory but that are no longer listed in skulto.json. Skills in
+the manifest's "ignored" list are kept. Pruning asks for confirmation
+unless -y is given; combine with --dry-run to preview the removals.
+
+In a monorepo, list member directories (globs) under "workspace" in the
+root skulto.json and run 'skulto sync --workspace' from the root. Each
+member's skills are installed into that member's project scope, and every
+member inherits the root's skills unless it lists or ignores them itself.
+Running plain 'skulto sync' inside a member also applies the root's skills.
+
+Skills vendored with 'skulto save --vendor' are listed by path (for example
+"./.skulto/vendor/my-skill") and installed straight from that directory.
+
+Use --global to install the user-level manifest written by
+'skulto save --global' at global scope, for example on a new machine.
+
+Use --check in CI to verify the project without changing anything. It
+reports skills that are missing, installed but not listed (and not
+ignored), or out of date with skulto.json and skulto.lock, and exits
+with status 2 when anything has drifted.
+
 Examples:
   skulto sync
-  skulto sync -y        # non-interactive: detected platforms, global scope
+  skulto sync --update    # pull latest revisions and refresh skulto.lock
+  skulto sync -y          # non-interactive: saved project locations
+  skulto sync --dry-run   # show what would be cloned and installed
+  skulto sync --prune     # also remove skills dropped from skulto.json
+  skulto sync --workspace # sync every workspace member from the root
+  skulto sync --global    # install the user-level manifest globally
+  skulto sync --check     # exit 2 if installed skills differ from skulto.json
+  skulto sync --check --json
   skulto install`,
 	Args: cobra.NoArgs,
 	RunE: runSync,
 }
 
 func init() {
-	syncCmd.Flags().BoolVarP(&syncYes, "yes", "y", false, "Skip interactive prompts; use detected platforms with global scope")
+	syncCmd.Flags().BoolVarP(&syncYes, "yes", "y", false, "Skip interactive prompts; use saved project locations, else detected platforms")
+	syncCmd.Flags().BoolVar(&syncDryRun, "dry-run", false, "Show what would be installed without making changes")
+	syncCmd.Flags().BoolVar(&syncJSON, "json", false, "Print the --dry-run plan or --check report as JSON")
+	syncCmd.Flags().BoolVar(&syncUpdate, "update", false, "Update skills to their latest revisions and refresh skulto.lock")
+	syncCmd.Flags().BoolVar(&syncPrune, "prune", false, "Uninstall project skills that are no longer in skulto.json")
+	syncCmd.Flags().BoolVar(&syncWorkspace, "workspace", false, "Sync every member of the workspace defined in ./skulto.json")
+	syncCmd.Flags().BoolVar(&syncGlobal, "global", false, "Sync the user-level manifest from 'skulto save --global' at global scope")
+	syncCmd.Flags().BoolVar(&syncCheck, "check", false, "Report drift from skulto.json without changing anything; exit 2 on drift")
 }
 
 func runSync(cmd *cobra.Command, args []string) error {
@@ -50,10 +106,82 @@ func runSync(cmd *cobra.Command, args []string) error {
 		return trackCLIError("sync", fmt.Errorf("get working directory: %w", err))
 	}
 
+	if syncGlobal {
+		return runSyncGlobal(ctx)
+	}
+	if syncWorkspace {
+		return runSyncWorkspace(ctx, cwd)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return trackCLIError("sync", fmt.Errorf("load config: %w", err))
+	}
+
 	mf, err := manifest.Read(cwd)
 	if err != nil {
 		return trackCLIError("sync", fmt.Errorf("read manifest: %w", err))
 	}
+	mf, err = resolveExtends(ctx, cfg, cwd, mf, syncFetchesExtends())
+	if err != nil {
+		return trackCLIError("sync", err)
+	}
+	rootDir, root, err := manifest.FindWorkspaceRoot(cwd)
+	if err != nil {
+		return trackCLIError("sync", fmt.Errorf("find workspace root: %w", err))
+	}
+	if root != nil {
+		fmt.Printf("Workspace member of %s\n", manifest.Path(rootDir))
+		root, err = resolveExtends(ctx, cfg, rootDir, root, syncFetchesExtends())
+		if err != nil {
+			return trackCLIError("sync", err)
+		}
+		mf = manifest.Inherit(root, mf)
+	}
+
+	return syncProject(ctx, cwd, mf)
+}
+
+// syncFetchesExtends reports whether sync refreshes the repositories its
+// manifests extend. --check and --dry-run change nothing, so they read
+// bases from the clone cache only.
+func syncFetchesExtends() bool {
+	return !syncCheck && !syncDryRun && !installDryRun
+}
+
+// runSyncGlobal syncs the user-level manifest at global scope.
+func runSyncGlobal(ctx context.Context) error {
+	if syncWorkspace || syncCheck || syncPrune {
+		return trackCLIError("sync", fmt.Errorf("--global cannot be combined with --workspace, --check or --prune"))
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		return trackCLIError("sync", fmt.Errorf("load config: %w", err))
+	}
+	dir := globalManifestDir(cfg)
+	mf, err := manifest.Read(dir)
+	if err != nil {
+		return trackCLIError("sync", fmt.Errorf("read manifest: %w", err))
+	}
+	if mf == nil {
+		fmt.Printf("No user-level manifest at %s.\n\n", manifest.Path(dir))
+		fmt.Println("Create one with:")
+		fmt.Println("  skulto save --global")
+		return nil
+	}
+	mf, err = resolveExtends(ctx, cfg, dir, mf, syncFetchesExtends())
+	if err != nil {
+		return trackCLIError("sync", err)
+	}
+	return syncProject(ctx, dir, mf)
+}
+
+// syncProject syncs one project directory against its effective manifest.
+// mf is nil when the directory has no skulto.json.
+func syncProject(ctx context.Context, cwd string, mf *manifest.ManifestFile) error {
+	if mf == nil && syncCheck {
+		return trackCLIError("sync", fmt.Errorf("no skulto.json found in %s", cwd))
+	}
 	if mf == nil {
 		fmt.Println("No skulto.json found in the current directory.")
 		fmt.Println()
@@ -62,7 +190,11 @@ func runSync(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	if mf.SkillCount() == 0 {
+	if syncCheck {
+		return runSyncCheck(cwd, mf)
+	}
+
+	if mf.SkillCount() == 0 && !syncPrune {
 		fmt.Println("skulto.json has no skills listed.")
 		return nil
 	}
@@ -81,6 +213,20 @@ func runSync(cmd *cobra.Command, args []string) error {
 
 	service := installer.NewInstallService(database, cfg, telemetryClient)
 
+	// `skulto install --dry-run` with no arguments delegates here too.
+	if syncDryRun || installDryRun {
+		plan, err := planSync(ctx, mf, service, database, cwd)
+		if err != nil {
+			return trackCLIError("sync", err)
+		}
+		if syncPrune {
+			if err := planSyncPrune(ctx, plan, service, database, cwd, mf); err != nil {
+				return trackCLIError("sync", err)
+			}
+		}
+		return printPlan(plan, syncJSON || installJSON)
+	}
+
 	headerStyle := lipgloss.NewStyle().Bold(true)
 	fmt.Printf("%s (%d skills)\n", headerStyle.Render("SYNCING from skulto.json"), mf.SkillCount())
 	fmt.Println(strings.Repeat("\u2500", 50))
@@ -92,32 +238,68 @@ func runSync(cmd *cobra.Command, args []string) error {
 		return trackCLIError("sync", err)
 	}
 
-	skillsToInstall, skippedSkills := syncResolveSkills(mf, database, skippedSources)
+	skillsToInstall, skippedSkills := syncResolveSkills(mf, database, cfg, cwd, skippedSources)
+
+	existingLock, err := manifest.ReadLock(cwd)
+	if err != nil {
+		return trackCLIError("sync", err)
+	}
+	activeLock := existingLock
+	pins := make(map[string]pinnedRevision)
+	if syncUpdate {
+		syncUpdateClones(ctx, cfg, skillsToInstall)
+		activeLock = nil
+	} else if existingLock != nil {
+		if err := pinLocked(ctx, cfg, mf, existingLock, skillsToInstall, pins); err != nil {
+			return trackCLIError("sync", err)
+		}
+	}
+	if err := pinRefs(ctx, cfg, mf, activeLock, skillsToInstall, pins); err != nil {
+		return trackCLIError("sync", err)
+	}
+
+	removed := 0
+	if syncPrune {
+		removed, err = syncPruneExtras(ctx, cfg, service, database, cwd, mf)
+		if err != nil {
+			return trackCLIError("sync", err)
+		}
+	}
 
 	if len(skillsToInstall) == 0 {
 		fmt.Println("\nNo skills to install.")
 		if skippedSkills > 0 {
 			fmt.Printf("(%d skill(s) skipped)\n", skippedSkills)
 		}
+		if syncPrune {
+			fmt.Printf("Removed: %d\n", removed)
+		}
+		syncWriteLock(cwd, database, cfg, mf, existingLock, pins)
 		return nil
 	}
 
 	fmt.Printf("\n%d skill(s) to install. Select where to install them:\n\n", len(skillsToInstall))
 
-	plan, err := buildSyncPlan(ctx, service, database, syncYes)
+	plan, err := buildSyncPlan(ctx, service, database, cwd, syncYes)
 	if err != nil {
 		return trackCLIError("sync", err)
 	}
+	if syncGlobal {
+		plan = atScope(plan, installer.ScopeGlobal)
+	}
 	if len(plan) == 0 {
 		fmt.Println("Cancelled.")
 		return nil
 	}
 
-	installed, skipped, errored := syncInstallSkills(ctx, skillsToInstall, service, plan, reader, cwd, syncYes)
+	installed, skipped, errored := syncInstallSkills(ctx, mf, skillsToInstall, pins, service, plan, reader, cwd, syncYes)
 
 	fmt.Println()
 	fmt.Println(strings.Repeat("\u2500", 50))
 	fmt.Printf("Done! Installed: %d, Skipped: %d", installed, skipped)
+	if syncPrune {
+		fmt.Printf(", Removed: %d", removed)
+	}
 	if errored > 0 {
 		fmt.Printf(", Errors: %d", errored)
 	}
@@ -126,11 +308,197 @@ func runSync(cmd *cobra.Command, args []string) error {
 	}
 	fmt.Println()
 
+	// A lock only records a sync that went through
+	if errored == 0 {
+		syncWriteLock(cwd, database, cfg, mf, existingLock, pins)
+	} else {
+		fmt.Printf("%s left unchanged because some skills failed to install\n", manifest.LockFileName)
+	}
+
 	telemetryClient.TrackManifestSynced(mf.SkillCount(), installed, skipped)
 
 	return nil
 }
 
+// runSyncWorkspace syncs the workspace root and then each member directory,
+// with root skills inherited into every member. A failing member does not
+// stop the others; failures are reported together at the end.
+func runSyncWorkspace(ctx context.Context, rootDir string) error {
+	root, err := manifest.Read(rootDir)
+	if err != nil {
+		return trackCLIError("sync", fmt.Errorf("read manifest: %w", err))
+	}
+	if root == nil || !root.IsWorkspace() {
+		return trackCLIError("sync", fmt.Errorf("%s does not define a workspace; add member globs under \"workspace\"", manifest.Path(rootDir)))
+	}
+	members, err := root.Members(rootDir)
+	if err != nil {
+		return trackCLIError("sync", err)
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		return trackCLIError("sync", fmt.Errorf("load config: %w", err))
+	}
+	root, err = resolveExtends(ctx, cfg, rootDir, root, syncFetchesExtends())
+	if err != nil {
+		return trackCLIError("sync", err)
+	}
+
+	// Every project chdirs so project-scope installs land in it
+	defer func() { _ = os.Chdir(rootDir) }()
+
+	projects := append([]string{rootDir}, members...)
+	var failed []string
+	drifted := false
+	for _, dir := range projects {
+		rel, _ := filepath.Rel(rootDir, dir)
+		fmt.Printf("\n%s %s\n", planHeaderStyle.Render("WORKSPACE"), rel)
+
+		mf := root
+		if dir != rootDir {
+			member, err := manifest.Read(dir)
+			if err == nil {
+				member, err = resolveExtends(ctx, cfg, dir, member, syncFetchesExtends())
+			}
+			if err != nil {
+				failed = append(failed, fmt.Sprintf("%s: %v", rel, err))
+				continue
+			}
+			mf = manifest.Inherit(root, member)
+		}
+		if err := os.Chdir(dir); err != nil {
+			failed = append(failed, fmt.Sprintf("%s: %v", rel, err))
+			continue
+		}
+		if err := syncProject(ctx, dir, mf); err != nil {
+			if ExitCode(err) == ExitDrift {
+				drifted = true
+				continue
+			}
+			failed = append(failed, fmt.Sprintf("%s: %v", rel, err))
+		}
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("workspace sync failed for %d of %d project(s):\n  %s", len(failed), len(projects), strings.Join(failed, "\n  "))
+	}
+	if drifted {
+		return &exitError{code: ExitDrift, err: fmt.Errorf("workspace is out of sync with skulto.json")}
+	}
+	return nil
+}
+
+// syncUpdateClones moves the clones of the given skills' sources to the
+// latest revision of the branch or tag they follow, undoing any locked
+// checkout.
+func syncUpdateClones(ctx context.Context, cfg *config.Config, skills []*models.Skill) {
+	repoManager := scraper.NewRepositoryManagerFromConfig(cfg)
+
+	updated := make(map[string]bool)
+	for _, skill := range skills {
+		if skill.Source == nil || updated[skill.Source.FullName] {
+			continue
+		}
+		updated[skill.Source.FullName] = true
+		repoManager.ConfigureSource(skill.Source)
+		if _, err := repoManager.CloneOrUpdate(ctx, skill.Source.Owner, skill.Source.Repo); err != nil {
+			fmt.Printf("  Warning: failed to update %s: %v\n", skill.Source.FullName, err)
+		}
+	}
+}
+
+// manifestExtras returns the skulto-managed project installations in cwd
+// whose skills are neither listed in the manifest nor ignored by it, with
+// the locations each occupies.
+func manifestExtras(database *db.DB, cwd string, mf *manifest.ManifestFile) ([]string, map[string][]installer.InstallLocation, error) {
+	installed, err := installedAtScope(database, installer.ScopeProject, cwd)
+	if err != nil {
+		return nil, nil, err
+	}
+	ignored := make(map[string]bool, len(mf.Ignored))
+	for _, slug := range mf.Ignored {
+		ignored[slug] = true
+	}
+
+	var slugs []string
+	for slug := range installed {
+		if _, listed := mf.Skills[slug]; listed || ignored[slug] {
+			delete(installed, slug)
+			continue
+		}
+		slugs = append(slugs, slug)
+	}
+	sort.Strings(slugs)
+	return slugs, installed, nil
+}
+
+// syncPruneExtras uninstalls project skills that were dropped from the
+// manifest, after confirmation unless -y was given.
+func syncPruneExtras(
+	ctx context.Context,
+	cfg *config.Config,
+	service *installer.InstallService,
+	database *db.DB,
+	cwd string,
+	mf *manifest.ManifestFile,
+) (int, error) {
+	slugs, locations, err := manifestExtras(database, cwd, mf)
+	if err != nil {
+		return 0, err
+	}
+	if len(slugs) == 0 {
+		return 0, nil
+	}
+	fmt.Printf("\nSkills not in skulto.json: %s\n", strings.Join(slugs, ", "))
+	summary := fmt.Sprintf("prune %d skill(s) not in skulto.json", len(slugs))
+	return pruneSkills(ctx, cfg, service, database, "sync", summary, slugs, locations, syncYes)
+}
+
+// planSyncPrune adds the removals --prune would make to a dry-run plan.
+func planSyncPrune(
+	ctx context.Context,
+	plan *installer.Plan,
+	service *installer.InstallService,
+	database *db.DB,
+	cwd string,
+	mf *manifest.ManifestFile,
+) error {
+	slugs, locations, err := manifestExtras(database, cwd, mf)
+	if err != nil {
+		return err
+	}
+	for _, slug := range slugs {
+		p, err := service.PlanUninstall(ctx, slug, locations[slug])
+		if err != nil {
+			plan.AddError("%s: %v", slug, err)
+			continue
+		}
+		plan.Merge(p)
+	}
+	return nil
+}
+
+// syncWriteLock pins the manifest's skills to the revisions the sync
+// installed in skulto.lock.
+func syncWriteLock(cwd string, database *db.DB, cfg *config.Config, mf *manifest.ManifestFile, existing *manifest.LockFile, pins map[string]pinnedRevision) {
+	previous := existing
+	if syncUpdate {
+		previous = nil
+	}
+	lf, warnings := buildLock(database, cfg, mf, previous, pins)
+	for _, w := range warnings {
+		fmt.Printf("  Warning: not locked: %s\n", w)
+	}
+	written, err := writeLock(cwd, lf, existing)
+	if err != nil {
+		fmt.Printf("Warning: failed to write %s: %v\n", manifest.LockFileName, err)
+		return
+	}
+	if written {
+		fmt.Printf("Updated %s (%d skill(s) locked)\n", manifest.LockFileName, len(lf.Skills))
+	}
+}
+
 // syncEnsureSources checks that all sources referenced in the manifest exist in the database.
 // For missing sources, it prompts the user to add them. Returns a set of skipped source names.
 func syncEnsureSources(
@@ -143,6 +511,9 @@ func syncEnsureSources(
 ) (map[string]bool, error) {
 	sourceSkills := make(map[string][]string)
 	for slug, source := range mf.Skills {
+		if manifest.IsPathSource(source) {
+			continue
+		}
 		sourceSkills[source] = append(sourceSkills[source], slug)
 	}
 
@@ -190,21 +561,19 @@ func syncPromptAddSource(
 
 	fmt.Printf("Adding %s...\n", sourceName)
 
-	parts := strings.SplitN(sourceName, "/", 2)
-	if len(parts) != 2 {
-		fmt.Printf("  Invalid source format: %s (expected owner/repo)\n", sourceName)
+	source, err := scraper.ParseRepositoryURL(sourceName)
+	if err != nil {
+		fmt.Printf("  Invalid source format: %s (expected owner/repo or host/namespace/repo)\n", sourceName)
 		return true, nil
 	}
-
-	scraperCfg := scraper.ScraperConfig{
-		Token:        cfg.GitHub.Token,
-		DataDir:      cfg.BaseDir,
-		RepoCacheTTL: cfg.GitHub.RepoCacheTTL,
-		UseGitClone:  cfg.GitHub.UseGitClone,
+	if err := database.UpsertSource(source); err != nil {
+		return true, fmt.Errorf("add source %s: %w", sourceName, err)
 	}
+
+	scraperCfg := scraper.ScraperConfigFromConfig(cfg)
 	sc := scraper.NewScraperWithConfig(scraperCfg, database)
 
-	if _, err := sc.ScrapeRepository(ctx, parts[0], parts[1]); err != nil {
+	if _, err := sc.ScrapeRepository(ctx, source.Owner, source.Repo); err != nil {
 		fmt.Printf("  Failed to add source: %v\n", err)
 		return true, nil
 	}
@@ -214,10 +583,13 @@ func syncPromptAddSource(
 }
 
 // syncResolveSkills resolves manifest slugs to database skills, skipping any from skipped sources
-// or not found in the database. Returns the skills to install and the count of skipped skills.
+// or not found in the database. Skills listed by path (vendored) are registered from that
+// directory. Returns the skills to install and the count of skipped skills.
 func syncResolveSkills(
 	mf *manifest.ManifestFile,
 	database *db.DB,
+	cfg *config.Config,
+	cwd string,
 	skippedSources map[string]bool,
 ) ([]*models.Skill, int) {
 	var skillsToInstall []*models.Skill
@@ -225,25 +597,53 @@ func syncResolveSkills(
 
 	warnStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
 
+	skipStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
+
 	for _, slug := range mf.SortedSlugs() {
 		sourceName := mf.Skills[slug]
+		opts := mf.SkillOptions(slug)
+
+		// warn reports an unresolvable skill; optional skills are skipped quietly.
+		warn := func(format string, args ...any) {
+			skippedSkills++
+			if opts.Optional {
+				fmt.Printf("  %s %s (optional, skipped)\n", skipStyle.Render("o"), slug)
+				return
+			}
+			fmt.Printf("  %s %s\n", warnStyle.Render("WARN"), fmt.Sprintf(format, args...))
+		}
 
 		if skippedSources[sourceName] {
 			skippedSkills++
 			continue
 		}
 
-		skill, err := database.GetSkillBySlug(slug)
+		if manifest.IsPathSource(sourceName) {
+			skill, err := registerPathSkill(database, cfg, cwd, slug, sourceName)
+			if err != nil {
+				warn("Skill '%s': %v", slug, err)
+				continue
+			}
+			skillsToInstall = append(skillsToInstall, skill)
+			continue
+		}
+
+		skill, err := database.GetSkillBySlugAndSource(slug, sourceName)
+		if err == nil && skill == nil {
+			skill, err = database.GetSkillBySlug(slug)
+		}
 		if err != nil || skill == nil {
-			fmt.Printf("  %s Skill '%s' not found in database\n", warnStyle.Render("WARN"), slug)
-			skippedSkills++
+			warn("Skill '%s' not found in database", slug)
 			continue
 		}
 
 		if skill.Source != nil && skill.Source.FullName != sourceName {
-			fmt.Printf("  %s Skill '%s' found but from different source (%s, expected %s)\n",
-				warnStyle.Render("WARN"), slug, skill.Source.FullName, sourceName)
-			skippedSkills++
+			warn("Skill '%s' found but from different source (%s, expected %s)", slug, skill.Source.FullName, sourceName)
+			continue
+		}
+
+		if err := checkSkillVersion(skill, opts.Version); err != nil {
+			warn("Skill '%s' %v", slug, err)
 			continue
 		}
 
@@ -253,12 +653,96 @@ func syncResolveSkills(
 	return skillsToInstall, skippedSkills
 }
 
+// registerPathSkill records the skill in the directory source names
+// (relative to dir) as a local skill, so it installs from that directory.
+func registerPathSkill(database *db.DB, cfg *config.Config, dir, slug, source string) (*models.Skill, error) {
+	skill, err := discovery.NewIngestionService(database, cfg).RegisterLocalSkill(filepath.Join(dir, filepath.FromSlash(source)), slug)
+	if err != nil {
+		return nil, err
+	}
+	if skill.Slug != slug {
+		return nil, fmt.Errorf("%s contains skill '%s'", source, skill.Slug)
+	}
+	return skill, nil
+}
+
+// planSync computes what runSync would do without adding sources or installing.
+// Missing sources are reported as clones; their skills are unknown until indexed.
+func planSync(
+	ctx context.Context,
+	mf *manifest.ManifestFile,
+	service *installer.InstallService,
+	database *db.DB,
+	cwd string,
+) (*installer.Plan, error) {
+	plan := installer.NewPlan("sync")
+
+	installOpts, err := buildSyncPlan(ctx, service, database, cwd, true)
+	if err != nil {
+		return nil, err
+	}
+	if syncGlobal {
+		installOpts = atScope(installOpts, installer.ScopeGlobal)
+	}
+
+	for _, slug := range mf.SortedSlugs() {
+		sourceName := mf.Skills[slug]
+		if manifest.IsPathSource(sourceName) {
+			plan.AddWarning("%s will be installed from %s", slug, sourceName)
+			continue
+		}
+		if source, err := database.GetSource(sourceName); err != nil || source == nil {
+			plan.AddClone(sourceName)
+			continue
+		}
+
+		skillOpts := mf.SkillOptions(slug)
+		skill, err := database.GetSkillBySlugAndSource(slug, sourceName)
+		if err == nil && skill == nil {
+			skill, err = database.GetSkillBySlug(slug)
+		}
+		if err != nil || skill == nil {
+			if skillOpts.Optional {
+				plan.AddWarning("optional skill '%s' not found; it will be skipped", slug)
+			} else {
+				plan.AddError("skill '%s' not found in database", slug)
+			}
+			continue
+		}
+		if skill.Source != nil && skill.Source.FullName != sourceName {
+			plan.AddError("skill '%s' found but from different source (%s, expected %s)",
+				slug, skill.Source.FullName, sourceName)
+			continue
+		}
+		if err := checkSkillVersion(skill, skillOpts.Version); err != nil {
+			plan.AddError("skill '%s' %v", slug, err)
+			continue
+		}
+		if skillOpts.Ref != "" {
+			plan.AddWarning("%s will be checked out at %s", sourceName, skillOpts.Ref)
+		}
+
+		for _, opts := range manifestSkillPlan(installOpts, skillOpts) {
+			p, err := service.PlanInstall(ctx, slug, opts)
+			if err != nil {
+				plan.AddError("%s: %v", slug, err)
+				continue
+			}
+			plan.Merge(p)
+		}
+	}
+
+	return plan, nil
+}
+
 // syncInstallSkills installs the resolved skills against every entry in the plan.
 // Each plan entry is a (platforms, scope) InstallOptions; multi-entry plans occur
 // when the user has remembered heterogeneous pairs (e.g. claude=project + cursor=global).
 func syncInstallSkills(
 	ctx context.Context,
+	mf *manifest.ManifestFile,
 	skills []*models.Skill,
+	pins map[string]pinnedRevision,
 	service *installer.InstallService,
 	plan []installer.InstallOptions,
 	reader *bufio.Reader,
@@ -274,17 +758,29 @@ func syncInstallSkills(
 
 	skipAll := false
 
+	fullPlan := plan
 	for _, skill := range skills {
+		plan := manifestSkillPlan(fullPlan, mf.SkillOptions(skill.Slug))
+		pin, pinned := pins[skill.Slug]
+		if pinned {
+			pinnedPlan := make([]installer.InstallOptions, len(plan))
+			for i, opts := range plan {
+				opts.SourceDir = pin.Dir
+				pinnedPlan[i] = opts
+			}
+			plan = pinnedPlan
+		}
 		locations, _ := service.GetInstallLocations(ctx, skill.Slug)
-		if syncPlanFullyInstalled(locations, plan, cwd) {
+		relevantLocations := syncFilterRelevantLocations(locations, cwd)
+		fullyInstalled := syncPlanFullyInstalled(locations, plan, cwd)
+		if fullyInstalled && (!pinned || syncLinkedTo(relevantLocations, skill, pin.Dir)) {
 			fmt.Printf("  %s %s (already installed)\n", skipStyle.Render("o"), skill.Slug)
 			skipped++
 			continue
 		}
 
-		relevantLocations := syncFilterRelevantLocations(locations, cwd)
-
-		if len(relevantLocations) > 0 && !skipAll && !yes {
+		// Installs moving to a pinned revision are relinked without asking
+		if !fullyInstalled && len(relevantLocations) > 0 && !skipAll && !yes {
 			fmt.Printf("\n  '%s' is already installed at some locations.\n", skill.Slug)
 			fmt.Print("  Also install to your selected locations? [y/N/s(kip all)] ")
 
@@ -327,23 +823,91 @@ func syncInstallSkills(
 	return installed, skipped, errored
 }
 
+// manifestSkillPlan narrows the sync plan to a skill's manifest platforms and
+// scope. Platforms listed in the manifest replace the selected ones; a
+// manifest scope replaces the selected scopes.
+func manifestSkillPlan(plan []installer.InstallOptions, opts manifest.SkillOptions) []installer.InstallOptions {
+	if len(opts.Platforms) == 0 && opts.Scope == "" {
+		return plan
+	}
+
+	var scopes []installer.InstallScope
+	if opts.Scope != "" {
+		scopes = []installer.InstallScope{installer.InstallScope(opts.Scope)}
+	}
+
+	if len(opts.Platforms) > 0 {
+		if scopes == nil {
+			seen := make(map[installer.InstallScope]bool)
+			for _, entry := range plan {
+				for _, s := range entry.Scopes {
+					if !seen[s] {
+						seen[s] = true
+						scopes = append(scopes, s)
+					}
+				}
+			}
+			if len(scopes) == 0 {
+				scopes = []installer.InstallScope{installer.ScopeProject}
+			}
+		}
+		return []installer.InstallOptions{{
+			Platforms: opts.Platforms,
+			Scopes:    scopes,
+			Confirm:   true,
+		}}
+	}
+
+	narrowed := make([]installer.InstallOptions, 0, len(plan))
+	for _, entry := range plan {
+		entry.Scopes = scopes
+		narrowed = append(narrowed, entry)
+	}
+	return narrowed
+}
+
+// checkSkillVersion checks a skill's frontmatter version against a manifest
+// constraint. An empty constraint always passes.
+func checkSkillVersion(skill *models.Skill, constraint string) error {
+	if constraint == "" {
+		return nil
+	}
+	c, err := semver.NewConstraint(constraint)
+	if err != nil {
+		return fmt.Errorf("has invalid version constraint %q: %w", constraint, err)
+	}
+	v, err := semver.NewVersion(skill.Version)
+	if err != nil {
+		return fmt.Errorf("has no version to check against %q", constraint)
+	}
+	if !c.Check(v) {
+		return fmt.Errorf("is version %s, manifest requires %s", skill.Version, constraint)
+	}
+	return nil
+}
+
 // buildSyncPlan decides where the manifest's skills should be installed.
 //
 // Non-interactive (--yes or piped stdin):
-//  1. If the user has opted into remembered install locations, honor each
+//  1. Use the platforms and scope saved for this project (see `skulto prefs`).
+//  2. If the user has opted into remembered install locations, honor each
 //     enabled (platform, scope) pair individually. This mirrors `install -y`.
-//  2. Otherwise fall back to detected platforms at **project scope**, because
+//  3. Otherwise fall back to detected platforms at **project scope**, because
 //     skulto.json is a project manifest by design (see cert 2u).
 //
 // Interactive: prompt for platforms and scopes as before, returning a single
-// combined plan entry.
-func buildSyncPlan(ctx context.Context, service *installer.InstallService, database *db.DB, yes bool) ([]installer.InstallOptions, error) {
+// combined plan entry. A single-scope selection is saved for the project.
+func buildSyncPlan(ctx context.Context, service *installer.InstallService, database *db.DB, cwd string, yes bool) ([]installer.InstallOptions, error) {
 	platforms, err := service.DetectPlatforms(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("detect platforms: %w", err)
 	}
 
 	if yes || !isInteractive() {
+		if opts, ok := service.ProjectPreferenceOptions(cwd); ok {
+			return []installer.InstallOptions{opts}, nil
+		}
+
 		if remember, _ := database.GetRememberInstallLocations(); remember {
 			saved, err := database.GetEnabledAgentScopes()
 			if err == nil && len(saved) > 0 {
@@ -388,6 +952,15 @@ func buildSyncPlan(ctx context.Context, service *installer.InstallService, datab
 		return nil, nil
 	}
 
+	// The user-level manifest only ever installs globally
+	if syncGlobal {
+		return []installer.InstallOptions{{
+			Platforms: result.Selected,
+			Scopes:    []installer.InstallScope{installer.ScopeGlobal},
+			Confirm:   true,
+		}}, nil
+	}
+
 	scopeStrs, err := prompts.RunScopeSelector(nil)
 	if err != nil {
 		return nil, fmt.Errorf("scope selection: %w", err)
@@ -397,6 +970,13 @@ func buildSyncPlan(ctx context.Context, service *installer.InstallService, datab
 		scopes = []installer.InstallScope{installer.ScopeProject}
 	}
 
+	// Remember a single-scope selection so later `sync -y` runs match it
+	if len(scopes) == 1 {
+		if err := service.SaveProjectPreference(cwd, result.Selected, scopes[0]); err == nil {
+			fmt.Println("Saved these locations for this project (change with 'skulto prefs').")
+		}
+	}
+
 	return []installer.InstallOptions{{
 		Platforms: result.Selected,
 		Scopes:    scopes,
@@ -404,6 +984,16 @@ func buildSyncPlan(ctx context.Context, service *installer.InstallService, datab
 	}}, nil
 }
 
+// atScope returns plan with every entry moved to scope.
+func atScope(plan []installer.InstallOptions, scope installer.InstallScope) []installer.InstallOptions {
+	out := make([]installer.InstallOptions, 0, len(plan))
+	for _, entry := range plan {
+		entry.Scopes = []installer.InstallScope{scope}
+		out = append(out, entry)
+	}
+	return out
+}
+
 // syncPlanFullyInstalled returns true when the skill is already present at
 // every (platform, scope) pair the plan would install to. Project-scoped
 // installs match cwd; global-scoped installs match the user's home dir.
@@ -434,6 +1024,18 @@ func syncPlanFullyInstalled(existing []installer.InstallLocation, plan []install
 	return true
 }
 
+// syncLinkedTo reports whether the skill's installs at locations all link
+// into the checkout at dir.
+func syncLinkedTo(locations []installer.InstallLocation, skill *models.Skill, dir string) bool {
+	want := filepath.Join(dir, filepath.Dir(filepath.FromSlash(skill.FilePath)))
+	for _, loc := range locations {
+		if target, err := os.Readlink(loc.GetSkillPath(skill.Slug)); err != nil || target != want {
+			return false
+		}
+	}
+	return true
+}
+
 // syncFilterRelevantLocations filters install locations to only those matching
 // the current working directory (for project scope) or home directory (for global scope).
 func syncFilterRelevantLocations(locations []installer.InstallLocation, cwd string) []installer.InstallLocation {
@@ -451,3 +1053,35 @@ func syncFilterRelevantLocations(locations []installer.InstallLocation, cwd stri
 	}
 	return relevant
 }
+
+// runSyncCheck reports drift between the project and skulto.json without
+// installing or removing anything. Drift is returned as an ExitDrift error.
+func runSyncCheck(cwd string, mf *manifest.ManifestFile) error {
+	lf, err := manifest.ReadLock(cwd)
+	if err != nil {
+		return trackCLIError("sync", err)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return trackCLIError("sync", fmt.Errorf("load config: %w", err))
+	}
+	paths := config.GetPaths(cfg)
+	database, err := db.New(db.DefaultConfig(paths.Database))
+	if err != nil {
+		return trackCLIError("sync", fmt.Errorf("initialize database: %w", err))
+	}
+	defer func() { _ = database.Close() }()
+
+	report, err := detectDrift(database, cwd, mf, lf)
+	if err != nil {
+		return trackCLIError("sync", err)
+	}
+	if err := printDriftReport(report, syncJSON); err != nil {
+		return trackCLIError("sync", err)
+	}
+	if !report.InSync {
+		return &exitError{code: ExitDrift, err: fmt.Errorf("%d skill(s) out of sync with skulto.json", report.Count())}
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package cli
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/asteroid-belt/skulto/internal/installer"
+	"github.com/asteroid-belt/skulto/internal/manifest"
+	"github.com/asteroid-belt/skulto/internal/models"
+)
+
+func TestManifestSkillPlan(t *testing.T) {
+	plan := []installer.InstallOptions{{
+		Platforms: []string{"claude", "cursor", "codex"},
+		Scopes:    []installer.InstallScope{installer.ScopeProject},
+		Confirm:   true,
+	}}
+
+	t.Run("no options keeps plan", func(t *testing.T) {
+		assert.Equal(t, plan, manifestSkillPlan(plan, manifest.SkillOptions{}))
+	})
+
+	t.Run("platforms replace selection", func(t *testing.T) {
+		got := manifestSkillPlan(plan, manifest.SkillOptions{Platforms: []string{"claude"}})
+		require.Len(t, got, 1)
+		assert.Equal(t, []string{"claude"}, got[0].Platforms)
+		assert.Equal(t, []installer.InstallScope{installer.ScopeProject}, got[0].Scopes)
+	})
+
+	t.Run("scope replaces selection", func(t *testing.T) {
+		got := manifestSkillPlan(plan, manifest.SkillOptions{Scope: "global"})
+		require.Len(t, got, 1)
+		assert.Equal(t, plan[0].Platforms, got[0].Platforms)
+		assert.Equal(t, []installer.InstallScope{installer.ScopeGlobal}, got[0].Scopes)
+		// The caller's plan is not modified
+		assert.Equal(t, []installer.InstallScope{installer.ScopeProject}, plan[0].Scopes)
+	})
+}
+
+func TestCheckSkillVersion(t *testing.T) {
+	skill := &models.Skill{Slug: "teach", Version: "1.4.0"}
+
+	assert.NoError(t, checkSkillVersion(skill, ""))
+	assert.NoError(t, checkSkillVersion(skill, "^1.2"))
+	assert.Error(t, checkSkillVersion(skill, ">=2.0"))
+	assert.Error(t, checkSkillVersion(skill, "not a constraint"))
+	assert.Error(t, checkSkillVersion(&models.Skill{Slug: "x"}, "^1.0"))
+}
+
+func TestManifestExtras(t *testing.T) {
+	database := testDB(t)
+	cwd := t.TempDir()
+
+	for _, slug := range []string{"listed", "dropped", "ignored", "elsewhere", "global"} {
+		require.NoError(t, database.CreateSkill(&models.Skill{ID: slug + "-id", Slug: slug, Title: slug}))
+	}
+	for _, id := range []string{"listed-id", "dropped-id", "ignored-id"} {
+		require.NoError(t, database.AddInstallation(&models.SkillInstallation{SkillID: id, Platform: "claude", Scope: "project", BasePath: cwd}))
+	}
+	require.NoError(t, database.AddInstallation(&models.SkillInstallation{SkillID: "elsewhere-id", Platform: "claude", Scope: "project", BasePath: t.TempDir()}))
+	require.NoError(t, database.AddInstallation(&models.SkillInstallation{SkillID: "global-id", Platform: "claude", Scope: "global", BasePath: cwd}))
+
+	mf := manifest.New()
+	mf.Skills["listed"] = "acme/skills"
+	mf.Ignored = []string{"ignored"}
+
+	slugs, locations, err := manifestExtras(database, cwd, mf)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"dropped"}, slugs)
+	assert.Equal(t, []installer.InstallLocation{{Platform: installer.PlatformClaude, Scope: installer.ScopeProject, BasePath: cwd}}, locations["dropped"])
+	assert.NotContains(t, locations, "listed")
+}
+
+func TestBuildSyncPlan_UsesProjectPreference(t *testing.T) {
+	setupTestTelemetry()
+	database := testDB(t)
+	cfg := testConfig(t)
+	service := installer.NewInstallService(database, cfg, telemetryClient)
+	cwd := t.TempDir()
+
+	require.NoError(t, database.SetRememberInstallLocations(true))
+	require.NoError(t, database.EnableAgentsWithScopes(map[string]string{"cursor": "global"}))
+	require.NoError(t, service.SaveProjectPreference(cwd, []string{"claude", "codex"}, installer.ScopeProject))
+
+	plan, err := buildSyncPlan(context.Background(), service, database, cwd, true)
+	require.NoError(t, err)
+	require.Len(t, plan, 1)
+	assert.Equal(t, []string{"claude", "codex"}, plan[0].Platforms)
+	assert.Equal(t, []installer.InstallScope{installer.ScopeProject}, plan[0].Scopes)
+
+	// Another project falls back to remembered locations
+	plan, err = buildSyncPlan(context.Background(), service, database, t.TempDir(), true)
+	require.NoError(t, err)
+	require.Len(t, plan, 1)
+	assert.Equal(t, []string{"cursor"}, plan[0].Platforms)
+}
+
+func TestAtScope(t *testing.T) {
+	plan := []installer.InstallOptions{
+		{Platforms: []string{"claude"}, Scopes: []installer.InstallScope{installer.ScopeProject}},
+		{Platforms: []string{"cursor"}, Scopes: []installer.InstallScope{installer.ScopeProject, installer.ScopeGlobal}},
+	}
+	got := atScope(plan, installer.ScopeGlobal)
+	for _, entry := range got {
+		assert.Equal(t, []installer.InstallScope{installer.ScopeGlobal}, entry.Scopes)
+	}
+	assert.Equal(t, []installer.InstallScope{installer.ScopeProject}, plan[0].Scopes, "input is not modified")
+}
@@ -0,0 +1,145 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/asteroid-belt/skulto/internal/config"
+	"github.com/asteroid-belt/skulto/internal/db"
+	"github.com/asteroid-belt/skulto/internal/journal"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+)
+
+var (
+	undoCount    int
+	historyLimit int
+)
+
+var undoCmd = &cobra.Command{
+	Use:   "undo",
+	Short: "Reverse the last uninstall, remove or manifest save",
+	Long: `Reverse the most recent destructive operations recorded in the journal.
+
+Uninstall, remove and save record what they delete: symlinks, database
+rows, repository clones and the previous skulto.json. Undo puts them back.
+Use 'skulto history' to see what would be reversed.
+
+The journal keeps the last 50 operations for 30 days. Override with
+SKULTO_JOURNAL_MAX_ENTRIES and SKULTO_JOURNAL_MAX_DAYS.
+
+Examples:
+  # Undo the last operation
+  skulto undo
+
+  # Undo the last three operations
+  skulto undo -n 3`,
+	Args: cobra.NoArgs,
+	RunE: runUndo,
+}
+
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "List operations that can be undone",
+	Long: `List recent uninstall, remove and save operations from the journal,
+newest first. Operations already reversed are marked as undone.
+
+Examples:
+  skulto history
+  skulto history --limit 5`,
+	Args: cobra.NoArgs,
+	RunE: runHistory,
+}
+
+func init() {
+	undoCmd.Flags().IntVarP(&undoCount, "count", "n", 1,
+		"Number of operations to undo")
+	historyCmd.Flags().IntVarP(&historyLimit, "limit", "l", 20,
+		"Maximum number of operations to show (0 for all)")
+}
+
+// openJournal returns the undo journal configured for this installation.
+func openJournal(cfg *config.Config, database *db.DB) *journal.Journal {
+	return journal.Open(cfg, database)
+}
+
+// commitJournal saves a recorder and prints how to reverse it.
+// Journal failures never fail the command that was recorded.
+func commitJournal(rec *journal.Recorder) {
+	entry, err := rec.Commit()
+	if err != nil {
+		fmt.Printf("Warning: failed to record undo journal: %v\n", err)
+		return
+	}
+	if entry != nil {
+		fmt.Println(planMutedStyle.Render("Run 'skulto undo' to reverse this."))
+	}
+}
+
+func runUndo(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return trackCLIError("undo", fmt.Errorf("load config: %w", err))
+	}
+
+	paths := config.GetPaths(cfg)
+	database, err := db.New(db.DefaultConfig(paths.Database))
+	if err != nil {
+		return trackCLIError("undo", fmt.Errorf("initialize database: %w", err))
+	}
+	defer func() { _ = database.Close() }()
+
+	results, err := openJournal(cfg, database).Undo(undoCount)
+	if errors.Is(err, journal.ErrNothingToUndo) {
+		fmt.Println("Nothing to undo.")
+		return nil
+	}
+
+	for _, r := range results {
+		fmt.Printf("%s #%d %s (%d item(s) restored)\n",
+			cleanStyle.Render("✓ Undone"), r.Entry.ID, r.Entry.Summary, r.Restored)
+		for _, w := range r.Warnings {
+			fmt.Printf("  %s %s\n", highStyle.Render("!"), w)
+		}
+	}
+	if err != nil {
+		return trackCLIError("undo", err)
+	}
+	return nil
+}
+
+func runHistory(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return trackCLIError("history", fmt.Errorf("load config: %w", err))
+	}
+
+	paths := config.GetPaths(cfg)
+	database, err := db.New(db.DefaultConfig(paths.Database))
+	if err != nil {
+		return trackCLIError("history", fmt.Errorf("initialize database: %w", err))
+	}
+	defer func() { _ = database.Close() }()
+
+	entries, err := openJournal(cfg, database).History(historyLimit)
+	if err != nil {
+		return trackCLIError("history", err)
+	}
+	if len(entries) == 0 {
+		fmt.Println("No operations recorded.")
+		return nil
+	}
+
+	headerStyle := lipgloss.NewStyle().Bold(true)
+	fmt.Println(headerStyle.Render(fmt.Sprintf("%-5s %-17s %-10s %s", "ID", "WHEN", "COMMAND", "SUMMARY")))
+	fmt.Println(strings.Repeat("─", 60))
+	for _, e := range entries {
+		line := fmt.Sprintf("%-5d %-17s %-10s %s", e.ID, e.CreatedAt.Local().Format("2006-01-02 15:04"), e.Command, e.Summary)
+		if e.IsUndone() {
+			line = planMutedStyle.Render(line + " (undone)")
+		}
+		fmt.Println(line)
+	}
+	return nil
+}
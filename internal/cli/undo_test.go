@@ -0,0 +1,22 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUndoCmd_Structure(t *testing.T) {
+	assert.Equal(t, "undo", undoCmd.Use)
+	assert.NotEmpty(t, undoCmd.Long)
+
+	nFlag := undoCmd.Flags().Lookup("count")
+	assert.NotNil(t, nFlag)
+	assert.Equal(t, "n", nFlag.Shorthand)
+	assert.Equal(t, "1", nFlag.DefValue)
+}
+
+func TestHistoryCmd_Structure(t *testing.T) {
+	assert.Equal(t, "history", historyCmd.Use)
+	assert.NotNil(t, historyCmd.Flags().Lookup("limit"))
+}
@@ -11,8 +11,11 @@ import (
 )
 
 var (
-	uninstallYes bool
-	uninstallAll bool
+	uninstallYes    bool
+	uninstallAll    bool
+	uninstallDryRun bool
+	uninstallJSON   bool
+	uninstallForce  bool
 )
 
 var uninstallCmd = &cobra.Command{
@@ -27,6 +30,9 @@ Interactive Mode (default):
 Non-Interactive Mode (-y):
   Removes from all installed locations.
 
+Skills required by other installed skills (via requires: in their
+frontmatter) are not removed unless --force is given.
+
 Examples:
   # Interactive uninstall - choose which locations
   skulto uninstall docker-expert
@@ -35,7 +41,13 @@ Examples:
   skulto uninstall docker-expert -y
 
   # Same as -y, explicit flag
-  skulto uninstall docker-expert --all`,
+  skulto uninstall docker-expert --all
+
+  # Preview which symlinks would be removed
+  skulto uninstall docker-expert --dry-run
+
+  # Remove a skill even though other installed skills require it
+  skulto uninstall changelog -y --force`,
 	Args: cobra.ExactArgs(1),
 	RunE: runUninstall,
 }
@@ -45,6 +57,12 @@ func init() {
 		"Skip interactive prompts, remove from all locations")
 	uninstallCmd.Flags().BoolVarP(&uninstallAll, "all", "a", false,
 		"Remove from all installed locations (same as -y)")
+	uninstallCmd.Flags().BoolVar(&uninstallDryRun, "dry-run", false,
+		"Show what would be removed without making changes")
+	uninstallCmd.Flags().BoolVar(&uninstallJSON, "json", false,
+		"Print the --dry-run plan as JSON")
+	uninstallCmd.Flags().BoolVarP(&uninstallForce, "force", "f", false,
+		"Uninstall even if other installed skills require this skill")
 }
 
 func runUninstall(cmd *cobra.Command, args []string) error {
@@ -67,6 +85,14 @@ func runUninstall(cmd *cobra.Command, args []string) error {
 	// Create install service
 	service := installer.NewInstallService(database, cfg, telemetryClient)
 
+	if uninstallDryRun {
+		plan, err := service.PlanUninstall(ctx, slug, nil)
+		if err != nil {
+			return trackCLIError("uninstall", err)
+		}
+		return printPlan(plan, uninstallJSON)
+	}
+
 	// Get current install locations
 	locations, err := service.GetInstallLocations(ctx, slug)
 	if err != nil {
@@ -105,9 +131,10 @@ func runUninstall(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	// Perform uninstallation
+	// Perform uninstallation, recording it so it can be undone
 	fmt.Printf("Uninstalling from %d location(s)...\n", len(toUninstall))
-	if err := service.Uninstall(ctx, slug, toUninstall); err != nil {
+	entry, err := service.UninstallJournaled(ctx, slug, toUninstall, uninstallForce)
+	if err != nil {
 		return trackCLIError("uninstall", fmt.Errorf("uninstall failed: %w", err))
 	}
 
@@ -116,6 +143,9 @@ func runUninstall(cmd *cobra.Command, args []string) error {
 		fmt.Printf("  ✓ Removed from %s (%s)\n", loc.Platform, loc.Scope)
 	}
 	fmt.Println("\nDone!")
+	if entry != nil {
+		fmt.Println(planMutedStyle.Render("Run 'skulto undo' to reverse this."))
+	}
 
 	return nil
 }
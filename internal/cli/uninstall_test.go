@@ -19,6 +19,10 @@ func TestUninstallCmd_Structure(t *testing.T) {
 	aFlag := uninstallCmd.Flags().Lookup("all")
 	assert.NotNil(t, aFlag)
 	assert.Equal(t, "a", aFlag.Shorthand)
+
+	fFlag := uninstallCmd.Flags().Lookup("force")
+	assert.NotNil(t, fFlag)
+	assert.Equal(t, "f", fFlag.Shorthand)
 }
 
 func TestUninstallCmd_RequiresArg(t *testing.T) {
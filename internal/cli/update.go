@@ -3,7 +3,8 @@ package cli
 import (
 	"context"
 	"fmt"
-	"time"
+	"os"
+	"path/filepath"
 
 	"github.com/asteroid-belt/skulto/internal/config"
 	"github.com/asteroid-belt/skulto/internal/db"
@@ -33,16 +34,30 @@ Examples:
   skulto update
 
   # Update and scan ALL skills (not just new/updated)
-  skulto update --scan-all`,
+  skulto update --scan-all
+
+  # Preview which repositories would be pulled
+  skulto update --dry-run`,
 	Args: cobra.NoArgs,
 	RunE: runUpdate,
 }
 
-var updateScanAll bool
+var (
+	updateScanAll bool
+	updateDryRun  bool
+	updateJSON    bool
+	updateJobs    int
+)
 
 func init() {
 	updateCmd.Flags().BoolVar(&updateScanAll, "scan-all", false,
 		"Scan all skills, not just newly updated ones")
+	updateCmd.Flags().BoolVar(&updateDryRun, "dry-run", false,
+		"Show which repositories would be pulled without making changes")
+	updateCmd.Flags().BoolVar(&updateJSON, "json", false,
+		"Print the --dry-run plan as JSON")
+	updateCmd.Flags().IntVarP(&updateJobs, "jobs", "j", 0,
+		"Repositories to pull at once (default from SKULTO_PULL_CONCURRENCY, or 8)")
 }
 
 // SkillChange tracks what changed for a skill during update.
@@ -87,6 +102,14 @@ func runUpdate(cmd *cobra.Command, args []string) error {
 		_ = database.Close()
 	}()
 
+	if updateDryRun {
+		plan, err := planUpdate(cfg, database)
+		if err != nil {
+			return err
+		}
+		return printPlan(plan, updateJSON)
+	}
+
 	result := &UpdateResult{}
 
 	// Phase 1: Pull repositories
@@ -116,6 +139,42 @@ func runUpdate(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// planUpdate lists the repositories update would clone or fetch and how many
+// skills it would scan. Content changes are unknown until the fetch runs.
+func planUpdate(cfg *config.Config, database *db.DB) (*installer.Plan, error) {
+	plan := installer.NewPlan("update")
+
+	sources, err := database.ListSources()
+	if err != nil {
+		return nil, fmt.Errorf("list sources: %w", err)
+	}
+
+	repoManager := scraper.NewRepositoryManagerFromConfig(cfg)
+	for _, source := range sources {
+		repoName := fmt.Sprintf("%s/%s", source.Owner, source.Repo)
+		gitDir := filepath.Join(repoManager.GetRepoPath(source.Owner, source.Repo), ".git")
+		if source.LocalDir() != "" {
+			plan.AddPull(repoName) // Rescanned in place
+		} else if _, err := os.Stat(gitDir); err == nil {
+			plan.AddPull(repoName)
+		} else {
+			plan.AddClone(repoName)
+		}
+	}
+
+	var skills []models.Skill
+	if updateScanAll {
+		skills, err = database.GetAllSkills()
+	} else {
+		skills, err = database.GetPendingSkills()
+	}
+	if err == nil {
+		plan.PendingScans = len(skills)
+	}
+
+	return plan, nil
+}
+
 func runUpdatePull(ctx context.Context, cfg *config.Config, database *db.DB, result *UpdateResult) error {
 	sources, err := database.ListSources()
 	if err != nil {
@@ -128,12 +187,7 @@ func runUpdatePull(ctx context.Context, cfg *config.Config, database *db.DB, res
 	}
 
 	// Create scraper
-	scraperCfg := scraper.ScraperConfig{
-		Token:        cfg.GitHub.Token,
-		DataDir:      cfg.BaseDir,
-		RepoCacheTTL: cfg.GitHub.RepoCacheTTL,
-		UseGitClone:  cfg.GitHub.UseGitClone,
-	}
+	scraperCfg := scraper.ScraperConfigFromConfig(cfg)
 	s := scraper.NewScraperWithConfig(scraperCfg, database)
 
 	// Track skills before pull to detect updates
@@ -143,39 +197,20 @@ func runUpdatePull(ctx context.Context, cfg *config.Config, database *db.DB, res
 		skillsBefore[skill.ID] = skill.ContentHash
 	}
 
-	// Initialize progress bar
-	progress := NewProgressBar(len(sources), 15)
-
-	// Sync each repository
-	for i, source := range sources {
-		repoName := fmt.Sprintf("%s/%s", source.Owner, source.Repo)
-		progress.Update(i+1, repoName)
-		ClearLine()
-		fmt.Print("   " + progress.Render())
-
-		syncCtx, cancel := context.WithTimeout(ctx, 5*time.Minute)
-		scrapeResult, err := s.ScrapeRepository(syncCtx, source.Owner, source.Repo)
-		cancel()
-
-		if err != nil {
-			ClearLine()
-			fmt.Printf("   x %s: %v\n", repoName, err)
+	for _, r := range pullSources(ctx, cfg, s, sources, updateJobs) {
+		if r.Err != nil {
 			result.ReposErrored++
 			continue
 		}
 
 		result.ReposSynced++
-		result.SkillsNew += scrapeResult.SkillsNew
-		result.SkillsUpdated += scrapeResult.SkillsUpdated
+		result.SkillsNew += r.Result.SkillsNew
+		result.SkillsUpdated += r.Result.SkillsUpdated
 
 		// Track telemetry per repo
-		telemetryClient.TrackRepoSynced(source.ID, scrapeResult.SkillsNew, 0, scrapeResult.SkillsUpdated)
+		telemetryClient.TrackRepoSynced(r.Source.ID, r.Result.SkillsNew, 0, r.Result.SkillsUpdated)
 	}
 
-	// Final progress
-	ClearLine()
-	fmt.Println("   ✓ Pull complete")
-
 	// Sync install state
 	fmt.Println("   Reconciling install state...")
 	inst := installer.New(database, cfg)
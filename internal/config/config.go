@@ -2,8 +2,12 @@
 package config
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/asteroid-belt/skulto/internal/log"
 )
@@ -16,11 +20,46 @@ type Config struct {
 	// GitHub API settings
 	GitHub GitHubConfig
 
+	// Credentials for git hosts other than GitHub
+	Git GitConfig
+
 	// Embedding/Vector Store settings
 	Embedding VectorConfig
 
 	// LLM settings for skill builder
 	LLM LLMConfig
+
+	// Undo journal retention
+	Journal JournalConfig
+
+	// Parallel repository pulls
+	Pull PullConfig
+
+	// Registry indexes (URLs or local files) whose sources are merged in by
+	// `skulto registry sync` and `skulto pull` (SKULTO_REGISTRIES)
+	Registries []string
+
+	// Repository cache limits
+	Cache CacheConfig
+}
+
+// CacheConfig limits the disk space `skulto cache gc` leaves cloned
+// repositories.
+type CacheConfig struct {
+	Budget int64 // Bytes of clones to keep; 0 for no limit (SKULTO_CACHE_BUDGET, e.g. "2GB")
+}
+
+// PullConfig limits how `skulto pull` and `skulto update` fetch repositories.
+type PullConfig struct {
+	Concurrency int           // Repositories pulled at once (SKULTO_PULL_CONCURRENCY)
+	PerHost     int           // Repositories pulled at once from one host (SKULTO_PULL_PER_HOST)
+	Timeout     time.Duration // Limit for each repository (SKULTO_PULL_TIMEOUT, e.g. "90s" or "5m")
+}
+
+// JournalConfig holds retention limits for the undo journal.
+type JournalConfig struct {
+	MaxEntries int // Operations kept for `skulto undo` (SKULTO_JOURNAL_MAX_ENTRIES)
+	MaxAgeDays int // Days before an operation expires (SKULTO_JOURNAL_MAX_DAYS)
 }
 
 // LLMConfig holds LLM provider configuration for skill building.
@@ -81,6 +120,27 @@ type GitHubConfig struct {
 	UseGitClone  bool // Use git clone instead of API (default: true)
 }
 
+// GitConfig holds settings for cloning from any git host.
+type GitConfig struct {
+	// HostTokens maps a host (e.g. "gitlab.example.com") to the token used
+	// for https clones from it. Set with SKULTO_GIT_TOKENS as comma-separated
+	// host=token pairs; a token may be "user:token" to set the username.
+	HostTokens map[string]string
+
+	// SSHKey is a private key file for ssh remotes (SKULTO_SSH_KEY), tried
+	// after ssh-agent and before ~/.ssh/id_*. SSHKeyPassphrase
+	// (SKULTO_SSH_KEY_PASSPHRASE) unlocks it if it is encrypted.
+	SSHKey           string
+	SSHKeyPassphrase string
+
+	// MetadataProviders chooses where stars and forks come from per host:
+	// "github", "gitlab" or "none". Set with SKULTO_METADATA_PROVIDERS as
+	// comma-separated host=kind pairs; "*=none" turns lookups off everywhere.
+	// Unlisted hosts use GitHub for github.com, GitLab for gitlab.com and
+	// gitlab.* hosts, and none otherwise.
+	MetadataProviders map[string]string
+}
+
 // Load reads configuration from environment variables.
 func Load() (*Config, error) {
 	cfg := DefaultConfig()
@@ -105,6 +165,15 @@ func Load() (*Config, error) {
 		cfg.GitHub.Token = token
 	}
 
+	if tokens := os.Getenv("SKULTO_GIT_TOKENS"); tokens != "" {
+		cfg.Git.HostTokens = parseHostTokens(tokens)
+	}
+	cfg.Git.SSHKey = os.Getenv("SKULTO_SSH_KEY")
+	cfg.Git.SSHKeyPassphrase = os.Getenv("SKULTO_SSH_KEY_PASSPHRASE")
+	if providers := os.Getenv("SKULTO_METADATA_PROVIDERS"); providers != "" {
+		cfg.Git.MetadataProviders = parseHostTokens(providers)
+	}
+
 	if apiKey := os.Getenv("OPENAI_API_KEY"); apiKey != "" {
 		cfg.Embedding.APIKey = apiKey
 		cfg.Embedding.Enabled = true
@@ -119,6 +188,31 @@ func Load() (*Config, error) {
 		cfg.LLM.OpenRouterAPIKey = apiKey
 	}
 
+	if n, err := strconv.Atoi(os.Getenv("SKULTO_JOURNAL_MAX_ENTRIES")); err == nil && n > 0 {
+		cfg.Journal.MaxEntries = n
+	}
+	if n, err := strconv.Atoi(os.Getenv("SKULTO_JOURNAL_MAX_DAYS")); err == nil && n > 0 {
+		cfg.Journal.MaxAgeDays = n
+	}
+
+	if n, err := strconv.Atoi(os.Getenv("SKULTO_PULL_CONCURRENCY")); err == nil && n > 0 {
+		cfg.Pull.Concurrency = n
+	}
+	if n, err := strconv.Atoi(os.Getenv("SKULTO_PULL_PER_HOST")); err == nil && n > 0 {
+		cfg.Pull.PerHost = n
+	}
+	if d := parseTimeout(os.Getenv("SKULTO_PULL_TIMEOUT")); d > 0 {
+		cfg.Pull.Timeout = d
+	}
+
+	if registries := os.Getenv("SKULTO_REGISTRIES"); registries != "" {
+		cfg.Registries = parseList(registries)
+	}
+
+	if budget, err := ParseSize(os.Getenv("SKULTO_CACHE_BUDGET")); err == nil && budget > 0 {
+		cfg.Cache.Budget = budget
+	}
+
 	// Derive Embedding.DataDir from BaseDir if not explicitly set
 	if cfg.Embedding.DataDir == "" {
 		cfg.Embedding.DataDir = filepath.Join(cfg.BaseDir, "vectors")
@@ -132,6 +226,72 @@ func Load() (*Config, error) {
 	return cfg, nil
 }
 
+// parseHostTokens parses comma-separated host=value pairs, such as
+// SKULTO_GIT_TOKENS. Malformed entries are skipped.
+func parseHostTokens(s string) map[string]string {
+	tokens := make(map[string]string)
+	for _, entry := range strings.Split(s, ",") {
+		host, token, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		host = strings.ToLower(strings.TrimSpace(host))
+		token = strings.TrimSpace(token)
+		if !ok || host == "" || token == "" {
+			continue
+		}
+		tokens[host] = token
+	}
+	return tokens
+}
+
+// parseList parses a comma-separated list, dropping empty entries.
+func parseList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// parseTimeout parses a Go duration ("90s", "5m"), or a bare number of
+// seconds. Invalid or empty values return 0.
+func parseTimeout(s string) time.Duration {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0
+	}
+	if n, err := strconv.Atoi(s); err == nil {
+		return time.Duration(n) * time.Second
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0
+	}
+	return d
+}
+
+// ParseSize parses a size such as "2GB", "500M", "1.5 GiB" or a bare number
+// of bytes. Units are powers of 1024.
+func ParseSize(s string) (int64, error) {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	if s == "" {
+		return 0, fmt.Errorf("empty size")
+	}
+	num := strings.TrimRight(s, "KMGTIB ")
+	unit := strings.TrimSpace(s[len(num):])
+	unit = strings.TrimSuffix(strings.TrimSuffix(unit, "B"), "I")
+	shifts := map[string]uint{"": 0, "K": 10, "M": 20, "G": 30, "T": 40}
+	shift, ok := shifts[unit]
+	if !ok {
+		return 0, fmt.Errorf("invalid size %q", s)
+	}
+	n, err := strconv.ParseFloat(num, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid size %q", s)
+	}
+	return int64(n * float64(uint64(1)<<shift)), nil
+}
+
 // ensureDirectories creates required directories if they don't exist.
 func ensureDirectories(cfg *Config) error {
 	dirs := []string{
@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -110,3 +111,55 @@ func TestGitHubTokenFromEnv(t *testing.T) {
 
 	assert.Equal(t, "ghp_test123", cfg.GitHub.Token)
 }
+
+func TestParseHostTokens(t *testing.T) {
+	tokens := parseHostTokens(" GitLab.example.com=glpat-1 , bitbucket.org=x-token-auth:bb,broken,=empty,host=")
+	assert.Equal(t, map[string]string{
+		"gitlab.example.com": "glpat-1",
+		"bitbucket.org":      "x-token-auth:bb",
+	}, tokens)
+}
+
+func TestParseList(t *testing.T) {
+	assert.Equal(t, []string{"https://skills.acme.dev/index.json", "/etc/skulto/registry.json"},
+		parseList(" https://skills.acme.dev/index.json ,, /etc/skulto/registry.json,"))
+	assert.Empty(t, parseList(" , "))
+}
+
+func TestParseTimeout(t *testing.T) {
+	assert.Equal(t, 90*time.Second, parseTimeout("90s"))
+	assert.Equal(t, 2*time.Minute, parseTimeout(" 2m "))
+	assert.Equal(t, 45*time.Second, parseTimeout("45"))
+	assert.Equal(t, time.Duration(0), parseTimeout(""))
+	assert.Equal(t, time.Duration(0), parseTimeout("soon"))
+}
+
+func TestParseSize(t *testing.T) {
+	tests := map[string]int64{
+		"1024":    1024,
+		"2GB":     2 << 30,
+		"500m":    500 << 20,
+		"1.5 GiB": 3 << 29,
+		"64K":     64 << 10,
+		"0":       0,
+	}
+	for in, want := range tests {
+		got, err := ParseSize(in)
+		require.NoError(t, err, in)
+		assert.Equal(t, want, got, in)
+	}
+	for _, in := range []string{"", "big", "2XB", "-1GB", "GB"} {
+		_, err := ParseSize(in)
+		assert.Error(t, err, in)
+	}
+}
+
+func TestMetadataProvidersFromEnv(t *testing.T) {
+	t.Setenv("SKULTO_SKIP_MIGRATION", "1")
+	t.Setenv("SKULTO_METADATA_PROVIDERS", "git.corp.com=gitlab, *=none")
+
+	cfg, err := Load()
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{"git.corp.com": "gitlab", "*": "none"}, cfg.Git.MetadataProviders)
+}
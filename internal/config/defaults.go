@@ -1,5 +1,7 @@
 package config
 
+import "time"
+
 // DefaultConfig returns a Config with sensible defaults.
 func DefaultConfig() *Config {
 	return &Config{
@@ -16,6 +18,17 @@ func DefaultConfig() *Config {
 		Embedding: DefaultVectorConfig(),
 
 		LLM: DefaultLLMConfig(),
+
+		Journal: JournalConfig{
+			MaxEntries: 50,
+			MaxAgeDays: 30,
+		},
+
+		Pull: PullConfig{
+			Concurrency: 8,
+			PerHost:     4,
+			Timeout:     5 * time.Minute,
+		},
 	}
 }
 
@@ -13,6 +13,7 @@ type Paths struct {
 	Repositories string // Cloned repositories directory
 	Skills       string // Local skills directory
 	Favorites    string // Favorites JSON file (persists across DB resets)
+	Journal      string // Undo journal storage (trashed clones)
 }
 
 // GetPaths returns all commonly used paths based on config.
@@ -24,6 +25,7 @@ func GetPaths(cfg *Config) Paths {
 		Repositories: filepath.Join(cfg.BaseDir, "repositories"),
 		Skills:       filepath.Join(cfg.BaseDir, "skills"),
 		Favorites:    filepath.Join(cfg.BaseDir, "favorites.json"),
+		Journal:      filepath.Join(cfg.BaseDir, "journal"),
 	}
 }
 
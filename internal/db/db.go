@@ -126,6 +126,9 @@ func (db *DB) migrate() error {
 		&models.SecurityScan{},
 		&models.AgentPreference{},
 		&models.DiscoveredSkill{},
+		&models.JournalEntry{},
+		&models.Profile{},
+		&models.ProjectPreference{},
 	)
 }
 
@@ -218,6 +221,12 @@ func (db *DB) Path() string {
 	return db.path
 }
 
+// Vacuum rebuilds the database file, returning the space left by deleted
+// rows to the filesystem.
+func (db *DB) Vacuum() error {
+	return db.Exec("VACUUM").Error
+}
+
 // Close closes the database connection.
 func (db *DB) Close() error {
 	sqlDB, err := db.DB.DB()
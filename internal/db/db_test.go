@@ -1,8 +1,10 @@
 package db
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -81,6 +83,38 @@ func TestNew_CreatesDirectory(t *testing.T) {
 	}
 }
 
+func TestVacuum(t *testing.T) {
+	db := testDB(t)
+
+	content := strings.Repeat("x", 4096)
+	for i := 0; i < 200; i++ {
+		skill := &models.Skill{ID: fmt.Sprintf("skill-%d", i), Slug: fmt.Sprintf("skill-%d", i), Title: "Skill", Content: content}
+		if err := db.CreateSkill(skill); err != nil {
+			t.Fatalf("CreateSkill() error = %v", err)
+		}
+	}
+	for i := 0; i < 200; i++ {
+		if err := db.HardDeleteSkill(fmt.Sprintf("skill-%d", i)); err != nil {
+			t.Fatalf("HardDeleteSkill() error = %v", err)
+		}
+	}
+
+	before, err := os.Stat(db.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Vacuum(); err != nil {
+		t.Fatalf("Vacuum() error = %v", err)
+	}
+	after, err := os.Stat(db.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if after.Size() >= before.Size() {
+		t.Errorf("Vacuum() left the file at %d bytes, was %d", after.Size(), before.Size())
+	}
+}
+
 func TestGetStats_EmptyDB(t *testing.T) {
 	db := testDB(t)
 
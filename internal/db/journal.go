@@ -0,0 +1,53 @@
+package db
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/asteroid-belt/skulto/internal/models"
+)
+
+// AddJournalEntry records a new undoable operation.
+func (db *DB) AddJournalEntry(entry *models.JournalEntry) error {
+	return db.Create(entry).Error
+}
+
+// GetJournalEntry retrieves a journal entry by ID.
+// Returns nil, nil if not found.
+func (db *DB) GetJournalEntry(id uint) (*models.JournalEntry, error) {
+	var entry models.JournalEntry
+	err := db.First(&entry, "id = ?", id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// ListJournalEntries returns journal entries newest first.
+// A limit of 0 returns all entries.
+func (db *DB) ListJournalEntries(limit int, includeUndone bool) ([]models.JournalEntry, error) {
+	var entries []models.JournalEntry
+	q := db.Order("id DESC")
+	if !includeUndone {
+		q = q.Where("undone_at IS NULL")
+	}
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+	err := q.Find(&entries).Error
+	return entries, err
+}
+
+// MarkJournalEntryUndone records that an entry has been reversed.
+func (db *DB) MarkJournalEntryUndone(id uint, at time.Time) error {
+	return db.Model(&models.JournalEntry{}).Where("id = ?", id).Update("undone_at", at).Error
+}
+
+// DeleteJournalEntry removes a journal entry.
+func (db *DB) DeleteJournalEntry(id uint) error {
+	return db.Delete(&models.JournalEntry{}, "id = ?", id).Error
+}
@@ -0,0 +1,42 @@
+package db
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/asteroid-belt/skulto/internal/models"
+)
+
+// UpsertProfile creates or replaces a profile by name.
+func (db *DB) UpsertProfile(profile *models.Profile) error {
+	return db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "name"}},
+		DoUpdates: clause.AssignmentColumns([]string{"description", "skills", "platforms", "scope", "updated_at"}),
+	}).Create(profile).Error
+}
+
+// GetProfile retrieves a profile by name.
+// Returns nil, nil if not found.
+func (db *DB) GetProfile(name string) (*models.Profile, error) {
+	var profile models.Profile
+	err := db.First(&profile, "name = ?", name).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &profile, nil
+}
+
+// ListProfiles returns all profiles sorted by name.
+func (db *DB) ListProfiles() ([]models.Profile, error) {
+	var profiles []models.Profile
+	err := db.Order("name ASC").Find(&profiles).Error
+	return profiles, err
+}
+
+// DeleteProfile removes a profile by name.
+func (db *DB) DeleteProfile(name string) error {
+	return db.Delete(&models.Profile{}, "name = ?", name).Error
+}
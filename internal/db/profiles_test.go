@@ -0,0 +1,47 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/asteroid-belt/skulto/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProfiles_UpsertGetListDelete(t *testing.T) {
+	db := testDB(t)
+
+	missing, err := db.GetProfile("backend")
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+
+	profile := &models.Profile{Name: "backend", Scope: "project"}
+	profile.SetSkills([]string{"go-style", "sql-review"})
+	require.NoError(t, db.UpsertProfile(profile))
+
+	// Replace with a different skill set
+	updated := &models.Profile{Name: "backend", Scope: "global", Description: "API work"}
+	updated.SetSkills([]string{"go-style"})
+	updated.SetPlatforms([]string{"claude"})
+	require.NoError(t, db.UpsertProfile(updated))
+
+	require.NoError(t, db.UpsertProfile(&models.Profile{Name: "alpha", Scope: "project"}))
+
+	got, err := db.GetProfile("backend")
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, []string{"go-style"}, got.GetSkills())
+	assert.Equal(t, []string{"claude"}, got.GetPlatforms())
+	assert.Equal(t, "global", got.Scope)
+	assert.Equal(t, "API work", got.Description)
+
+	all, err := db.ListProfiles()
+	require.NoError(t, err)
+	require.Len(t, all, 2)
+	assert.Equal(t, "alpha", all[0].Name)
+
+	require.NoError(t, db.DeleteProfile("backend"))
+	got, err = db.GetProfile("backend")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
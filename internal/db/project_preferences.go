@@ -0,0 +1,42 @@
+package db
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/asteroid-belt/skulto/internal/models"
+)
+
+// SetProjectPreference creates or replaces the install preference for a project path.
+func (db *DB) SetProjectPreference(pref *models.ProjectPreference) error {
+	return db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "path"}},
+		DoUpdates: clause.AssignmentColumns([]string{"platforms", "scope", "updated_at"}),
+	}).Create(pref).Error
+}
+
+// GetProjectPreference retrieves the install preference for a project path.
+// Returns nil, nil if none is saved.
+func (db *DB) GetProjectPreference(path string) (*models.ProjectPreference, error) {
+	var pref models.ProjectPreference
+	err := db.First(&pref, "path = ?", path).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &pref, nil
+}
+
+// ListProjectPreferences returns all saved project preferences sorted by path.
+func (db *DB) ListProjectPreferences() ([]models.ProjectPreference, error) {
+	var prefs []models.ProjectPreference
+	err := db.Order("path ASC").Find(&prefs).Error
+	return prefs, err
+}
+
+// DeleteProjectPreference removes the install preference for a project path.
+func (db *DB) DeleteProjectPreference(path string) error {
+	return db.Delete(&models.ProjectPreference{}, "path = ?", path).Error
+}
@@ -0,0 +1,43 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/asteroid-belt/skulto/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectPreferences_SetGetListDelete(t *testing.T) {
+	db := testDB(t)
+
+	missing, err := db.GetProjectPreference("/work/api")
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+
+	pref := &models.ProjectPreference{Path: "/work/api", Scope: "project"}
+	pref.SetPlatforms([]string{"claude", "cursor"})
+	require.NoError(t, db.SetProjectPreference(pref))
+
+	// Replacing keeps one row per path
+	updated := &models.ProjectPreference{Path: "/work/api", Scope: "global"}
+	updated.SetPlatforms([]string{"codex"})
+	require.NoError(t, db.SetProjectPreference(updated))
+	require.NoError(t, db.SetProjectPreference(&models.ProjectPreference{Path: "/work/web", Scope: "project"}))
+
+	got, err := db.GetProjectPreference("/work/api")
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, []string{"codex"}, got.GetPlatforms())
+	assert.Equal(t, "global", got.Scope)
+
+	all, err := db.ListProjectPreferences()
+	require.NoError(t, err)
+	require.Len(t, all, 2)
+	assert.Equal(t, "/work/api", all[0].Path)
+
+	require.NoError(t, db.DeleteProjectPreference("/work/api"))
+	got, err = db.GetProjectPreference("/work/api")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
@@ -7,6 +7,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/asteroid-belt/skulto/internal/config"
 	"github.com/asteroid-belt/skulto/internal/db"
@@ -178,6 +179,45 @@ func (s *IngestionService) getDestDir(scope string) string {
 	return filepath.Join(cwd, ".skulto", "skills")
 }
 
+// CopySkill copies a skill directory to dest, replacing any previous copy.
+// A symlinked src is resolved first so the files themselves are copied.
+// A src that already is dest, or lives inside it, is left untouched.
+func CopySkill(src, dest string) error {
+	resolved, err := filepath.EvalSymlinks(src)
+	if err != nil {
+		return err
+	}
+	if within(resolved, dest) {
+		return nil
+	}
+	if err := os.RemoveAll(dest); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		return err
+	}
+	return copyDir(resolved, dest)
+}
+
+// within reports whether path is dir or inside it. dest's parent is
+// resolved like src so a symlinked temp or home directory still compares
+// equal; dest itself is not, since replacing a symlink at dest is a copy.
+func within(path, dir string) bool {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return false
+	}
+	dir, err = filepath.Abs(dir)
+	if err != nil {
+		return false
+	}
+	if parent, err := filepath.EvalSymlinks(filepath.Dir(dir)); err == nil {
+		dir = filepath.Join(parent, filepath.Base(dir))
+	}
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
 // copyDir recursively copies a directory.
 func copyDir(src, dst string) error {
 	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
@@ -223,8 +263,19 @@ func copyFile(src, dst string) error {
 	return err
 }
 
-// parseAndCreateSkillRecord parses the skill.md file and creates database records.
-func (s *IngestionService) parseAndCreateSkillRecord(destPath string, discoveredSkill *models.DiscoveredSkill, opts *IngestOptions) (*models.Skill, error) {
+// RegisterLocalSkill records the skill in dir as a local skill without
+// copying or moving it, so installs symlink to dir itself. Used for skills
+// vendored into a project. Re-registering updates the existing record.
+func (s *IngestionService) RegisterLocalSkill(dir, name string) (*models.Skill, error) {
+	if err := s.ValidateSkill(dir); err != nil {
+		return nil, err
+	}
+	return s.upsertLocalSkill(dir, name, false)
+}
+
+// upsertLocalSkill parses skill.md in destPath, scans it and saves it as a
+// local skill.
+func (s *IngestionService) upsertLocalSkill(destPath, name string, installed bool) (*models.Skill, error) {
 	// Read skill.md content (try both lowercase and uppercase)
 	skillMdPath := filepath.Join(destPath, "skill.md")
 	content, err := os.ReadFile(skillMdPath)
@@ -242,7 +293,7 @@ func (s *IngestionService) parseAndCreateSkillRecord(destPath string, discovered
 	// This ensures skills aren't duplicated after DB reset
 	parser := scraper.NewSkillParser()
 	skillFile := &scraper.SkillFile{
-		ID:   "local-" + discoveredSkill.Name,
+		ID:   "local-" + name,
 		Path: destPath,
 		// Don't set RepoName - local skills have no source
 	}
@@ -253,7 +304,7 @@ func (s *IngestionService) parseAndCreateSkillRecord(destPath string, discovered
 
 	// Set local skill flags and clear SourceID (local skills have no source)
 	parsedSkill.IsLocal = true
-	parsedSkill.IsInstalled = true
+	parsedSkill.IsInstalled = installed
 	parsedSkill.FilePath = destPath
 	parsedSkill.SourceID = nil // Local skills have no source
 
@@ -268,6 +319,15 @@ func (s *IngestionService) parseAndCreateSkillRecord(destPath string, discovered
 	if err := s.db.UpsertSkillWithTags(parsedSkill, tags); err != nil {
 		return nil, fmt.Errorf("failed to save skill: %w", err)
 	}
+	return parsedSkill, nil
+}
+
+// parseAndCreateSkillRecord parses the skill.md file and creates database records.
+func (s *IngestionService) parseAndCreateSkillRecord(destPath string, discoveredSkill *models.DiscoveredSkill, opts *IngestOptions) (*models.Skill, error) {
+	parsedSkill, err := s.upsertLocalSkill(destPath, discoveredSkill.Name, true)
+	if err != nil {
+		return nil, err
+	}
 
 	// Create SkillInstallation record
 	basePath := destPath
@@ -441,3 +441,68 @@ func TestLocalSkillIDFormat(t *testing.T) {
 	expectedID := "local-" + name
 	assert.Equal(t, "local-my-skill", expectedID, "ID should use local- prefix with name")
 }
+
+func TestIngestionService_RegisterLocalSkill(t *testing.T) {
+	tmpDir := t.TempDir()
+	skillDir := filepath.Join(tmpDir, "project", ".skulto", "vendor", "team-skill")
+	require.NoError(t, os.MkdirAll(skillDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(skillDir, "SKILL.md"), []byte("---\nname: team-skill\ndescription: Shared team conventions\n---\n\n# Team Skill\n"), 0644))
+
+	database := testDB(t)
+	svc := &IngestionService{db: database}
+
+	skill, err := svc.RegisterLocalSkill(skillDir, "team-skill")
+	require.NoError(t, err)
+	assert.True(t, skill.IsLocal)
+	assert.Equal(t, skillDir, skill.FilePath, "registered skills stay where they are")
+	assert.Nil(t, skill.SourceID)
+
+	// The directory is left in place and not replaced by a symlink
+	info, err := os.Lstat(skillDir)
+	require.NoError(t, err)
+	assert.True(t, info.IsDir())
+
+	// Registering again updates the same record
+	again, err := svc.RegisterLocalSkill(skillDir, "team-skill")
+	require.NoError(t, err)
+	assert.Equal(t, skill.ID, again.ID)
+
+	_, err = svc.RegisterLocalSkill(filepath.Join(tmpDir, "missing"), "missing")
+	assert.Error(t, err)
+}
+
+func TestCopySkill_ReplacesDestination(t *testing.T) {
+	tmpDir := t.TempDir()
+	src := filepath.Join(tmpDir, "src")
+	require.NoError(t, os.MkdirAll(filepath.Join(src, "refs"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(src, "SKILL.md"), []byte("# Skill\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(src, "refs", "notes.md"), []byte("notes\n"), 0644))
+
+	link := filepath.Join(tmpDir, "link")
+	require.NoError(t, os.Symlink(src, link))
+
+	dest := filepath.Join(tmpDir, "vendor", "skill")
+	require.NoError(t, os.MkdirAll(dest, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dest, "stale.md"), []byte("old\n"), 0644))
+
+	require.NoError(t, CopySkill(link, dest))
+	assert.FileExists(t, filepath.Join(dest, "SKILL.md"))
+	assert.FileExists(t, filepath.Join(dest, "refs", "notes.md"))
+	assert.NoFileExists(t, filepath.Join(dest, "stale.md"))
+}
+
+func TestCopySkill_SourceIsDestination(t *testing.T) {
+	tmpDir := t.TempDir()
+	dest := filepath.Join(tmpDir, "vendor", "skill")
+	require.NoError(t, os.MkdirAll(filepath.Join(dest, "refs"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dest, "SKILL.md"), []byte("# Skill\n"), 0644))
+
+	// A symlink resolving to dest, and a src nested inside dest
+	link := filepath.Join(tmpDir, "link")
+	require.NoError(t, os.Symlink(dest, link))
+
+	for _, src := range []string{dest, link, filepath.Join(dest, "refs")} {
+		require.NoError(t, CopySkill(src, dest))
+		assert.FileExists(t, filepath.Join(dest, "SKILL.md"), "src %s", src)
+	}
+}
@@ -0,0 +1,114 @@
+package installer
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/asteroid-belt/skulto/internal/models"
+	"github.com/asteroid-belt/skulto/internal/scraper"
+	"github.com/asteroid-belt/skulto/pkg/version"
+)
+
+var (
+	// ErrSkultoTooOld is returned when a skill's min_version is newer than this build.
+	ErrSkultoTooOld = errors.New("skill requires a newer version of skulto")
+
+	// ErrNoSupportedPlatforms is returned when none of the chosen platforms
+	// are in a skill's declared platforms.
+	ErrNoSupportedPlatforms = errors.New("skill does not support any of the selected platforms")
+)
+
+// skultoVersion returns the running version; overridden in tests.
+var skultoVersion = version.Short
+
+// Compatibility describes a skill's declared platforms and min_version
+// and how they compare to this build.
+type Compatibility struct {
+	Platforms  []Platform `json:"platforms,omitempty"`   // Declared platforms; empty means all
+	Unknown    []string   `json:"unknown,omitempty"`     // Declared names that match no known platform
+	MinVersion string     `json:"min_version,omitempty"` // Declared minimum skulto version
+	VersionOK  bool       `json:"version_ok"`            // False if min_version is newer than this build
+}
+
+// CheckCompatibility reads platforms and min_version from the skill's frontmatter.
+// Development builds satisfy any min_version, as do unparseable constraints.
+func CheckCompatibility(skill *models.Skill) Compatibility {
+	meta := scraper.SkillMeta(skill)
+	c := Compatibility{MinVersion: meta.MinVersion, VersionOK: true}
+
+	for _, name := range meta.Platforms {
+		p := PlatformFromStringOrAlias(strings.ToLower(strings.TrimSpace(name)))
+		if p == "" {
+			c.Unknown = append(c.Unknown, name)
+			continue
+		}
+		c.Platforms = append(c.Platforms, p)
+	}
+
+	if c.MinVersion != "" {
+		current, err := semver.NewVersion(skultoVersion())
+		required, minErr := semver.NewVersion(c.MinVersion)
+		if err == nil && minErr == nil && current.LessThan(required) {
+			c.VersionOK = false
+		}
+	}
+
+	return c
+}
+
+// Supports reports whether the skill declares support for p.
+// Skills without a platforms list support every platform.
+func (c Compatibility) Supports(p Platform) bool {
+	if len(c.Platforms) == 0 && len(c.Unknown) == 0 {
+		return true
+	}
+	for _, declared := range c.Platforms {
+		if declared == p {
+			return true
+		}
+	}
+	return false
+}
+
+// PlatformNames returns the declared platforms as strings, including unknown names.
+func (c Compatibility) PlatformNames() []string {
+	names := make([]string, 0, len(c.Platforms)+len(c.Unknown))
+	for _, p := range c.Platforms {
+		names = append(names, string(p))
+	}
+	return append(names, c.Unknown...)
+}
+
+// VersionError returns an ErrSkultoTooOld error if this build is older than min_version.
+func (c Compatibility) VersionError(slug string) error {
+	if c.VersionOK {
+		return nil
+	}
+	return fmt.Errorf("%w: %s needs skulto >= %s (running %s)", ErrSkultoTooOld, slug, c.MinVersion, skultoVersion())
+}
+
+// FilterCompatibleLocations drops locations whose platform the skill does not
+// declare and refuses skills whose min_version is newer than this build.
+// Skipped locations are returned so callers can warn about them; an error is
+// returned if nothing is left to install.
+func FilterCompatibleLocations(skill *models.Skill, locations []InstallLocation) (kept, skipped []InstallLocation, err error) {
+	compat := CheckCompatibility(skill)
+	if err := compat.VersionError(skill.Slug); err != nil {
+		return nil, nil, err
+	}
+
+	for _, loc := range locations {
+		if compat.Supports(loc.Platform) {
+			kept = append(kept, loc)
+		} else {
+			skipped = append(skipped, loc)
+		}
+	}
+
+	if len(kept) == 0 && len(skipped) > 0 {
+		return nil, skipped, fmt.Errorf("%w: %s supports %s", ErrNoSupportedPlatforms, skill.Slug, strings.Join(compat.PlatformNames(), ", "))
+	}
+	return kept, skipped, nil
+}
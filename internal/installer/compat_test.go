@@ -0,0 +1,100 @@
+package installer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/asteroid-belt/skulto/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func withSkultoVersion(t *testing.T, v string) {
+	t.Helper()
+	orig := skultoVersion
+	skultoVersion = func() string { return v }
+	t.Cleanup(func() { skultoVersion = orig })
+}
+
+func compatSkill(frontmatter string) *models.Skill {
+	return &models.Skill{Slug: "compat", Content: "---\nname: compat\n" + frontmatter + "---\n# Compat\n"}
+}
+
+func TestCheckCompatibility(t *testing.T) {
+	withSkultoVersion(t, "1.4.0")
+
+	c := CheckCompatibility(compatSkill("platforms: [claude, Cursor, vscode]\nmin_version: 1.2.0\n"))
+	assert.Equal(t, []Platform{PlatformClaude, PlatformCursor}, c.Platforms)
+	assert.Equal(t, []string{"vscode"}, c.Unknown)
+	assert.True(t, c.VersionOK)
+	assert.True(t, c.Supports(PlatformClaude))
+	assert.False(t, c.Supports(PlatformWindsurf))
+
+	c = CheckCompatibility(compatSkill("min_version: 2.0.0\n"))
+	assert.False(t, c.VersionOK)
+	assert.ErrorIs(t, c.VersionError("compat"), ErrSkultoTooOld)
+	assert.True(t, c.Supports(PlatformWindsurf), "no platforms means all")
+
+	// Development builds accept any min_version
+	withSkultoVersion(t, "dev")
+	assert.True(t, CheckCompatibility(compatSkill("min_version: 9.0.0\n")).VersionOK)
+}
+
+func TestFilterCompatibleLocations(t *testing.T) {
+	withSkultoVersion(t, "1.0.0")
+	locations := []InstallLocation{
+		{Platform: PlatformClaude, Scope: ScopeGlobal, BasePath: "/home"},
+		{Platform: PlatformCursor, Scope: ScopeGlobal, BasePath: "/home"},
+	}
+
+	kept, skipped, err := FilterCompatibleLocations(compatSkill("platforms: [claude]\n"), locations)
+	require.NoError(t, err)
+	assert.Equal(t, locations[:1], kept)
+	assert.Equal(t, locations[1:], skipped)
+
+	_, _, err = FilterCompatibleLocations(compatSkill("platforms: [windsurf]\n"), locations)
+	assert.ErrorIs(t, err, ErrNoSupportedPlatforms)
+
+	_, _, err = FilterCompatibleLocations(compatSkill("min_version: 1.1.0\n"), locations)
+	assert.ErrorIs(t, err, ErrSkultoTooOld)
+}
+
+func TestInstall_HonorsCompatibility(t *testing.T) {
+	withSkultoVersion(t, "1.0.0")
+	service, _, _ := setupPlanService(t)
+	ctx := context.Background()
+
+	sourceID := "owner/repo"
+	for slug, fm := range map[string]string{
+		"claude-only": "platforms: [claude]\n",
+		"too-new":     "min_version: 3.0.0\n",
+	} {
+		require.NoError(t, service.DB().CreateSkill(&models.Skill{
+			ID:       "skill-" + slug,
+			Slug:     slug,
+			Title:    slug,
+			Content:  "---\nname: " + slug + "\n" + fm + "---\n# " + slug + "\n",
+			FilePath: "skills/" + slug + "/SKILL.md",
+			SourceID: &sourceID,
+		}))
+		setupTestSkillDir(t, service.cfg, "owner", "repo", slug)
+	}
+
+	opts := InstallOptions{Platforms: []string{"claude", "cursor"}, Scopes: []InstallScope{ScopeGlobal}}
+	result, err := service.Install(ctx, "claude-only", opts)
+	require.NoError(t, err)
+	require.Len(t, result.Locations, 1)
+	assert.Equal(t, PlatformClaude, result.Locations[0].Platform)
+	require.Len(t, result.Skipped, 1)
+	assert.Equal(t, PlatformCursor, result.Skipped[0].Platform)
+
+	_, err = service.Install(ctx, "too-new", opts)
+	assert.ErrorIs(t, err, ErrSkultoTooOld)
+	has, err := service.DB().HasInstallations("skill-too-new")
+	require.NoError(t, err)
+	assert.False(t, has)
+
+	plan, err := service.PlanInstall(ctx, "claude-only", opts)
+	require.NoError(t, err)
+	assert.Len(t, plan.Warnings, 1)
+}
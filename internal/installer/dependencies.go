@@ -0,0 +1,249 @@
+package installer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/asteroid-belt/skulto/internal/models"
+	"github.com/asteroid-belt/skulto/internal/scraper"
+)
+
+var (
+	// ErrDependencyCycle is returned when skills require each other in a loop.
+	ErrDependencyCycle = errors.New("dependency cycle")
+
+	// ErrMissingDependency is returned when a required skill is not indexed
+	// or does not satisfy the declared source or version.
+	ErrMissingDependency = errors.New("missing dependency")
+
+	// ErrRequiredByInstalled is returned when uninstalling a skill that
+	// another installed skill requires.
+	ErrRequiredByInstalled = errors.New("skill is required by installed skills")
+)
+
+// DependencyCycleError reports the chain of slugs that forms a cycle.
+type DependencyCycleError struct {
+	Path []string // e.g. [a b c a]
+}
+
+func (e *DependencyCycleError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrDependencyCycle, strings.Join(e.Path, " -> "))
+}
+
+func (e *DependencyCycleError) Unwrap() error { return ErrDependencyCycle }
+
+// MissingDependencyError reports a requirement that could not be satisfied.
+type MissingDependencyError struct {
+	Skill       string                  // Slug of the skill declaring the requirement
+	Requirement models.SkillRequirement // The unsatisfied requirement
+	Reason      string                  // Why it could not be satisfied
+}
+
+func (e *MissingDependencyError) Error() string {
+	return fmt.Sprintf("%s: %s requires %s (%s)", ErrMissingDependency, e.Skill, e.Requirement, e.Reason)
+}
+
+func (e *MissingDependencyError) Unwrap() error { return ErrMissingDependency }
+
+// RequiredByError reports the installed skills that depend on a skill being uninstalled.
+type RequiredByError struct {
+	Slug       string
+	Dependents []string
+}
+
+func (e *RequiredByError) Error() string {
+	return fmt.Sprintf("%s is required by %s (use force to uninstall anyway)", e.Slug, strings.Join(e.Dependents, ", "))
+}
+
+func (e *RequiredByError) Unwrap() error { return ErrRequiredByInstalled }
+
+// ResolveDependencies returns the skills transitively required by slug, in
+// install order (dependencies before the skills that need them). The skill
+// itself is not included. Cycles and unsatisfiable requirements are errors.
+func (s *InstallService) ResolveDependencies(slug string) ([]*models.Skill, error) {
+	skill, err := s.db.GetSkillBySlug(slug)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up skill: %w", err)
+	}
+	if skill == nil {
+		return nil, fmt.Errorf("skill not found: %s", slug)
+	}
+
+	r := &dependencyResolver{
+		service: s,
+		visited: make(map[string]bool),
+	}
+	if err := r.visit(skill, nil); err != nil {
+		return nil, err
+	}
+
+	// Drop the root, which is always last in post-order
+	return r.order[:len(r.order)-1], nil
+}
+
+// InstallDependenciesTo installs the skills slug transitively requires at the
+// given locations and returns their slugs in install order. Used by callers
+// that pick exact locations rather than platform/scope options (the TUI).
+func (s *InstallService) InstallDependenciesTo(ctx context.Context, slug string, locations []InstallLocation) ([]string, error) {
+	deps, err := s.ResolveDependencies(slug)
+	if err != nil {
+		return nil, err
+	}
+	var installed []string
+	for _, dep := range deps {
+		if _, err := s.installSkill(ctx, dep, locations, ""); err != nil {
+			return installed, fmt.Errorf("failed to install dependency %s: %w", dep.Slug, err)
+		}
+		installed = append(installed, dep.Slug)
+	}
+	return installed, nil
+}
+
+// dependencyResolver walks requires: declarations depth-first.
+type dependencyResolver struct {
+	service *InstallService
+	visited map[string]bool // Slugs fully resolved
+	order   []*models.Skill // Post-order: dependencies first
+}
+
+func (r *dependencyResolver) visit(skill *models.Skill, stack []string) error {
+	for i, slug := range stack {
+		if slug == skill.Slug {
+			path := append(append([]string{}, stack[i:]...), skill.Slug)
+			return &DependencyCycleError{Path: path}
+		}
+	}
+	if r.visited[skill.Slug] {
+		return nil
+	}
+	stack = append(stack, skill.Slug)
+
+	for _, req := range scraper.SkillMeta(skill).Requires {
+		dep, err := r.service.findRequirement(skill.Slug, req)
+		if err != nil {
+			return err
+		}
+		if err := r.visit(dep, stack); err != nil {
+			return err
+		}
+	}
+
+	r.visited[skill.Slug] = true
+	r.order = append(r.order, skill)
+	return nil
+}
+
+// findRequirement looks up the skill satisfying req, checking source and version.
+func (s *InstallService) findRequirement(owner string, req models.SkillRequirement) (*models.Skill, error) {
+	var dep *models.Skill
+	var err error
+	if req.Source != "" {
+		dep, err = s.db.GetSkillBySlugAndSource(req.Slug, req.Source)
+	} else {
+		dep, err = s.db.GetSkillBySlug(req.Slug)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up dependency %s: %w", req.Slug, err)
+	}
+	if dep == nil {
+		reason := "not found"
+		if req.Source != "" {
+			reason = "not found in " + req.Source + "; add the source first"
+		}
+		return nil, &MissingDependencyError{Skill: owner, Requirement: req, Reason: reason}
+	}
+
+	if req.Version != "" {
+		constraint, err := semver.NewConstraint(req.Version)
+		if err != nil {
+			return nil, &MissingDependencyError{Skill: owner, Requirement: req, Reason: "invalid version constraint"}
+		}
+		version, err := semver.NewVersion(dep.Version)
+		if err != nil {
+			return nil, &MissingDependencyError{Skill: owner, Requirement: req, Reason: "available skill has no version"}
+		}
+		if !constraint.Check(version) {
+			return nil, &MissingDependencyError{Skill: owner, Requirement: req, Reason: "found version " + dep.Version}
+		}
+	}
+
+	return dep, nil
+}
+
+// Dependents returns the slugs of installed skills that require slug at any
+// of the given locations. If locations is empty, any installation counts.
+func (s *InstallService) Dependents(ctx context.Context, slug string, locations []InstallLocation) ([]string, error) {
+	skill, err := s.db.GetSkillBySlug(slug)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up skill: %w", err)
+	}
+	if skill == nil {
+		return nil, nil
+	}
+
+	installations, err := s.db.GetAllInstallations()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get installations: %w", err)
+	}
+
+	seen := make(map[string]bool)
+	var dependents []string
+	for _, inst := range installations {
+		if inst.SkillID == skill.ID || seen[inst.SkillID] {
+			continue
+		}
+		if len(locations) > 0 && !installationMatches(inst.Platform, inst.Scope, inst.BasePath, locations) {
+			continue
+		}
+		other, err := s.db.GetSkill(inst.SkillID)
+		if err != nil || other == nil {
+			continue
+		}
+		if requiresSkill(other, skill) {
+			seen[inst.SkillID] = true
+			dependents = append(dependents, other.Slug)
+		}
+	}
+
+	sort.Strings(dependents)
+	return dependents, nil
+}
+
+// checkDependents returns a RequiredByError if installed skills need slug.
+func (s *InstallService) checkDependents(ctx context.Context, slug string, locations []InstallLocation) error {
+	dependents, err := s.Dependents(ctx, slug, locations)
+	if err != nil {
+		return err
+	}
+	if len(dependents) > 0 {
+		return &RequiredByError{Slug: slug, Dependents: dependents}
+	}
+	return nil
+}
+
+// requiresSkill reports whether other declares a requirement satisfied by skill.
+func requiresSkill(other, skill *models.Skill) bool {
+	for _, req := range scraper.SkillMeta(other).Requires {
+		if req.Slug != skill.Slug {
+			continue
+		}
+		if req.Source == "" || (skill.Source != nil && skill.Source.FullName == req.Source) {
+			return true
+		}
+	}
+	return false
+}
+
+// installationMatches reports whether an installation row is one of locations.
+func installationMatches(platform, scope, basePath string, locations []InstallLocation) bool {
+	for _, loc := range locations {
+		if string(loc.Platform) == platform && string(loc.Scope) == scope && loc.BasePath == basePath {
+			return true
+		}
+	}
+	return false
+}
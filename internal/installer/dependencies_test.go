@@ -0,0 +1,161 @@
+package installer
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/asteroid-belt/skulto/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// addDependencySkill creates a skill in owner/repo whose frontmatter declares requires.
+func addDependencySkill(t *testing.T, service *InstallService, slug, version string, requires ...string) {
+	t.Helper()
+	content := "---\nname: " + slug + "\n"
+	if version != "" {
+		content += "version: " + version + "\n"
+	}
+	if len(requires) > 0 {
+		content += "requires:\n"
+		for _, r := range requires {
+			content += "  - " + r + "\n"
+		}
+	}
+	content += "---\n\n# " + slug + "\n"
+
+	sourceID := "owner/repo"
+	require.NoError(t, service.DB().CreateSkill(&models.Skill{
+		ID:       "skill-" + slug,
+		Slug:     slug,
+		Title:    slug,
+		Content:  content,
+		Version:  version,
+		FilePath: "skills/" + slug + "/SKILL.md",
+		SourceID: &sourceID,
+	}))
+	setupTestSkillDir(t, service.cfg, "owner", "repo", slug)
+}
+
+func TestResolveDependencies_Order(t *testing.T) {
+	service, _, _ := setupPlanService(t)
+	addDependencySkill(t, service, "changelog", "1.0.0")
+	addDependencySkill(t, service, "semver", "2.1.0", "changelog")
+	addDependencySkill(t, service, "release", "", "semver", "changelog")
+
+	deps, err := service.ResolveDependencies("release")
+	require.NoError(t, err)
+	var slugs []string
+	for _, d := range deps {
+		slugs = append(slugs, d.Slug)
+	}
+	assert.Equal(t, []string{"changelog", "semver"}, slugs)
+}
+
+func TestResolveDependencies_CycleAndMissing(t *testing.T) {
+	service, _, _ := setupPlanService(t)
+	addDependencySkill(t, service, "a", "", "b")
+	addDependencySkill(t, service, "b", "", "a")
+	addDependencySkill(t, service, "c", "", "nope")
+	addDependencySkill(t, service, "d", "", "'{slug: a, version: \">=1.0\"}'")
+	addDependencySkill(t, service, "e", "", "'{slug: a, source: other/repo}'")
+
+	_, err := service.ResolveDependencies("a")
+	var cycle *DependencyCycleError
+	require.True(t, errors.As(err, &cycle))
+	assert.Equal(t, []string{"a", "b", "a"}, cycle.Path)
+
+	_, err = service.ResolveDependencies("c")
+	assert.ErrorIs(t, err, ErrMissingDependency)
+
+	_, err = service.ResolveDependencies("d")
+	assert.ErrorIs(t, err, ErrMissingDependency)
+
+	_, err = service.ResolveDependencies("e")
+	assert.ErrorIs(t, err, ErrMissingDependency)
+}
+
+func TestInstall_InstallsDependencies(t *testing.T) {
+	service, _, _ := setupPlanService(t)
+	ctx := context.Background()
+	addDependencySkill(t, service, "changelog", "")
+	addDependencySkill(t, service, "release", "", "changelog")
+
+	opts := InstallOptions{Platforms: []string{"claude"}, Scopes: []InstallScope{ScopeGlobal}}
+	result, err := service.Install(ctx, "release", opts)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"changelog"}, result.Dependencies)
+
+	locs, err := service.GetInstallLocations(ctx, "changelog")
+	require.NoError(t, err)
+	assert.Len(t, locs, 1)
+
+	// SkipDependencies installs only the named skill
+	addDependencySkill(t, service, "deploy", "", "plan-skill")
+	opts.SkipDependencies = true
+	result, err = service.Install(ctx, "deploy", opts)
+	require.NoError(t, err)
+	assert.Empty(t, result.Dependencies)
+	locs, err = service.GetInstallLocations(ctx, "plan-skill")
+	require.NoError(t, err)
+	assert.Empty(t, locs)
+}
+
+func TestInstallBatch_SharedDependencyOnce(t *testing.T) {
+	service, _, _ := setupPlanService(t)
+	ctx := context.Background()
+	addDependencySkill(t, service, "changelog", "")
+	addDependencySkill(t, service, "release", "", "changelog")
+	addDependencySkill(t, service, "notes", "", "changelog")
+
+	opts := InstallOptions{Platforms: []string{"claude"}, Scopes: []InstallScope{ScopeGlobal}}
+	results := service.InstallBatch(ctx, []string{"release", "notes"}, opts)
+	require.Len(t, results, 2)
+	assert.Equal(t, []string{"changelog"}, results[0].Dependencies)
+	assert.Empty(t, results[1].Dependencies)
+}
+
+func TestUninstall_RefusesRequiredSkill(t *testing.T) {
+	service, _, _ := setupPlanService(t)
+	ctx := context.Background()
+	addDependencySkill(t, service, "changelog", "")
+	addDependencySkill(t, service, "release", "", "changelog")
+
+	opts := InstallOptions{Platforms: []string{"claude"}, Scopes: []InstallScope{ScopeGlobal}}
+	_, err := service.Install(ctx, "release", opts)
+	require.NoError(t, err)
+
+	err = service.UninstallAll(ctx, "changelog")
+	var required *RequiredByError
+	require.True(t, errors.As(err, &required))
+	assert.Equal(t, []string{"release"}, required.Dependents)
+
+	locs, err := service.GetInstallLocations(ctx, "changelog")
+	require.NoError(t, err)
+	require.Len(t, locs, 1)
+	assert.ErrorIs(t, service.Uninstall(ctx, "changelog", locs), ErrRequiredByInstalled)
+
+	// Removing the dependent first, or forcing, is allowed
+	require.NoError(t, service.ForceUninstall(ctx, "changelog", nil))
+	locs, err = service.GetInstallLocations(ctx, "changelog")
+	require.NoError(t, err)
+	assert.Empty(t, locs)
+}
+
+func TestPlanInstall_IncludesDependencies(t *testing.T) {
+	service, _, _ := setupPlanService(t)
+	addDependencySkill(t, service, "changelog", "")
+	addDependencySkill(t, service, "release", "", "changelog")
+	addDependencySkill(t, service, "broken", "", "missing")
+
+	opts := InstallOptions{Platforms: []string{"claude"}, Scopes: []InstallScope{ScopeGlobal}}
+	plan, err := service.PlanInstall(context.Background(), "release", opts)
+	require.NoError(t, err)
+	require.Len(t, plan.Changes, 2)
+	assert.Equal(t, "changelog", plan.Changes[0].Slug)
+
+	plan, err = service.PlanInstall(context.Background(), "broken", opts)
+	require.NoError(t, err)
+	assert.Len(t, plan.Errors, 1)
+}
@@ -215,10 +215,13 @@ func (i *Installer) InstallTo(ctx context.Context, skill *models.Skill, source *
 	}
 
 	// Get source skill path in repository using the skill's actual FilePath
-	sourcePath := i.paths.GetSourcePath(source.Owner, source.Repo, skill.FilePath)
+	sourcePath := i.paths.GetSkillSourcePath(source, skill.FilePath)
 
 	// Verify source path exists
 	if _, err := os.Stat(sourcePath); os.IsNotExist(err) {
+		if dir := source.LocalDir(); dir != "" {
+			return fmt.Errorf("skill directory not found in local source %s: %s — run 'skulto pull' to rescan", dir, sourcePath)
+		}
 		repoDir := filepath.Join(i.cfg.BaseDir, "repositories", source.Owner, source.Repo)
 		if _, repoErr := os.Stat(repoDir); os.IsNotExist(repoErr) {
 			return fmt.Errorf("repository not cloned: %s/%s — run 'skulto pull' to sync", source.Owner, source.Repo)
@@ -829,3 +829,47 @@ func TestInstallToLocations_DoesNotDeleteDirectory(t *testing.T) {
 	_, err = os.Stat(filepath.Join(skillsDir, "existing-skill", "SKILL.md"))
 	assert.NoError(t, err, "existing skill must not be deleted")
 }
+
+func TestInstallToLocalSource(t *testing.T) {
+	tempDir := t.TempDir()
+
+	// A local checkout, not a clone in the cache
+	liveDir := filepath.Join(tempDir, "checkout")
+	skillDir := filepath.Join(liveDir, "skills", "live-skill")
+	require.NoError(t, os.MkdirAll(skillDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(skillDir, "SKILL.md"), []byte("# Live"), 0644))
+
+	projectDir := filepath.Join(tempDir, "project")
+	require.NoError(t, os.MkdirAll(projectDir, 0755))
+
+	cfg := setupTestConfig(t)
+	database := setupTestDB(t)
+
+	skill := &models.Skill{
+		ID:       "test-live-skill",
+		Slug:     "live-skill",
+		Title:    "Live Skill",
+		FilePath: "skills/live-skill/SKILL.md",
+	}
+	source := &models.Source{
+		Owner:    "local/0123abcd",
+		Repo:     "checkout",
+		Host:     models.SourceHostLocal,
+		CloneURL: "file://" + filepath.ToSlash(liveDir),
+	}
+	require.NoError(t, database.CreateSkill(skill))
+
+	inst := New(database, cfg)
+	loc := InstallLocation{Platform: PlatformClaude, Scope: ScopeProject, BasePath: projectDir}
+	require.NoError(t, inst.InstallTo(context.Background(), skill, source, []InstallLocation{loc}))
+
+	target, err := os.Readlink(loc.GetSkillPath(skill.Slug))
+	require.NoError(t, err)
+	assert.Equal(t, skillDir, target, "should link into the live directory")
+
+	// Edits in the checkout are visible through the install without a pull
+	require.NoError(t, os.WriteFile(filepath.Join(skillDir, "SKILL.md"), []byte("# Edited"), 0644))
+	content, err := os.ReadFile(filepath.Join(loc.GetSkillPath(skill.Slug), "SKILL.md"))
+	require.NoError(t, err)
+	assert.Equal(t, "# Edited", string(content))
+}
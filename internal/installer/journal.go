@@ -0,0 +1,66 @@
+package installer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/asteroid-belt/skulto/internal/db"
+	"github.com/asteroid-belt/skulto/internal/journal"
+	"github.com/asteroid-belt/skulto/internal/log"
+	"github.com/asteroid-belt/skulto/internal/models"
+)
+
+// RecordUninstall snapshots a skill's installation rows at the given
+// locations, or all of them when locations is empty, so 'skulto undo' can
+// restore them.
+func RecordUninstall(rec *journal.Recorder, database *db.DB, skill *models.Skill, locations []InstallLocation) {
+	rows, err := database.GetInstallations(skill.ID)
+	if err != nil {
+		return
+	}
+	if len(locations) == 0 {
+		rec.RecordInstallations(rows)
+		return
+	}
+	var matched []models.SkillInstallation
+	for _, row := range rows {
+		for _, loc := range locations {
+			if row.Platform == string(loc.Platform) && row.Scope == string(loc.Scope) && row.BasePath == loc.BasePath {
+				matched = append(matched, row)
+				break
+			}
+		}
+	}
+	rec.RecordInstallations(matched)
+}
+
+// UninstallJournaled removes a skill like Uninstall, or ForceUninstall with
+// force, and records the removal in the undo journal. It returns the
+// journal entry, or nil if nothing was recorded. Journal failures are
+// logged and never fail the uninstall.
+func (s *InstallService) UninstallJournaled(ctx context.Context, slug string, locations []InstallLocation, force bool) (*models.JournalEntry, error) {
+	summary := fmt.Sprintf("uninstall %s from %d location(s)", slug, len(locations))
+	if len(locations) == 0 {
+		summary = fmt.Sprintf("uninstall %s", slug)
+	}
+	rec := journal.Open(s.cfg, s.db).Begin("uninstall", summary)
+	if skill, _ := s.db.GetSkillBySlug(slug); skill != nil {
+		RecordUninstall(rec, s.db, skill, locations)
+	}
+
+	var err error
+	if force {
+		err = s.ForceUninstall(ctx, slug, locations)
+	} else {
+		err = s.Uninstall(ctx, slug, locations)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := rec.Commit()
+	if err != nil {
+		log.Printf("skulto: failed to record undo journal: %v\n", err)
+	}
+	return entry, nil
+}
@@ -0,0 +1,45 @@
+package installer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/asteroid-belt/skulto/internal/journal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUninstallJournaled(t *testing.T) {
+	service, skill, _ := setupPlanService(t)
+	ctx := context.Background()
+
+	opts := InstallOptions{Platforms: []string{"claude", "cursor"}, Scopes: []InstallScope{ScopeGlobal}}
+	_, err := service.Install(ctx, skill.Slug, opts)
+	require.NoError(t, err)
+	locations, err := service.GetInstallLocations(ctx, skill.Slug)
+	require.NoError(t, err)
+	require.Len(t, locations, 2)
+
+	entry, err := service.UninstallJournaled(ctx, skill.Slug, locations[:1], false)
+	require.NoError(t, err)
+	require.NotNil(t, entry)
+	assert.Equal(t, "uninstall", entry.Command)
+	remaining, err := service.GetInstallLocations(ctx, skill.Slug)
+	require.NoError(t, err)
+	assert.Len(t, remaining, 1)
+
+	// Without locations, a forced uninstall records every installation
+	entry, err = service.UninstallJournaled(ctx, skill.Slug, nil, true)
+	require.NoError(t, err)
+	require.NotNil(t, entry)
+	remaining, err = service.GetInstallLocations(ctx, skill.Slug)
+	require.NoError(t, err)
+	assert.Empty(t, remaining)
+
+	// Both can be undone
+	_, err = journal.Open(service.cfg, service.DB()).Undo(2)
+	require.NoError(t, err)
+	remaining, err = service.GetInstallLocations(ctx, skill.Slug)
+	require.NoError(t, err)
+	assert.Len(t, remaining, 2)
+}
@@ -4,6 +4,7 @@ import (
 	"path/filepath"
 
 	"github.com/asteroid-belt/skulto/internal/config"
+	"github.com/asteroid-belt/skulto/internal/models"
 )
 
 // PathResolver resolves paths for skill installation.
@@ -40,6 +41,15 @@ func (pr *PathResolver) GetSourcePath(owner, repo, skillFilePath string) string
 	)
 }
 
+// GetSkillSourcePath returns the directory a skill from source is installed
+// from: the live directory for local sources, the clone otherwise.
+func (pr *PathResolver) GetSkillSourcePath(source *models.Source, skillFilePath string) string {
+	if dir := source.LocalDir(); dir != "" {
+		return filepath.Join(filepath.FromSlash(dir), filepath.Dir(skillFilePath))
+	}
+	return pr.GetSourcePath(source.Owner, source.Repo, skillFilePath)
+}
+
 // GetRepositoriesDir returns the base repositories directory.
 func (pr *PathResolver) GetRepositoriesDir() string {
 	return filepath.Join(pr.cfg.BaseDir, "repositories")
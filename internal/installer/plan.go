@@ -0,0 +1,348 @@
+package installer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/asteroid-belt/skulto/internal/models"
+	"github.com/asteroid-belt/skulto/internal/security"
+)
+
+// PlanAction describes what a planned change would do to a symlink path.
+type PlanAction string
+
+const (
+	// PlanCreate creates a new symlink where nothing exists yet.
+	PlanCreate PlanAction = "create"
+	// PlanReplace replaces an existing symlink that points somewhere else.
+	PlanReplace PlanAction = "replace"
+	// PlanUnchanged leaves an existing symlink that already points at the skill.
+	PlanUnchanged PlanAction = "unchanged"
+	// PlanRemove removes an installed symlink.
+	PlanRemove PlanAction = "remove"
+	// PlanConflict means a non-empty directory occupies the target path.
+	PlanConflict PlanAction = "conflict"
+)
+
+// PlannedChange is a single filesystem change a command would make.
+type PlannedChange struct {
+	Slug     string     `json:"slug"`
+	Platform string     `json:"platform"`
+	Scope    string     `json:"scope"`
+	Path     string     `json:"path"`             // Symlink path in the platform skills directory
+	Target   string     `json:"target,omitempty"` // Source directory the symlink points to
+	Action   PlanAction `json:"action"`
+}
+
+// BlockedSkill is a skill that would not be installed without confirmation.
+type BlockedSkill struct {
+	Slug          string             `json:"slug"`
+	ThreatLevel   models.ThreatLevel `json:"threat_level"`
+	ThreatSummary string             `json:"threat_summary"`
+}
+
+// PlanDBChanges counts database rows a command would touch.
+type PlanDBChanges struct {
+	InstallationsAdded   int `json:"installations_added"`
+	InstallationsRemoved int `json:"installations_removed"`
+	SkillsDeleted        int `json:"skills_deleted"`
+	SourcesDeleted       int `json:"sources_deleted"`
+}
+
+// Plan is the side-effect-free description of what a command would do.
+// It is produced by the Plan* methods and rendered by --dry-run callers.
+type Plan struct {
+	Command       string          `json:"command"`
+	Changes       []PlannedChange `json:"changes"`
+	Clones        []string        `json:"clones,omitempty"`   // Repositories that would be cloned
+	Pulls         []string        `json:"pulls,omitempty"`    // Repositories that would be fetched
+	Removals      []string        `json:"removals,omitempty"` // Repository clones that would be deleted
+	Blocked       []BlockedSkill  `json:"blocked,omitempty"`
+	Errors        []string        `json:"errors,omitempty"`
+	Warnings      []string        `json:"warnings,omitempty"`
+	DB            PlanDBChanges   `json:"db"`
+	PendingScans  int             `json:"pending_scans,omitempty"`
+	skillsBlocked map[string]bool
+}
+
+// NewPlan creates an empty plan for the named command.
+func NewPlan(command string) *Plan {
+	return &Plan{
+		Command:       command,
+		Changes:       []PlannedChange{},
+		skillsBlocked: make(map[string]bool),
+	}
+}
+
+// Merge appends another plan's entries to this one.
+func (p *Plan) Merge(other *Plan) {
+	if other == nil {
+		return
+	}
+	p.Changes = append(p.Changes, other.Changes...)
+	p.Clones = appendUnique(p.Clones, other.Clones...)
+	p.Pulls = appendUnique(p.Pulls, other.Pulls...)
+	p.Removals = appendUnique(p.Removals, other.Removals...)
+	for _, b := range other.Blocked {
+		p.addBlocked(b)
+	}
+	p.Errors = append(p.Errors, other.Errors...)
+	p.Warnings = append(p.Warnings, other.Warnings...)
+	p.DB.InstallationsAdded += other.DB.InstallationsAdded
+	p.DB.InstallationsRemoved += other.DB.InstallationsRemoved
+	p.DB.SkillsDeleted += other.DB.SkillsDeleted
+	p.DB.SourcesDeleted += other.DB.SourcesDeleted
+	p.PendingScans += other.PendingScans
+}
+
+// AddClone records a repository that would be cloned.
+func (p *Plan) AddClone(repo string) {
+	p.Clones = appendUnique(p.Clones, repo)
+}
+
+// AddPull records a repository that would be fetched.
+func (p *Plan) AddPull(repo string) {
+	p.Pulls = appendUnique(p.Pulls, repo)
+}
+
+// AddError records a problem that would make part of the command fail.
+func (p *Plan) AddError(format string, args ...any) {
+	p.Errors = append(p.Errors, fmt.Sprintf(format, args...))
+}
+
+// AddWarning records something the command would skip without failing.
+func (p *Plan) AddWarning(format string, args ...any) {
+	p.Warnings = append(p.Warnings, fmt.Sprintf(format, args...))
+}
+
+// CountActions returns how many changes have the given action.
+func (p *Plan) CountActions(action PlanAction) int {
+	n := 0
+	for _, c := range p.Changes {
+		if c.Action == action {
+			n++
+		}
+	}
+	return n
+}
+
+// IsEmpty reports whether the plan would change nothing.
+func (p *Plan) IsEmpty() bool {
+	for _, c := range p.Changes {
+		if c.Action != PlanUnchanged {
+			return false
+		}
+	}
+	return len(p.Clones) == 0 && len(p.Pulls) == 0 && len(p.Removals) == 0 &&
+		p.DB == (PlanDBChanges{})
+}
+
+func (p *Plan) addBlocked(b BlockedSkill) {
+	if p.skillsBlocked == nil {
+		p.skillsBlocked = make(map[string]bool)
+	}
+	if p.skillsBlocked[b.Slug] {
+		return
+	}
+	p.skillsBlocked[b.Slug] = true
+	p.Blocked = append(p.Blocked, b)
+}
+
+// appendUnique appends values not already present in the slice.
+func appendUnique(list []string, values ...string) []string {
+	for _, v := range values {
+		found := false
+		for _, existing := range list {
+			if existing == v {
+				found = true
+				break
+			}
+		}
+		if !found {
+			list = append(list, v)
+		}
+	}
+	return list
+}
+
+// PlanInstall computes what Install would do for a skill without touching the
+// filesystem or database. Security scanning runs in memory only; skills with
+// warnings are reported in Plan.Blocked since the CLI requires confirmation.
+// Required skills are planned too unless opts.SkipDependencies is set.
+func (s *InstallService) PlanInstall(ctx context.Context, slug string, opts InstallOptions) (*Plan, error) {
+	plan := NewPlan("install")
+
+	skill, err := s.db.GetSkillBySlug(slug)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up skill: %w", err)
+	}
+	if skill == nil {
+		return nil, fmt.Errorf("skill not found: %s", slug)
+	}
+
+	if !opts.SkipDependencies {
+		deps, err := s.ResolveDependencies(slug)
+		if err != nil {
+			plan.AddError("%s: %v", slug, err)
+		}
+		depOpts := opts
+		depOpts.SkipDependencies = true
+		for _, dep := range deps {
+			depPlan, err := s.PlanInstall(ctx, dep.Slug, depOpts)
+			if err != nil {
+				plan.AddError("%s: %v", dep.Slug, err)
+				continue
+			}
+			plan.Merge(depPlan)
+		}
+	}
+
+	scanResult := security.NewScanner().ScanAndClassify(skill)
+	if scanResult.HasWarning {
+		plan.addBlocked(BlockedSkill{
+			Slug:          skill.Slug,
+			ThreatLevel:   scanResult.ThreatLevel,
+			ThreatSummary: scanResult.ThreatSummary,
+		})
+	}
+
+	var sourcePath string
+	switch {
+	case skill.IsLocal:
+		sourcePath = skill.FilePath
+	case skill.SourceID != nil:
+		source, _ := s.db.GetSource(*skill.SourceID)
+		if source == nil {
+			return nil, fmt.Errorf("cannot install skill without source: %s", slug)
+		}
+		sourcePath = s.installer.paths.GetSkillSourcePath(source, skill.FilePath)
+		repoDir := filepath.Join(s.installer.paths.GetRepositoriesDir(), source.Owner, source.Repo)
+		if source.LocalDir() == "" && !exists(repoDir) {
+			plan.AddClone(source.Owner + "/" + source.Repo)
+		}
+	default:
+		return nil, fmt.Errorf("cannot install skill without source: %s", slug)
+	}
+
+	locations, skipped, err := FilterCompatibleLocations(skill, s.resolveLocations(opts))
+	if err != nil {
+		plan.AddError("%v", err)
+		return plan, nil
+	}
+	for _, loc := range skipped {
+		plan.AddWarning("%s: skipping %s (%s), not in the skill's platforms", skill.Slug, loc.Platform, loc.Scope)
+	}
+
+	for _, loc := range locations {
+		targetPath := loc.GetSkillPath(skill.Slug)
+		if targetPath == "" {
+			continue
+		}
+		change := PlannedChange{
+			Slug:     skill.Slug,
+			Platform: string(loc.Platform),
+			Scope:    string(loc.Scope),
+			Path:     targetPath,
+			Target:   sourcePath,
+			Action:   planSymlinkAction(targetPath, sourcePath),
+		}
+		plan.Changes = append(plan.Changes, change)
+
+		if change.Action == PlanConflict {
+			plan.AddError("%s: %s is a non-empty directory", skill.Slug, targetPath)
+			continue
+		}
+		if ok, _ := s.db.IsInstalledAt(skill.ID, string(loc.Platform), string(loc.Scope), loc.BasePath); !ok {
+			plan.DB.InstallationsAdded++
+		}
+	}
+
+	return plan, nil
+}
+
+// PlanUninstall computes what Uninstall would remove for the given locations.
+// If locations is empty, every recorded installation of the skill is planned.
+func (s *InstallService) PlanUninstall(ctx context.Context, slug string, locations []InstallLocation) (*Plan, error) {
+	plan := NewPlan("uninstall")
+
+	skill, err := s.db.GetSkillBySlug(slug)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up skill: %w", err)
+	}
+	if skill == nil {
+		return nil, fmt.Errorf("skill not found: %s", slug)
+	}
+
+	if len(locations) == 0 {
+		locations, err = s.installer.GetInstallLocations(skill.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.checkDependents(ctx, slug, locations); err != nil {
+		plan.AddError("%v", err)
+	}
+
+	plan.Merge(s.installer.planRemoveLocations(skill, locations))
+	return plan, nil
+}
+
+// PlanRemoveSkill computes what UninstallAll would remove for a skill.
+// Used by source removal, which uninstalls every skill from a repository.
+func (i *Installer) PlanRemoveSkill(skill *models.Skill) (*Plan, error) {
+	locations, err := i.GetInstallLocations(skill.ID)
+	if err != nil {
+		return nil, err
+	}
+	return i.planRemoveLocations(skill, locations), nil
+}
+
+// planRemoveLocations builds remove entries for each location a skill is installed at.
+func (i *Installer) planRemoveLocations(skill *models.Skill, locations []InstallLocation) *Plan {
+	plan := NewPlan("uninstall")
+	for _, loc := range locations {
+		targetPath := loc.GetSkillPath(skill.Slug)
+		if targetPath == "" {
+			continue
+		}
+		if isSymlink(targetPath) {
+			target, _ := os.Readlink(targetPath)
+			plan.Changes = append(plan.Changes, PlannedChange{
+				Slug:     skill.Slug,
+				Platform: string(loc.Platform),
+				Scope:    string(loc.Scope),
+				Path:     targetPath,
+				Target:   target,
+				Action:   PlanRemove,
+			})
+		}
+		if ok, _ := i.db.IsInstalledAt(skill.ID, string(loc.Platform), string(loc.Scope), loc.BasePath); ok {
+			plan.DB.InstallationsRemoved++
+		}
+	}
+	return plan
+}
+
+// planSymlinkAction classifies what installing sourcePath at targetPath would do.
+func planSymlinkAction(targetPath, sourcePath string) PlanAction {
+	if !exists(targetPath) {
+		return PlanCreate
+	}
+	if !isSymlink(targetPath) {
+		// Install removes the existing entry with os.Remove, which cannot
+		// delete a non-empty directory.
+		if info, err := os.Stat(targetPath); err == nil && info.IsDir() {
+			if entries, _ := os.ReadDir(targetPath); len(entries) > 0 {
+				return PlanConflict
+			}
+		}
+		return PlanReplace
+	}
+	if current, err := os.Readlink(targetPath); err == nil && current == sourcePath {
+		return PlanUnchanged
+	}
+	return PlanReplace
+}
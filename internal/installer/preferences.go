@@ -0,0 +1,45 @@
+package installer
+
+import (
+	"fmt"
+
+	"github.com/asteroid-belt/skulto/internal/models"
+)
+
+// ProjectPreferenceOptions returns install options from the platforms and
+// scope saved for the project at dir. The second result is false when no
+// preference with platforms is saved.
+func (s *InstallService) ProjectPreferenceOptions(dir string) (InstallOptions, bool) {
+	pref, err := s.db.GetProjectPreference(dir)
+	if err != nil || pref == nil || len(pref.GetPlatforms()) == 0 {
+		return InstallOptions{}, false
+	}
+	scope := InstallScope(pref.Scope)
+	if !scope.IsValid() {
+		scope = ScopeProject
+	}
+	return InstallOptions{
+		Platforms: pref.GetPlatforms(),
+		Scopes:    []InstallScope{scope},
+		Confirm:   true,
+	}, true
+}
+
+// SaveProjectPreference remembers the platforms and scope to use for
+// non-interactive installs in the project at dir.
+func (s *InstallService) SaveProjectPreference(dir string, platforms []string, scope InstallScope) error {
+	if len(platforms) == 0 {
+		return fmt.Errorf("no platforms given")
+	}
+	if !scope.IsValid() {
+		return ErrInvalidScope
+	}
+	for _, p := range platforms {
+		if !Platform(p).IsValid() {
+			return fmt.Errorf("unknown platform %q", p)
+		}
+	}
+	pref := &models.ProjectPreference{Path: dir, Scope: string(scope)}
+	pref.SetPlatforms(platforms)
+	return s.db.SetProjectPreference(pref)
+}
@@ -114,6 +114,8 @@ func (i *Installer) resolveSkultoSkill(slug, resolvedTarget string) (*models.Ski
 	// Check for repo-backed skill: target contains /repositories/{owner}/{repo}/
 	if idx := strings.Index(resolvedTarget, "/repositories/"); idx != -1 {
 		afterRepos := resolvedTarget[idx+len("/repositories/"):]
+		// Pinned revisions are checked out under repositories/.pins/{owner}/{repo}/{sha}/
+		afterRepos = strings.TrimPrefix(afterRepos, ".pins/")
 		parts := strings.SplitN(afterRepos, "/", 3)
 		if len(parts) >= 2 {
 			sourceFullName := parts[0] + "/" + parts[1]
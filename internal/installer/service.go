@@ -10,6 +10,7 @@ import (
 	"github.com/asteroid-belt/skulto/internal/config"
 	"github.com/asteroid-belt/skulto/internal/db"
 	"github.com/asteroid-belt/skulto/internal/models"
+	"github.com/asteroid-belt/skulto/internal/scraper"
 	"github.com/asteroid-belt/skulto/internal/security"
 	"github.com/asteroid-belt/skulto/internal/telemetry"
 )
@@ -19,6 +20,14 @@ type InstallOptions struct {
 	Platforms []string       // nil = all user platforms
 	Scopes    []InstallScope // nil = default to global
 	Confirm   bool           // true = skip prompts (for non-interactive mode)
+
+	// SkipDependencies installs only the named skill, ignoring its requires: list
+	SkipDependencies bool
+
+	// SourceDir links the named skill from this checkout instead of its
+	// source's clone, e.g. a revision pinned by skulto.lock. Dependencies
+	// still come from their clones.
+	SourceDir string
 }
 
 // ScanInfo captures security scan metadata for a single skill.
@@ -36,6 +45,13 @@ type InstallResult struct {
 	Locations []InstallLocation
 	Errors    []error
 	Scan      ScanInfo // Security scan metadata (callers render this)
+
+	// Dependencies lists required skills installed alongside this one
+	Dependencies []string
+
+	// Skipped lists requested locations left out because the skill's
+	// platforms frontmatter does not include their platform
+	Skipped []InstallLocation
 }
 
 // DetectedPlatform describes a platform with its installation status.
@@ -179,7 +195,15 @@ func (s *InstallService) ScanSkill(slug string) (*ScanInfo, error) {
 }
 
 // Install installs a skill to the specified locations.
+// Skills declared in its requires: frontmatter are resolved transitively and
+// installed first at the same locations, unless opts.SkipDependencies is set.
 func (s *InstallService) Install(ctx context.Context, slug string, opts InstallOptions) (*InstallResult, error) {
+	return s.installWithDependencies(ctx, slug, opts, make(map[string]bool))
+}
+
+// installWithDependencies installs slug after its dependencies. Slugs in done
+// were already installed earlier in the same batch and are skipped.
+func (s *InstallService) installWithDependencies(ctx context.Context, slug string, opts InstallOptions, done map[string]bool) (*InstallResult, error) {
 	// Look up skill
 	skill, err := s.db.GetSkillBySlug(slug)
 	if err != nil {
@@ -189,6 +213,68 @@ func (s *InstallService) Install(ctx context.Context, slug string, opts InstallO
 		return nil, fmt.Errorf("skill not found: %s", slug)
 	}
 
+	// Refuse skills that need a newer skulto before touching dependencies
+	if err := CheckCompatibility(skill).VersionError(skill.Slug); err != nil {
+		return &InstallResult{Skill: skill, Errors: []error{err}}, err
+	}
+
+	locations := s.resolveLocations(opts)
+
+	var installedDeps []string
+	if !opts.SkipDependencies {
+		deps, err := s.ResolveDependencies(slug)
+		if err != nil {
+			return &InstallResult{Skill: skill, Errors: []error{err}}, err
+		}
+		for _, dep := range deps {
+			if done[dep.Slug] {
+				continue
+			}
+			if _, err := s.installSkill(ctx, dep, locations, ""); err != nil {
+				err = fmt.Errorf("failed to install dependency %s: %w", dep.Slug, err)
+				return &InstallResult{Skill: skill, Errors: []error{err}, Dependencies: installedDeps}, err
+			}
+			done[dep.Slug] = true
+			installedDeps = append(installedDeps, dep.Slug)
+		}
+	}
+
+	result, err := s.installSkill(ctx, skill, locations, opts.SourceDir)
+	if result != nil {
+		result.Dependencies = installedDeps
+	}
+	if err == nil {
+		done[skill.Slug] = true
+	}
+	return result, err
+}
+
+// ensureClone clones source if its clone is missing, e.g. after a cache
+// budget trimmed it, so its skills can be linked. PlanInstall lists these
+// as clones.
+func (s *InstallService) ensureClone(ctx context.Context, source *models.Source) error {
+	repoDir := filepath.Join(s.installer.paths.GetRepositoriesDir(), source.Owner, source.Repo)
+	if source.LocalDir() != "" || exists(repoDir) {
+		return nil
+	}
+	repoManager := scraper.NewRepositoryManagerFromConfig(s.cfg)
+	repoManager.ConfigureSource(source)
+	if _, err := repoManager.CloneOrUpdate(ctx, source.Owner, source.Repo); err != nil {
+		return fmt.Errorf("clone %s/%s: %w", source.Owner, source.Repo, err)
+	}
+	return nil
+}
+
+// installSkill scans and installs a single skill without resolving dependencies.
+// A non-empty sourceDir is a checkout of the skill's source to link from
+// instead of its clone.
+func (s *InstallService) installSkill(ctx context.Context, skill *models.Skill, locations []InstallLocation, sourceDir string) (*InstallResult, error) {
+	// Honor platforms and min_version from frontmatter
+	locations, skipped, err := FilterCompatibleLocations(skill, locations)
+	if err != nil {
+		return &InstallResult{Skill: skill, Errors: []error{err}, Skipped: skipped}, err
+	}
+
 	// Scan skill for security threats (informational — does not block)
 	scanner := security.NewScanner()
 	scanResult := scanner.ScanAndClassify(skill)
@@ -207,6 +293,50 @@ func (s *InstallService) Install(ctx context.Context, slug string, opts InstallO
 		source, _ = s.db.GetSource(*skill.SourceID)
 	}
 
+	// Perform installation
+	if sourceDir != "" && !skill.IsLocal {
+		// Pinned revision - link the skill's directory in that checkout
+		skillDir := filepath.Join(sourceDir, filepath.Dir(filepath.FromSlash(skill.FilePath)))
+		if err := s.installer.InstallLocalSkillTo(ctx, skill, skillDir, locations); err != nil {
+			return &InstallResult{Skill: skill, Errors: []error{err}, Scan: scanInfo, Skipped: skipped}, err
+		}
+	} else if skill.IsLocal {
+		// Local skill - use InstallLocalSkillTo
+		if err := s.installer.InstallLocalSkillTo(ctx, skill, skill.FilePath, locations); err != nil {
+			return &InstallResult{Skill: skill, Errors: []error{err}, Scan: scanInfo, Skipped: skipped}, err
+		}
+	} else if source != nil {
+		// Remote skill with source - use InstallTo
+		if err := s.ensureClone(ctx, source); err != nil {
+			return &InstallResult{Skill: skill, Errors: []error{err}, Scan: scanInfo, Skipped: skipped}, err
+		}
+		if err := s.installer.InstallTo(ctx, skill, source, locations); err != nil {
+			return &InstallResult{Skill: skill, Errors: []error{err}, Scan: scanInfo, Skipped: skipped}, err
+		}
+	} else {
+		return nil, fmt.Errorf("cannot install skill without source: %s", skill.Slug)
+	}
+
+	// Get actual installed locations
+	installed, _ := s.installer.GetInstallLocations(skill.ID)
+
+	// Track telemetry
+	if s.telemetry != nil {
+		s.telemetry.TrackSkillInstalled(skill.Title, skill.Category, skill.IsLocal, len(installed))
+	}
+
+	return &InstallResult{
+		Skill:     skill,
+		Locations: installed,
+		Scan:      scanInfo,
+		Skipped:   skipped,
+	}, nil
+}
+
+// resolveLocations expands InstallOptions into concrete install locations.
+// Empty platforms fall back to the user's configured AI tools (then Claude),
+// and empty scopes fall back to global.
+func (s *InstallService) resolveLocations(opts InstallOptions) []InstallLocation {
 	// Determine platforms
 	platforms := opts.Platforms
 	if len(platforms) == 0 {
@@ -245,43 +375,18 @@ func (s *InstallService) Install(ctx context.Context, slug string, opts InstallO
 		}
 	}
 
-	// Perform installation
-	if skill.IsLocal {
-		// Local skill - use InstallLocalSkillTo
-		if err := s.installer.InstallLocalSkillTo(ctx, skill, skill.FilePath, locations); err != nil {
-			return &InstallResult{Skill: skill, Errors: []error{err}, Scan: scanInfo}, err
-		}
-	} else if source != nil {
-		// Remote skill with source - use InstallTo
-		if err := s.installer.InstallTo(ctx, skill, source, locations); err != nil {
-			return &InstallResult{Skill: skill, Errors: []error{err}, Scan: scanInfo}, err
-		}
-	} else {
-		return nil, fmt.Errorf("cannot install skill without source: %s", slug)
-	}
-
-	// Get actual installed locations
-	installed, _ := s.installer.GetInstallLocations(skill.ID)
-
-	// Track telemetry
-	if s.telemetry != nil {
-		s.telemetry.TrackSkillInstalled(skill.Title, skill.Category, skill.IsLocal, len(installed))
-	}
-
-	return &InstallResult{
-		Skill:     skill,
-		Locations: installed,
-		Scan:      scanInfo,
-	}, nil
+	return locations
 }
 
 // InstallBatch installs multiple skills with the same options.
+// Shared dependencies are installed once for the whole batch.
 // Returns results for each skill, including errors.
 func (s *InstallService) InstallBatch(ctx context.Context, slugs []string, opts InstallOptions) []InstallResult {
 	results := make([]InstallResult, len(slugs))
+	done := make(map[string]bool)
 
 	for i, slug := range slugs {
-		result, err := s.Install(ctx, slug, opts)
+		result, err := s.installWithDependencies(ctx, slug, opts, done)
 		if err != nil {
 			if result != nil {
 				// Install() returns partial result with scan info even on error
@@ -304,7 +409,28 @@ func (s *InstallService) InstallBatch(ctx context.Context, slugs []string, opts
 
 // Uninstall removes a skill from the specified locations.
 // If locations is nil or empty, does nothing (use UninstallAll for that).
+// Returns a *RequiredByError if a skill installed at one of the locations
+// requires it; use ForceUninstall to remove it anyway.
 func (s *InstallService) Uninstall(ctx context.Context, slug string, locations []InstallLocation) error {
+	if len(locations) > 0 {
+		if err := s.checkDependents(ctx, slug, locations); err != nil {
+			return err
+		}
+	}
+	return s.uninstallFrom(ctx, slug, locations)
+}
+
+// ForceUninstall removes a skill even if installed skills require it.
+// If locations is nil or empty, the skill is removed from every location.
+func (s *InstallService) ForceUninstall(ctx context.Context, slug string, locations []InstallLocation) error {
+	if len(locations) == 0 {
+		return s.uninstallAll(ctx, slug)
+	}
+	return s.uninstallFrom(ctx, slug, locations)
+}
+
+// uninstallFrom removes a skill from the given locations without dependency checks.
+func (s *InstallService) uninstallFrom(ctx context.Context, slug string, locations []InstallLocation) error {
 	// Look up skill
 	skill, err := s.db.GetSkillBySlug(slug)
 	if err != nil {
@@ -332,7 +458,16 @@ func (s *InstallService) Uninstall(ctx context.Context, slug string, locations [
 }
 
 // UninstallAll removes a skill from all installed locations.
+// Returns a *RequiredByError if another installed skill requires it.
 func (s *InstallService) UninstallAll(ctx context.Context, slug string) error {
+	if err := s.checkDependents(ctx, slug, nil); err != nil {
+		return err
+	}
+	return s.uninstallAll(ctx, slug)
+}
+
+// uninstallAll removes a skill from every location without dependency checks.
+func (s *InstallService) uninstallAll(ctx context.Context, slug string) error {
 	// Look up skill
 	skill, err := s.db.GetSkillBySlug(slug)
 	if err != nil {
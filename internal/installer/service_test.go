@@ -349,3 +349,21 @@ func TestInstallService_EnsurePathPolicy(t *testing.T) {
 	err := service.EnsurePathPolicy(ctx, cwd)
 	require.NoError(t, err)
 }
+
+func TestProjectPreferenceOptions(t *testing.T) {
+	service, _ := setupTestService(t)
+	dir := t.TempDir()
+
+	_, ok := service.ProjectPreferenceOptions(dir)
+	assert.False(t, ok)
+
+	require.NoError(t, service.SaveProjectPreference(dir, []string{"claude", "cursor"}, ScopeProject))
+	opts, ok := service.ProjectPreferenceOptions(dir)
+	require.True(t, ok)
+	assert.Equal(t, []string{"claude", "cursor"}, opts.Platforms)
+	assert.Equal(t, []InstallScope{ScopeProject}, opts.Scopes)
+
+	assert.Error(t, service.SaveProjectPreference(dir, []string{"nope"}, ScopeProject))
+	assert.Error(t, service.SaveProjectPreference(dir, []string{"claude"}, InstallScope("team")))
+	assert.Error(t, service.SaveProjectPreference(dir, nil, ScopeProject))
+}
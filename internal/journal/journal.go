@@ -0,0 +1,226 @@
+// Package journal records destructive operations (uninstall, source removal,
+// manifest writes) so they can be listed with `skulto history` and reversed
+// with `skulto undo`.
+package journal
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/asteroid-belt/skulto/internal/config"
+	"github.com/asteroid-belt/skulto/internal/db"
+	"github.com/asteroid-belt/skulto/internal/models"
+)
+
+// ErrNothingToUndo is returned when the journal has no operations left to reverse.
+var ErrNothingToUndo = errors.New("nothing to undo")
+
+// SymlinkRecord is a symlink that an operation removed.
+type SymlinkRecord struct {
+	Path   string `json:"path"`
+	Target string `json:"target"`
+}
+
+// ManifestSnapshot is the content of a manifest file before an operation wrote it.
+type ManifestSnapshot struct {
+	Path    string `json:"path"`
+	Content string `json:"content,omitempty"`
+	Existed bool   `json:"existed"`
+}
+
+// TrashRecord is a directory moved aside instead of deleted.
+type TrashRecord struct {
+	Original string `json:"original"`
+	Stored   string `json:"stored"`
+}
+
+// Operation is everything a command destroyed, in the form needed to restore it.
+type Operation struct {
+	Symlinks      []SymlinkRecord            `json:"symlinks,omitempty"`
+	Installations []models.SkillInstallation `json:"installations,omitempty"`
+	Skills        []models.Skill             `json:"skills,omitempty"`
+	Sources       []models.Source            `json:"sources,omitempty"`
+	Manifests     []ManifestSnapshot         `json:"manifests,omitempty"`
+	Trash         []TrashRecord              `json:"trash,omitempty"`
+}
+
+// IsEmpty reports whether the operation recorded nothing.
+func (o *Operation) IsEmpty() bool {
+	return len(o.Symlinks) == 0 && len(o.Installations) == 0 && len(o.Skills) == 0 &&
+		len(o.Sources) == 0 && len(o.Manifests) == 0 && len(o.Trash) == 0
+}
+
+// Journal stores operations in the database and trashed directories on disk.
+type Journal struct {
+	db         *db.DB
+	dir        string
+	maxEntries int
+	maxAge     time.Duration
+}
+
+// New creates a journal. dir holds trashed directories; maxEntries and
+// maxAgeDays bound retention (zero disables that limit).
+func New(database *db.DB, dir string, maxEntries, maxAgeDays int) *Journal {
+	return &Journal{
+		db:         database,
+		dir:        dir,
+		maxEntries: maxEntries,
+		maxAge:     time.Duration(maxAgeDays) * 24 * time.Hour,
+	}
+}
+
+// Open returns the journal in cfg's directories with its retention limits.
+func Open(cfg *config.Config, database *db.DB) *Journal {
+	return New(database, config.GetPaths(cfg).Journal, cfg.Journal.MaxEntries, cfg.Journal.MaxAgeDays)
+}
+
+// Recorder accumulates what a single command destroys.
+// Nothing is written until Commit.
+type Recorder struct {
+	j       *Journal
+	command string
+	summary string
+	op      Operation
+	started time.Time
+}
+
+// Begin starts recording an operation for the named command.
+func (j *Journal) Begin(command, summary string) *Recorder {
+	return &Recorder{j: j, command: command, summary: summary, started: time.Now()}
+}
+
+// SetSummary replaces the one-line description shown by `skulto history`.
+func (r *Recorder) SetSummary(summary string) {
+	r.summary = summary
+}
+
+// RecordInstallations snapshots installation rows and the targets of their
+// symlinks. Call before the rows and symlinks are removed.
+func (r *Recorder) RecordInstallations(rows []models.SkillInstallation) {
+	for _, row := range rows {
+		r.op.Installations = append(r.op.Installations, row)
+		if row.SymlinkPath == "" {
+			continue
+		}
+		if target, err := os.Readlink(row.SymlinkPath); err == nil {
+			r.op.Symlinks = append(r.op.Symlinks, SymlinkRecord{Path: row.SymlinkPath, Target: target})
+		}
+	}
+}
+
+// RecordSkills snapshots skill rows (with tags) before they are deleted.
+func (r *Recorder) RecordSkills(skills []models.Skill) {
+	r.op.Skills = append(r.op.Skills, skills...)
+}
+
+// RecordSource snapshots a source row before it is deleted.
+func (r *Recorder) RecordSource(source models.Source) {
+	r.op.Sources = append(r.op.Sources, source)
+}
+
+// RecordManifest snapshots a manifest file before it is written.
+func (r *Recorder) RecordManifest(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			r.op.Manifests = append(r.op.Manifests, ManifestSnapshot{Path: path})
+			return nil
+		}
+		return fmt.Errorf("snapshot manifest: %w", err)
+	}
+	r.op.Manifests = append(r.op.Manifests, ManifestSnapshot{Path: path, Content: string(data), Existed: true})
+	return nil
+}
+
+// Trash moves a directory into the journal instead of deleting it.
+// Missing paths are ignored.
+func (r *Recorder) Trash(path string) error {
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return nil
+	}
+	stored := filepath.Join(r.j.dir, "trash", strconv.FormatInt(r.started.UnixNano(), 10), strconv.Itoa(len(r.op.Trash)))
+	if err := os.MkdirAll(filepath.Dir(stored), 0755); err != nil {
+		return fmt.Errorf("create journal trash: %w", err)
+	}
+	if err := os.Rename(path, stored); err != nil {
+		return fmt.Errorf("move %s to journal: %w", path, err)
+	}
+	r.op.Trash = append(r.op.Trash, TrashRecord{Original: path, Stored: stored})
+	return nil
+}
+
+// Commit saves the operation and applies retention limits.
+// Empty operations are not recorded and return nil.
+func (r *Recorder) Commit() (*models.JournalEntry, error) {
+	if r.op.IsEmpty() {
+		return nil, nil
+	}
+	payload, err := json.Marshal(r.op)
+	if err != nil {
+		return nil, fmt.Errorf("encode journal entry: %w", err)
+	}
+	entry := &models.JournalEntry{
+		Command:   r.command,
+		Summary:   r.summary,
+		Payload:   string(payload),
+		CreatedAt: r.started,
+	}
+	if err := r.j.db.AddJournalEntry(entry); err != nil {
+		return nil, fmt.Errorf("save journal entry: %w", err)
+	}
+	if err := r.j.Prune(); err != nil {
+		return entry, err
+	}
+	return entry, nil
+}
+
+// History returns recorded operations newest first, including undone ones.
+func (j *Journal) History(limit int) ([]models.JournalEntry, error) {
+	return j.db.ListJournalEntries(limit, true)
+}
+
+// Prune deletes entries beyond the retention limits, along with their trash.
+func (j *Journal) Prune() error {
+	entries, err := j.db.ListJournalEntries(0, true)
+	if err != nil {
+		return fmt.Errorf("list journal: %w", err)
+	}
+	cutoff := time.Now().Add(-j.maxAge)
+	for i, entry := range entries {
+		expired := j.maxAge > 0 && entry.CreatedAt.Before(cutoff)
+		overflow := j.maxEntries > 0 && i >= j.maxEntries
+		if !expired && !overflow {
+			continue
+		}
+		if op, err := decode(&entry); err == nil {
+			removeTrash(op)
+		}
+		if err := j.db.DeleteJournalEntry(entry.ID); err != nil {
+			return fmt.Errorf("prune journal entry %d: %w", entry.ID, err)
+		}
+	}
+	return nil
+}
+
+// decode parses an entry's payload.
+func decode(entry *models.JournalEntry) (*Operation, error) {
+	var op Operation
+	if err := json.Unmarshal([]byte(entry.Payload), &op); err != nil {
+		return nil, fmt.Errorf("decode journal entry %d: %w", entry.ID, err)
+	}
+	return &op, nil
+}
+
+// removeTrash deletes the stored copies of trashed directories.
+func removeTrash(op *Operation) {
+	for _, t := range op.Trash {
+		_ = os.RemoveAll(t.Stored)
+		// Remove the per-operation parent once empty
+		_ = os.Remove(filepath.Dir(t.Stored))
+	}
+}
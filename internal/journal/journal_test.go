@@ -0,0 +1,148 @@
+package journal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/asteroid-belt/skulto/internal/db"
+	"github.com/asteroid-belt/skulto/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTestDB(t *testing.T) *db.DB {
+	t.Helper()
+	database, err := db.New(db.Config{Path: filepath.Join(t.TempDir(), "test.db")})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = database.Close() })
+	return database
+}
+
+func TestUndo_RestoresRemovedSource(t *testing.T) {
+	database := setupTestDB(t)
+	tmp := t.TempDir()
+	j := New(database, filepath.Join(tmp, "journal"), 10, 30)
+
+	// A source with one installed skill and a clone on disk
+	source := models.Source{ID: "owner/repo", Owner: "owner", Repo: "repo", FullName: "owner/repo"}
+	require.NoError(t, database.CreateSource(&source))
+	skill := models.Skill{ID: "s1", Slug: "one", Title: "One", SourceID: &source.ID, Tags: []models.Tag{{ID: "go", Name: "Go", Slug: "go"}}}
+	require.NoError(t, database.CreateSkill(&skill))
+
+	clone := filepath.Join(tmp, "repositories", "owner", "repo")
+	require.NoError(t, os.MkdirAll(filepath.Join(clone, "one"), 0755))
+	link := filepath.Join(tmp, "skills", "one")
+	require.NoError(t, os.MkdirAll(filepath.Dir(link), 0755))
+	require.NoError(t, os.Symlink(filepath.Join(clone, "one"), link))
+	row := models.SkillInstallation{SkillID: "s1", Platform: "claude", Scope: "global", BasePath: tmp, SymlinkPath: link}
+	require.NoError(t, database.AddInstallation(&row))
+
+	// Record, then destroy everything the way `skulto remove` does
+	skills, err := database.GetSkillsBySourceID(source.ID)
+	require.NoError(t, err)
+	rows, err := database.GetInstallations("s1")
+	require.NoError(t, err)
+
+	rec := j.Begin("remove", "remove owner/repo")
+	rec.RecordSource(source)
+	rec.RecordSkills(skills)
+	rec.RecordInstallations(rows)
+	require.NoError(t, os.Remove(link))
+	require.NoError(t, database.RemoveAllInstallations("s1"))
+	_, err = database.HardDeleteSkillsBySource(source.ID)
+	require.NoError(t, err)
+	require.NoError(t, database.HardDeleteSource(source.ID))
+	require.NoError(t, rec.Trash(clone))
+	entry, err := rec.Commit()
+	require.NoError(t, err)
+	require.NotNil(t, entry)
+	assert.NoDirExists(t, clone)
+
+	results, err := j.Undo(1)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Empty(t, results[0].Warnings)
+
+	assert.DirExists(t, clone)
+	target, err := os.Readlink(link)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(clone, "one"), target)
+	restored, err := database.GetSkill("s1")
+	require.NoError(t, err)
+	require.NotNil(t, restored)
+	assert.Len(t, restored.Tags, 1)
+	has, err := database.HasInstallations("s1")
+	require.NoError(t, err)
+	assert.True(t, has)
+	src, err := database.GetSource("owner/repo")
+	require.NoError(t, err)
+	assert.NotNil(t, src)
+
+	// Undone entries are not undone twice
+	_, err = j.Undo(1)
+	assert.ErrorIs(t, err, ErrNothingToUndo)
+}
+
+func TestUndo_RestoresManifest(t *testing.T) {
+	database := setupTestDB(t)
+	dir := t.TempDir()
+	j := New(database, filepath.Join(dir, "journal"), 10, 30)
+
+	existing := filepath.Join(dir, "skulto.json")
+	require.NoError(t, os.WriteFile(existing, []byte(`{"version":1}`), 0644))
+	created := filepath.Join(dir, "new", "skulto.json")
+
+	rec := j.Begin("save", "save")
+	require.NoError(t, rec.RecordManifest(existing))
+	require.NoError(t, rec.RecordManifest(created))
+	require.NoError(t, os.WriteFile(existing, []byte(`{"version":2}`), 0644))
+	require.NoError(t, os.MkdirAll(filepath.Dir(created), 0755))
+	require.NoError(t, os.WriteFile(created, []byte(`{}`), 0644))
+	_, err := rec.Commit()
+	require.NoError(t, err)
+
+	_, err = j.Undo(1)
+	require.NoError(t, err)
+	data, err := os.ReadFile(existing)
+	require.NoError(t, err)
+	assert.Equal(t, `{"version":1}`, string(data))
+	assert.NoFileExists(t, created)
+}
+
+func TestCommit_EmptyAndRetention(t *testing.T) {
+	database := setupTestDB(t)
+	dir := t.TempDir()
+	j := New(database, filepath.Join(dir, "journal"), 2, 30)
+
+	entry, err := j.Begin("uninstall", "nothing").Commit()
+	require.NoError(t, err)
+	assert.Nil(t, entry)
+
+	// Oldest entry beyond max entries is pruned along with its trash
+	var trashed string
+	for i := 0; i < 3; i++ {
+		src := filepath.Join(dir, "clone", string(rune('a'+i)))
+		require.NoError(t, os.MkdirAll(src, 0755))
+		rec := j.Begin("remove", "remove")
+		require.NoError(t, rec.Trash(src))
+		if i == 0 {
+			trashed = rec.op.Trash[0].Stored
+		}
+		_, err := rec.Commit()
+		require.NoError(t, err)
+	}
+	entries, err := j.History(0)
+	require.NoError(t, err)
+	assert.Len(t, entries, 2)
+	assert.NoDirExists(t, trashed)
+
+	// Expired entries are pruned by age
+	old := &models.JournalEntry{Command: "save", Payload: `{}`, CreatedAt: time.Now().AddDate(0, 0, -31)}
+	require.NoError(t, database.AddJournalEntry(old))
+	require.NoError(t, j.Prune())
+	gone, err := database.GetJournalEntry(old.ID)
+	require.NoError(t, err)
+	assert.Nil(t, gone)
+}
@@ -0,0 +1,142 @@
+package journal
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/asteroid-belt/skulto/internal/models"
+)
+
+// UndoResult describes one reversed operation.
+type UndoResult struct {
+	Entry    models.JournalEntry
+	Restored int      // Rows, symlinks, files and directories put back
+	Warnings []string // Items that could not be restored
+}
+
+// Undo reverses the last n operations that have not been undone yet,
+// newest first. Items that can no longer be restored (for example a path
+// that has since been reused) are reported as warnings rather than errors.
+func (j *Journal) Undo(n int) ([]UndoResult, error) {
+	if n <= 0 {
+		n = 1
+	}
+	entries, err := j.db.ListJournalEntries(n, false)
+	if err != nil {
+		return nil, fmt.Errorf("list journal: %w", err)
+	}
+	if len(entries) == 0 {
+		return nil, ErrNothingToUndo
+	}
+
+	results := make([]UndoResult, 0, len(entries))
+	for i := range entries {
+		result, err := j.undoEntry(&entries[i])
+		if err != nil {
+			return results, err
+		}
+		results = append(results, *result)
+	}
+	return results, nil
+}
+
+// undoEntry restores a single operation. Order matters: clones and sources
+// come back before skills, skills before installations, and rows before
+// the symlinks that point into restored clones.
+func (j *Journal) undoEntry(entry *models.JournalEntry) (*UndoResult, error) {
+	op, err := decode(entry)
+	if err != nil {
+		return nil, err
+	}
+	result := &UndoResult{Entry: *entry}
+	warn := func(format string, args ...any) {
+		result.Warnings = append(result.Warnings, fmt.Sprintf(format, args...))
+	}
+
+	for _, t := range op.Trash {
+		if _, err := os.Stat(t.Original); err == nil {
+			warn("%s already exists; kept journal copy at %s", t.Original, t.Stored)
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(t.Original), 0755); err != nil {
+			warn("restore %s: %v", t.Original, err)
+			continue
+		}
+		if err := os.Rename(t.Stored, t.Original); err != nil {
+			warn("restore %s: %v", t.Original, err)
+			continue
+		}
+		_ = os.Remove(filepath.Dir(t.Stored))
+		result.Restored++
+	}
+
+	for i := range op.Sources {
+		if err := j.db.UpsertSource(&op.Sources[i]); err != nil {
+			warn("restore source %s: %v", op.Sources[i].ID, err)
+			continue
+		}
+		result.Restored++
+	}
+
+	for i := range op.Skills {
+		skill := op.Skills[i]
+		tags := skill.Tags
+		skill.Tags = nil
+		if err := j.db.UpsertSkillWithTags(&skill, tags); err != nil {
+			warn("restore skill %s: %v", skill.Slug, err)
+			continue
+		}
+		result.Restored++
+	}
+
+	for i := range op.Installations {
+		if err := j.db.AddInstallation(&op.Installations[i]); err != nil {
+			warn("restore installation of %s: %v", op.Installations[i].SkillID, err)
+			continue
+		}
+		result.Restored++
+	}
+
+	for _, link := range op.Symlinks {
+		if current, err := os.Readlink(link.Path); err == nil && current == link.Target {
+			continue
+		}
+		if _, err := os.Lstat(link.Path); err == nil {
+			warn("%s is in use; symlink not restored", link.Path)
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(link.Path), 0755); err != nil {
+			warn("restore %s: %v", link.Path, err)
+			continue
+		}
+		if err := os.Symlink(link.Target, link.Path); err != nil {
+			warn("restore %s: %v", link.Path, err)
+			continue
+		}
+		result.Restored++
+	}
+
+	for _, m := range op.Manifests {
+		var err error
+		if m.Existed {
+			err = os.WriteFile(m.Path, []byte(m.Content), 0644)
+		} else {
+			err = os.Remove(m.Path)
+			if os.IsNotExist(err) {
+				err = nil
+			}
+		}
+		if err != nil {
+			warn("restore %s: %v", m.Path, err)
+			continue
+		}
+		result.Restored++
+	}
+
+	if err := j.db.MarkJournalEntryUndone(entry.ID, time.Now()); err != nil {
+		return result, fmt.Errorf("mark journal entry %d undone: %w", entry.ID, err)
+	}
+	return result, nil
+}
@@ -0,0 +1,99 @@
+package manifest
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// ExtendsRef identifies the manifest named by a skulto.json "extends" entry:
+// either "owner/repo//path/skulto.json@ref" in a git repository, or a local
+// file ("./base.json", "../skulto.json", "/abs/path", "~/org/skulto.json").
+type ExtendsRef struct {
+	Owner string // Repository owner; empty for a local file
+	Repo  string // Repository name; empty for a local file
+	Path  string // Manifest path within the repository, or the local file path
+	Ref   string // Branch, tag or commit; empty means the default branch
+}
+
+// IsLocal reports whether the reference names a local file.
+func (r ExtendsRef) IsLocal() bool {
+	return r.Owner == ""
+}
+
+// String returns the reference in "extends" syntax.
+func (r ExtendsRef) String() string {
+	if r.IsLocal() {
+		return r.Path
+	}
+	s := r.Owner + "/" + r.Repo + "//" + r.Path
+	if r.Ref != "" {
+		s += "@" + r.Ref
+	}
+	return s
+}
+
+// ParseExtends parses an "extends" value. In the repository form the path
+// defaults to skulto.json at the repository root, and a path naming a
+// directory gets skulto.json appended.
+func ParseExtends(spec string) (ExtendsRef, error) {
+	s := strings.TrimSpace(spec)
+	if s == "" {
+		return ExtendsRef{}, fmt.Errorf("empty extends")
+	}
+	if strings.HasPrefix(s, "file://") {
+		return ExtendsRef{Path: strings.TrimPrefix(s, "file://")}, nil
+	}
+	if strings.HasPrefix(s, ".") || strings.HasPrefix(s, "~") || filepath.IsAbs(s) {
+		return ExtendsRef{Path: s}, nil
+	}
+
+	// The ref follows the last "@"; the user part of
+	// git@github.com:owner/repo is not a ref.
+	var ref ExtendsRef
+	start := 0
+	if strings.HasPrefix(s, "git@") {
+		start = strings.Index(s, ":") + 1
+	}
+	if at := strings.LastIndex(s[start:], "@"); at >= 0 {
+		at += start
+		ref.Ref = s[at+1:]
+		s = s[:at]
+		if ref.Ref == "" {
+			return ExtendsRef{}, fmt.Errorf("extends %q: empty ref after @", spec)
+		}
+	}
+
+	// Split "repo//path", skipping the "//" of a URL scheme
+	scheme, rest := "", s
+	if i := strings.Index(s, "://"); i >= 0 {
+		scheme, rest = s[:i+3], s[i+3:]
+	}
+	repoPart, pathPart := rest, ""
+	if i := strings.Index(rest, "//"); i >= 0 {
+		repoPart, pathPart = rest[:i], rest[i+2:]
+	}
+	repoPart = scheme + repoPart
+
+	// Repositories on other hosts keep the host in the owner:
+	// gitlab.example.com/team/skills → gitlab.example.com/team, skills
+	parts := strings.Split(NormalizeSource(repoPart), "/")
+	if len(parts) < 2 || slices.Contains(parts, "") {
+		return ExtendsRef{}, fmt.Errorf("extends %q: expected owner/repo//path/skulto.json@ref or a local path", spec)
+	}
+	ref.Owner, ref.Repo = strings.Join(parts[:len(parts)-1], "/"), parts[len(parts)-1]
+
+	pathPart = strings.Trim(pathPart, "/")
+	if strings.Contains("/"+pathPart+"/", "/../") {
+		return ExtendsRef{}, fmt.Errorf("extends %q: path must stay inside the repository", spec)
+	}
+	switch {
+	case pathPart == "":
+		pathPart = FileName
+	case !strings.HasSuffix(pathPart, ".json"):
+		pathPart += "/" + FileName
+	}
+	ref.Path = pathPart
+	return ref, nil
+}
@@ -0,0 +1,65 @@
+package manifest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseExtends(t *testing.T) {
+	tests := []struct {
+		spec string
+		want ExtendsRef
+	}{
+		{"acme/baseline", ExtendsRef{Owner: "acme", Repo: "baseline", Path: "skulto.json"}},
+		{"acme/baseline@v2", ExtendsRef{Owner: "acme", Repo: "baseline", Path: "skulto.json", Ref: "v2"}},
+		{"acme/baseline//teams/web/skulto.json@release/v1", ExtendsRef{Owner: "acme", Repo: "baseline", Path: "teams/web/skulto.json", Ref: "release/v1"}},
+		{"acme/baseline//teams/web", ExtendsRef{Owner: "acme", Repo: "baseline", Path: "teams/web/skulto.json"}},
+		{"https://github.com/acme/baseline//base.json@main", ExtendsRef{Owner: "acme", Repo: "baseline", Path: "base.json", Ref: "main"}},
+		{"git@github.com:acme/baseline.git//base.json", ExtendsRef{Owner: "acme", Repo: "baseline", Path: "base.json"}},
+		{"gitlab.example.com/platform/baseline//skulto.json@v1", ExtendsRef{Owner: "gitlab.example.com/platform", Repo: "baseline", Path: "skulto.json", Ref: "v1"}},
+		{"git@gitlab.example.com:platform/baseline.git", ExtendsRef{Owner: "gitlab.example.com/platform", Repo: "baseline", Path: "skulto.json"}},
+		{"../skulto.json", ExtendsRef{Path: "../skulto.json"}},
+		{"/etc/skulto/base.json", ExtendsRef{Path: "/etc/skulto/base.json"}},
+		{"file:///etc/skulto/base.json", ExtendsRef{Path: "/etc/skulto/base.json"}},
+	}
+	for _, tt := range tests {
+		got, err := ParseExtends(tt.spec)
+		if err != nil {
+			t.Errorf("ParseExtends(%q) error = %v", tt.spec, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseExtends(%q) = %+v, want %+v", tt.spec, got, tt.want)
+		}
+	}
+
+	for _, bad := range []string{"", "baseline", "acme/baseline@", "acme/baseline//../secret.json"} {
+		if _, err := ParseExtends(bad); err == nil {
+			t.Errorf("ParseExtends(%q) expected error", bad)
+		}
+	}
+}
+
+func TestRead_Extends(t *testing.T) {
+	dir := t.TempDir()
+	content := `{"version": 2, "extends": "acme/baseline@v1", "skills": {}}`
+	if err := os.WriteFile(filepath.Join(dir, FileName), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	mf, err := Read(dir)
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if mf.Extends != "acme/baseline@v1" {
+		t.Errorf("Extends = %q", mf.Extends)
+	}
+
+	bad := `{"version": 2, "extends": "not a repo", "skills": {}}`
+	if err := os.WriteFile(filepath.Join(dir, FileName), []byte(bad), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Read(dir); err == nil {
+		t.Error("Read() accepted an invalid extends")
+	}
+}
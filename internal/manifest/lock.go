@@ -0,0 +1,185 @@
+package manifest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+const (
+	// LockFileName is the lockfile name, written next to skulto.json.
+	LockFileName = "skulto.lock"
+
+	// CurrentLockVersion is the current lockfile format version.
+	CurrentLockVersion = 1
+)
+
+// ErrHashMismatch is returned when a skill's content does not match its lock entry.
+var ErrHashMismatch = errors.New("skill content does not match skulto.lock")
+
+// LockFile is the JSON structure of skulto.lock.
+type LockFile struct {
+	Version int                    `json:"version"`
+	Skills  map[string]LockedSkill `json:"skills"` // slug -> resolved revision
+}
+
+// LockedSkill pins a manifest skill to an exact revision of its source.
+type LockedSkill struct {
+	Source string `json:"source"`        // "owner/repo"
+	Ref    string `json:"ref,omitempty"` // Manifest ref the commit was resolved from
+	Commit string `json:"commit"`        // Commit SHA of the source clone
+	Path   string `json:"path"`          // Skill directory within the repository
+	Hash   string `json:"hash"`          // "sha256:<hex>" over SKILL.md and auxiliary files
+}
+
+// HashMismatchError reports a skill whose files differ from the locked hash.
+type HashMismatchError struct {
+	Slug string
+	Want string
+	Got  string
+}
+
+func (e *HashMismatchError) Error() string {
+	return fmt.Sprintf("%s: %s (locked %s, found %s)", ErrHashMismatch, e.Slug, e.Want, e.Got)
+}
+
+func (e *HashMismatchError) Unwrap() error { return ErrHashMismatch }
+
+// NewLock creates an empty lockfile.
+func NewLock() *LockFile {
+	return &LockFile{
+		Version: CurrentLockVersion,
+		Skills:  make(map[string]LockedSkill),
+	}
+}
+
+// LockPath returns the full path to skulto.lock in the given directory.
+func LockPath(dir string) string {
+	return filepath.Join(dir, LockFileName)
+}
+
+// ReadLock reads a lockfile from the given directory.
+// Returns nil, nil if the file does not exist.
+func ReadLock(dir string) (*LockFile, error) {
+	data, err := os.ReadFile(LockPath(dir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read lockfile: %w", err)
+	}
+
+	var lf LockFile
+	if err := json.Unmarshal(data, &lf); err != nil {
+		return nil, fmt.Errorf("parse lockfile: %w", err)
+	}
+	if lf.Skills == nil {
+		lf.Skills = make(map[string]LockedSkill)
+	}
+	return &lf, nil
+}
+
+// WriteLock writes a lockfile to the given directory using atomic file operations.
+func WriteLock(dir string, lf *LockFile) error {
+	if lf.Version == 0 {
+		lf.Version = CurrentLockVersion
+	}
+	if lf.Skills == nil {
+		lf.Skills = make(map[string]LockedSkill)
+	}
+
+	data, err := json.MarshalIndent(lf, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal lockfile: %w", err)
+	}
+	data = append(data, '\n')
+
+	p := LockPath(dir)
+	tmpPath := p + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return fmt.Errorf("write temp file: %w", err)
+	}
+	if err := os.Rename(tmpPath, p); err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("rename lockfile: %w", err)
+	}
+	return nil
+}
+
+// LocksEqual returns true if two lockfiles pin identical revisions.
+func LocksEqual(a, b *LockFile) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	if len(a.Skills) != len(b.Skills) {
+		return false
+	}
+	for slug, locked := range a.Skills {
+		if b.Skills[slug] != locked {
+			return false
+		}
+	}
+	return true
+}
+
+// Verify returns a *HashMismatchError if the skill directory's content
+// differs from the locked hash.
+func (ls LockedSkill) Verify(slug, skillDir string) error {
+	got, err := HashSkillDir(skillDir)
+	if err != nil {
+		return err
+	}
+	if got != ls.Hash {
+		return &HashMismatchError{Slug: slug, Want: ls.Hash, Got: got}
+	}
+	return nil
+}
+
+// HashSkillDir returns a SHA256 digest over every regular file in a skill
+// directory (SKILL.md plus auxiliary files), covering relative paths and
+// contents in sorted order. Symlinks and .git directories are skipped.
+func HashSkillDir(dir string) (string, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && d.Name() == ".git" {
+			return filepath.SkipDir
+		}
+		if d.Type().IsRegular() {
+			rel, err := filepath.Rel(dir, path)
+			if err != nil {
+				return err
+			}
+			files = append(files, filepath.ToSlash(rel))
+		}
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("hash skill directory: %w", err)
+	}
+	sort.Strings(files)
+
+	h := sha256.New()
+	for _, rel := range files {
+		f, err := os.Open(filepath.Join(dir, filepath.FromSlash(rel)))
+		if err != nil {
+			return "", fmt.Errorf("hash skill directory: %w", err)
+		}
+		_, _ = fmt.Fprintf(h, "%s\x00", rel)
+		_, err = io.Copy(h, f)
+		_ = f.Close()
+		if err != nil {
+			return "", fmt.Errorf("hash skill directory: %w", err)
+		}
+		_, _ = h.Write([]byte{0})
+	}
+	return "sha256:" + hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,110 @@
+package manifest
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeSkillFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		p := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestLock_WriteRead(t *testing.T) {
+	dir := t.TempDir()
+
+	if got, err := ReadLock(dir); err != nil || got != nil {
+		t.Fatalf("ReadLock on missing file = %v, %v; want nil, nil", got, err)
+	}
+
+	lf := NewLock()
+	lf.Skills["teach"] = LockedSkill{Source: "asteroid-belt/skills", Commit: "abc123", Path: "skills/teach", Hash: "sha256:00"}
+	if err := WriteLock(dir, lf); err != nil {
+		t.Fatalf("WriteLock: %v", err)
+	}
+
+	got, err := ReadLock(dir)
+	if err != nil {
+		t.Fatalf("ReadLock: %v", err)
+	}
+	if !LocksEqual(lf, got) {
+		t.Errorf("round trip = %+v, want %+v", got, lf)
+	}
+	if got.Version != CurrentLockVersion {
+		t.Errorf("Version = %d, want %d", got.Version, CurrentLockVersion)
+	}
+}
+
+func TestLocksEqual(t *testing.T) {
+	a := NewLock()
+	a.Skills["x"] = LockedSkill{Source: "o/r", Commit: "1"}
+	b := NewLock()
+	b.Skills["x"] = LockedSkill{Source: "o/r", Commit: "2"}
+
+	if LocksEqual(a, b) {
+		t.Error("different commits should not be equal")
+	}
+	if !LocksEqual(nil, nil) {
+		t.Error("nil locks should be equal")
+	}
+	if LocksEqual(a, nil) {
+		t.Error("nil and non-nil should not be equal")
+	}
+}
+
+func TestHashSkillDir(t *testing.T) {
+	dir := t.TempDir()
+	writeSkillFiles(t, dir, map[string]string{
+		"SKILL.md":          "# Skill\n",
+		"scripts/run.sh":    "echo hi\n",
+		"references/doc.md": "ref\n",
+		".git/HEAD":         "ignored\n",
+	})
+
+	first, err := HashSkillDir(dir)
+	if err != nil {
+		t.Fatalf("HashSkillDir: %v", err)
+	}
+	again, _ := HashSkillDir(dir)
+	if first != again {
+		t.Errorf("hash not stable: %s vs %s", first, again)
+	}
+
+	// .git content does not affect the hash
+	writeSkillFiles(t, dir, map[string]string{".git/HEAD": "changed\n"})
+	if h, _ := HashSkillDir(dir); h != first {
+		t.Error(".git changes should not affect the hash")
+	}
+
+	// Auxiliary file changes do
+	writeSkillFiles(t, dir, map[string]string{"scripts/run.sh": "echo bye\n"})
+	changed, _ := HashSkillDir(dir)
+	if changed == first {
+		t.Error("auxiliary file change should change the hash")
+	}
+
+	locked := LockedSkill{Hash: first}
+	err = locked.Verify("teach", dir)
+	var mismatch *HashMismatchError
+	if !errors.As(err, &mismatch) || !errors.Is(err, ErrHashMismatch) {
+		t.Fatalf("Verify = %v, want HashMismatchError", err)
+	}
+	if mismatch.Got != changed {
+		t.Errorf("mismatch.Got = %s, want %s", mismatch.Got, changed)
+	}
+
+	locked.Hash = changed
+	if err := locked.Verify("teach", dir); err != nil {
+		t.Errorf("Verify with matching hash = %v", err)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"strings"
 )
 
 const (
@@ -13,29 +14,269 @@ const (
 	FileName = "skulto.json"
 
 	// CurrentVersion is the current manifest format version.
-	CurrentVersion = 1
+	// Version 1 maps each slug to "owner/repo". Version 2 also accepts a
+	// per-skill object (see SkillOptions); entries without options are
+	// still written as "owner/repo", so v1 files read as v2 unchanged.
+	// Version 1 files used "version" as a save counter, so their number
+	// says nothing about the format; see isLegacy.
+	CurrentVersion = 2
 )
 
 // ManifestFile is the JSON structure of skulto.json.
 type ManifestFile struct {
-	Version int               `json:"version"`
-	Skills  map[string]string `json:"skills"`            // slug -> "owner/repo"
-	Ignored []string          `json:"ignored,omitempty"` // skills explicitly excluded from manifest
+	Version   int                     `json:"version"`
+	Skills    map[string]string       `json:"skills"`              // slug -> "owner/repo"
+	Options   map[string]SkillOptions `json:"-"`                   // slug -> v2 per-skill settings; absent means defaults
+	Ignored   []string                `json:"ignored,omitempty"`   // skills explicitly excluded from manifest
+	Workspace []string                `json:"workspace,omitempty"` // member directory globs, relative to this manifest
+	Extends   string                  `json:"extends,omitempty"`   // base manifest this one inherits from (see ParseExtends)
+}
+
+// SkillOptions are the version 2 per-skill settings.
+type SkillOptions struct {
+	SourceURL string   // Source as written, when it is a URL rather than "owner/repo"
+	Ref       string   // Branch, tag or commit to install from instead of the default branch
+	Version   string   // Semver constraint on the skill's frontmatter version
+	Platforms []string // Platforms to install to; empty means the sync selection
+	Scope     string   // "global" or "project"; empty means the sync selection
+	Optional  bool     // Skip quietly if the source or skill is unavailable
+}
+
+// IsZero reports whether no options are set.
+func (o SkillOptions) IsZero() bool {
+	return o.SourceURL == "" && o.Ref == "" && o.Version == "" &&
+		len(o.Platforms) == 0 && o.Scope == "" && !o.Optional
+}
+
+// Equal reports whether two option sets are identical.
+func (o SkillOptions) Equal(other SkillOptions) bool {
+	if o.SourceURL != other.SourceURL || o.Ref != other.Ref || o.Version != other.Version ||
+		o.Scope != other.Scope || o.Optional != other.Optional || len(o.Platforms) != len(other.Platforms) {
+		return false
+	}
+	for i := range o.Platforms {
+		if o.Platforms[i] != other.Platforms[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// skillEntry is the version 2 object form of a skills entry.
+type skillEntry struct {
+	Source    string   `json:"source"`
+	Ref       string   `json:"ref,omitempty"`
+	Version   string   `json:"version,omitempty"`
+	Platforms []string `json:"platforms,omitempty"`
+	Scope     string   `json:"scope,omitempty"`
+	Optional  bool     `json:"optional,omitempty"`
+}
+
+// manifestJSON is the on-disk layout, with skills entries left raw so each
+// can be either a "owner/repo" string or a skillEntry object.
+type manifestJSON struct {
+	Version   int                        `json:"version"`
+	Skills    map[string]json.RawMessage `json:"skills"`
+	Ignored   []string                   `json:"ignored,omitempty"`
+	Workspace []string                   `json:"workspace,omitempty"`
+	Extends   string                     `json:"extends,omitempty"`
+}
+
+// UnmarshalJSON reads version 1 and version 2 manifests. Version 1 files are
+// migrated in memory to CurrentVersion.
+func (mf *ManifestFile) UnmarshalJSON(data []byte) error {
+	var raw manifestJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if raw.Version > CurrentVersion && !isLegacy(data, raw) {
+		return fmt.Errorf("manifest version %d is newer than supported version %d; upgrade skulto", raw.Version, CurrentVersion)
+	}
+
+	mf.Version = CurrentVersion
+	mf.Skills = make(map[string]string, len(raw.Skills))
+	mf.Options = make(map[string]SkillOptions)
+	mf.Ignored = raw.Ignored
+	mf.Workspace = raw.Workspace
+	if raw.Extends != "" {
+		if _, err := ParseExtends(raw.Extends); err != nil {
+			return err
+		}
+	}
+	mf.Extends = raw.Extends
+
+	for slug, value := range raw.Skills {
+		var source string
+		if err := json.Unmarshal(value, &source); err == nil {
+			mf.Skills[slug] = NormalizeSource(source)
+			if mf.Skills[slug] != source {
+				mf.Options[slug] = SkillOptions{SourceURL: source}
+			}
+			continue
+		}
+
+		var entry skillEntry
+		if err := json.Unmarshal(value, &entry); err != nil {
+			return fmt.Errorf("skill %q: expected \"owner/repo\" or an object: %w", slug, err)
+		}
+		if entry.Source == "" {
+			return fmt.Errorf("skill %q: missing source", slug)
+		}
+		if entry.Scope != "" && entry.Scope != "global" && entry.Scope != "project" {
+			return fmt.Errorf("skill %q: invalid scope %q", slug, entry.Scope)
+		}
+		mf.Skills[slug] = NormalizeSource(entry.Source)
+		opts := SkillOptions{
+			Ref:       entry.Ref,
+			Version:   entry.Version,
+			Platforms: entry.Platforms,
+			Scope:     entry.Scope,
+			Optional:  entry.Optional,
+		}
+		if mf.Skills[slug] != entry.Source {
+			opts.SourceURL = entry.Source
+		}
+		if !opts.IsZero() {
+			mf.Options[slug] = opts
+		}
+	}
+	return nil
+}
+
+// isLegacy reports whether a manifest has only what version 1 files had: a
+// "version", string skills entries and an "ignored" list. Version 1 bumped
+// "version" on every save, so such a file can carry any number.
+func isLegacy(data []byte, raw manifestJSON) bool {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return false
+	}
+	for key := range fields {
+		if key != "version" && key != "skills" && key != "ignored" {
+			return false
+		}
+	}
+	for _, value := range raw.Skills {
+		var source string
+		if err := json.Unmarshal(value, &source); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// MarshalJSON writes the manifest at CurrentVersion. Skills without options
+// use the "owner/repo" shorthand.
+func (mf *ManifestFile) MarshalJSON() ([]byte, error) {
+	raw := manifestJSON{
+		Version:   mf.Version,
+		Skills:    make(map[string]json.RawMessage, len(mf.Skills)),
+		Ignored:   mf.Ignored,
+		Workspace: mf.Workspace,
+		Extends:   mf.Extends,
+	}
+	for slug, source := range mf.Skills {
+		opts := mf.Options[slug]
+		if opts.SourceURL != "" {
+			source = opts.SourceURL
+		}
+
+		var value any = source
+		if opts.Ref != "" || opts.Version != "" || len(opts.Platforms) > 0 || opts.Scope != "" || opts.Optional {
+			value = skillEntry{
+				Source:    source,
+				Ref:       opts.Ref,
+				Version:   opts.Version,
+				Platforms: opts.Platforms,
+				Scope:     opts.Scope,
+				Optional:  opts.Optional,
+			}
+		}
+		data, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		raw.Skills[slug] = data
+	}
+	return json.Marshal(raw)
+}
+
+// NormalizeSource converts GitHub URLs (https://github.com/owner/repo[.git],
+// git@github.com:owner/repo.git) to "owner/repo", and URLs on other git hosts
+// (https://gitlab.example.com/team/skills.git, git@host:team/skills.git) to
+// "host/team/skills", matching source IDs. Other values are returned trimmed
+// but otherwise unchanged.
+func NormalizeSource(source string) string {
+	s := strings.TrimSpace(source)
+	host, path, ok := splitRemote(s)
+	if !ok {
+		return s
+	}
+	// GitLab web URLs continue past the repo: group/repo/-/tree/main
+	if i := strings.Index(path, "/-/"); i >= 0 {
+		path = path[:i]
+	}
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return s
+	}
+	if host == "github.com" {
+		return parts[0] + "/" + strings.TrimSuffix(parts[1], ".git")
+	}
+	parts[len(parts)-1] = strings.TrimSuffix(parts[len(parts)-1], ".git")
+	return host + "/" + strings.Join(parts, "/")
+}
+
+// splitRemote splits a git URL (scheme or scp-like) into host and path.
+func splitRemote(s string) (host, path string, ok bool) {
+	if scheme, rest, found := strings.Cut(s, "://"); found {
+		switch scheme {
+		case "https", "http", "ssh", "git":
+		default:
+			return "", "", false
+		}
+		hostPort, path, _ := strings.Cut(rest, "/")
+		if at := strings.LastIndex(hostPort, "@"); at >= 0 {
+			hostPort = hostPort[at+1:]
+		}
+		if i := strings.LastIndex(hostPort, ":"); i >= 0 {
+			hostPort = hostPort[:i]
+		}
+		return strings.ToLower(hostPort), path, hostPort != ""
+	}
+	// scp-like: [user@]host:path
+	if i := strings.Index(s, ":"); i > 0 && !strings.Contains(s[:i], "/") {
+		host := s[:i]
+		if at := strings.LastIndex(host, "@"); at >= 0 {
+			host = host[at+1:]
+		}
+		return strings.ToLower(host), s[i+1:], host != ""
+	}
+	return "", "", false
 }
 
 // Read reads a manifest from the given directory.
 // Returns nil, nil if the file does not exist.
 func Read(dir string) (*ManifestFile, error) {
-	p := Path(dir)
+	return ReadFile(Path(dir))
+}
 
-	data, err := os.ReadFile(p)
+// ReadFile reads a manifest from an explicit file path.
+// Returns nil, nil if the file does not exist.
+func ReadFile(path string) (*ManifestFile, error) {
+	data, err := os.ReadFile(path)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return nil, nil
 		}
 		return nil, fmt.Errorf("read manifest: %w", err)
 	}
+	return Parse(data)
+}
 
+// Parse decodes a manifest read from somewhere other than a file, such as
+// a commit of a git repository.
+func Parse(data []byte) (*ManifestFile, error) {
 	var mf ManifestFile
 	if err := json.Unmarshal(data, &mf); err != nil {
 		return nil, fmt.Errorf("parse manifest: %w", err)
@@ -53,9 +294,13 @@ func Read(dir string) (*ManifestFile, error) {
 
 // Write writes a manifest to the given directory using atomic file operations.
 func Write(dir string, mf *ManifestFile) error {
-	if mf.Version == 0 {
-		mf.Version = CurrentVersion
-	}
+	return WriteFile(Path(dir), mf)
+}
+
+// WriteFile writes a manifest to an explicit file path using atomic file
+// operations. Output is canonical: keys are sorted and lists keep their order.
+func WriteFile(p string, mf *ManifestFile) error {
+	mf.Version = CurrentVersion
 	if mf.Skills == nil {
 		mf.Skills = make(map[string]string)
 	}
@@ -68,8 +313,6 @@ func Write(dir string, mf *ManifestFile) error {
 	// Append trailing newline
 	data = append(data, '\n')
 
-	p := Path(dir)
-
 	// Ensure directory exists
 	if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
 		return fmt.Errorf("create directory: %w", err)
@@ -99,6 +342,7 @@ func New() *ManifestFile {
 	return &ManifestFile{
 		Version: CurrentVersion,
 		Skills:  make(map[string]string),
+		Options: make(map[string]SkillOptions),
 	}
 }
 
@@ -145,7 +389,8 @@ func SkillsEqual(a, b *ManifestFile) bool {
 	return true
 }
 
-// ManifestEqual returns true if two manifests have identical skills AND ignored lists.
+// ManifestEqual returns true if two manifests have identical skills, per-skill
+// options, ignored lists, workspace members AND extends.
 // Used as the write gate in save.go (replaces SkillsEqual for no-op detection).
 func ManifestEqual(a, b *ManifestFile) bool {
 	if !SkillsEqual(a, b) {
@@ -154,6 +399,11 @@ func ManifestEqual(a, b *ManifestFile) bool {
 	if a == nil || b == nil {
 		return a == b
 	}
+	for slug := range a.Skills {
+		if !a.SkillOptions(slug).Equal(b.SkillOptions(slug)) {
+			return false
+		}
+	}
 	if len(a.Ignored) != len(b.Ignored) {
 		return false
 	}
@@ -166,9 +416,22 @@ func ManifestEqual(a, b *ManifestFile) bool {
 			return false
 		}
 	}
+	if a.Extends != b.Extends || len(a.Workspace) != len(b.Workspace) {
+		return false
+	}
+	for i := range a.Workspace {
+		if a.Workspace[i] != b.Workspace[i] {
+			return false
+		}
+	}
 	return true
 }
 
+// SkillOptions returns the per-skill settings for slug (zero if none).
+func (mf *ManifestFile) SkillOptions(slug string) SkillOptions {
+	return mf.Options[slug]
+}
+
 // SortedSlugs returns skill slugs in alphabetical order.
 func (mf *ManifestFile) SortedSlugs() []string {
 	slugs := make([]string, 0, len(mf.Skills))
@@ -3,6 +3,7 @@ package manifest
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -30,8 +31,8 @@ func TestRead_FileExists(t *testing.T) {
 		t.Fatal("Read returned nil")
 		return
 	}
-	if got.Version != 1 {
-		t.Errorf("Version = %d, want 1", got.Version)
+	if got.Version != CurrentVersion {
+		t.Errorf("Version = %d, want %d", got.Version, CurrentVersion)
 	}
 	if len(got.Skills) != 2 {
 		t.Errorf("Skills count = %d, want 2", len(got.Skills))
@@ -330,3 +331,193 @@ func TestSortedSlugs(t *testing.T) {
 		}
 	}
 }
+
+func TestRead_MigratesV1(t *testing.T) {
+	dir := t.TempDir()
+	v1 := `{"version": 1, "skills": {"teach": "asteroid-belt/skills"}}`
+	if err := os.WriteFile(Path(dir), []byte(v1), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := Read(dir)
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if got.Version != CurrentVersion {
+		t.Errorf("Version = %d, want %d", got.Version, CurrentVersion)
+	}
+	if got.Skills["teach"] != "asteroid-belt/skills" {
+		t.Errorf("Skills[teach] = %q", got.Skills["teach"])
+	}
+	if !got.SkillOptions("teach").IsZero() {
+		t.Errorf("v1 entry should have no options, got %+v", got.SkillOptions("teach"))
+	}
+}
+
+func TestV2_RoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	v2 := `{
+  "version": 2,
+  "skills": {
+    "teach": "asteroid-belt/skills",
+    "superplan": {
+      "source": "https://github.com/asteroid-belt/skills.git",
+      "ref": "v1.2.0",
+      "version": "^1.2",
+      "platforms": ["claude", "cursor"],
+      "scope": "project",
+      "optional": true
+    }
+  }
+}`
+	if err := os.WriteFile(Path(dir), []byte(v2), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := Read(dir)
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if got.Skills["superplan"] != "asteroid-belt/skills" {
+		t.Errorf("source not normalized: %q", got.Skills["superplan"])
+	}
+	opts := got.SkillOptions("superplan")
+	want := SkillOptions{
+		SourceURL: "https://github.com/asteroid-belt/skills.git",
+		Ref:       "v1.2.0",
+		Version:   "^1.2",
+		Platforms: []string{"claude", "cursor"},
+		Scope:     "project",
+		Optional:  true,
+	}
+	if !opts.Equal(want) {
+		t.Errorf("options = %+v, want %+v", opts, want)
+	}
+
+	// Writing and re-reading preserves everything
+	if err := Write(dir, got); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	again, err := Read(dir)
+	if err != nil {
+		t.Fatalf("Read again: %v", err)
+	}
+	if !ManifestEqual(got, again) {
+		t.Errorf("round trip changed manifest: %+v vs %+v", got, again)
+	}
+
+	data, _ := os.ReadFile(Path(dir))
+	if !strings.Contains(string(data), `"teach": "asteroid-belt/skills"`) {
+		t.Errorf("entries without options should use the shorthand:\n%s", data)
+	}
+}
+
+func TestRead_MigratesSaveCountedV1(t *testing.T) {
+	// Version 1 bumped "version" on every save
+	dir := t.TempDir()
+	v1 := `{"version": 5, "skills": {"teach": "asteroid-belt/skills"}, "ignored": ["legacy"]}`
+	if err := os.WriteFile(Path(dir), []byte(v1), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := Read(dir)
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if got.Version != CurrentVersion {
+		t.Errorf("Version = %d, want %d", got.Version, CurrentVersion)
+	}
+	if got.Skills["teach"] != "asteroid-belt/skills" {
+		t.Errorf("Skills[teach] = %q", got.Skills["teach"])
+	}
+	if len(got.Ignored) != 1 || got.Ignored[0] != "legacy" {
+		t.Errorf("Ignored = %v, want [legacy]", got.Ignored)
+	}
+
+	// Written back at the current format version
+	if err := Write(dir, got); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	again, err := Read(dir)
+	if err != nil {
+		t.Fatalf("Read after Write: %v", err)
+	}
+	if again.Version != CurrentVersion {
+		t.Errorf("Version after Write = %d, want %d", again.Version, CurrentVersion)
+	}
+}
+
+func TestRead_RejectsInvalidV2Entries(t *testing.T) {
+	cases := map[string]string{
+		"missing source": `{"version": 2, "skills": {"x": {"ref": "main"}}}`,
+		"bad scope":      `{"version": 2, "skills": {"x": {"source": "o/r", "scope": "team"}}}`,
+		"future entries": `{"version": 99, "skills": {"x": {"source": "o/r"}}}`,
+		"future fields":  `{"version": 3, "skills": {}, "profiles": {}}`,
+	}
+	for name, content := range cases {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := os.WriteFile(Path(dir), []byte(content), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := Read(dir); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestManifestEqual_ComparesOptions(t *testing.T) {
+	a := New()
+	a.Skills["teach"] = "o/r"
+	b := New()
+	b.Skills["teach"] = "o/r"
+	if !ManifestEqual(a, b) {
+		t.Fatal("identical manifests should be equal")
+	}
+
+	b.Options["teach"] = SkillOptions{Platforms: []string{"claude"}}
+	if ManifestEqual(a, b) {
+		t.Error("different platforms should not be equal")
+	}
+	if ManifestEqual(b, a) {
+		t.Error("different platforms should not be equal (reversed)")
+	}
+}
+
+func TestNormalizeSource(t *testing.T) {
+	tests := map[string]string{
+		"owner/repo":                                        "owner/repo",
+		"https://github.com/owner/repo":                     "owner/repo",
+		"https://github.com/owner/repo.git":                 "owner/repo",
+		"git@github.com:owner/repo.git":                     "owner/repo",
+		"https://gitlab.com/group/repo.git":                 "gitlab.com/group/repo",
+		"https://gitlab.example.com/a/b/skills/-/tree/main": "gitlab.example.com/a/b/skills",
+		"ssh://git@git.example.com:2222/team/skills.git":    "git.example.com/team/skills",
+		"git@bitbucket.org:team/skills.git":                 "bitbucket.org/team/skills",
+		"gitlab.example.com/team/skills":                    "gitlab.example.com/team/skills",
+		"./.skulto/vendor/my-skill":                         "./.skulto/vendor/my-skill",
+	}
+	for in, want := range tests {
+		if got := NormalizeSource(in); got != want {
+			t.Errorf("NormalizeSource(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestVendorSource(t *testing.T) {
+	src := VendorSource("my-skill")
+	if src != "./.skulto/vendor/my-skill" {
+		t.Errorf("VendorSource = %q", src)
+	}
+	for source, want := range map[string]bool{
+		src:                      true,
+		"../shared/skills/x":     true,
+		"owner/repo":             false,
+		"https://gitlab.com/g/r": false,
+	} {
+		if got := IsPathSource(source); got != want {
+			t.Errorf("IsPathSource(%q) = %v, want %v", source, got, want)
+		}
+	}
+}
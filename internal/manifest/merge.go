@@ -0,0 +1,156 @@
+package manifest
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Conflict is a manifest field both sides of a merge changed differently.
+type Conflict struct {
+	Slug   string // Skill slug, or "" for a top-level field
+	Field  string // "skills" or "extends"
+	Base   string // Value in the common ancestor; "" if absent
+	Ours   string // Value on our side; "" if removed
+	Theirs string // Value on their side; "" if removed
+}
+
+func (c Conflict) String() string {
+	show := func(v string) string {
+		if v == "" {
+			return "(none)"
+		}
+		return v
+	}
+	name := c.Field
+	if c.Slug != "" {
+		name = c.Slug
+	}
+	return fmt.Sprintf("%s: base %s, ours %s, theirs %s", name, show(c.Base), show(c.Ours), show(c.Theirs))
+}
+
+// Merge performs a three-way merge of manifests. Each skill entry (source
+// plus per-skill options) merges as a unit: a side that left an entry as in
+// base takes the other side's change. Both sides changing the same entry
+// differently is a conflict, resolved to our side in the result. Ignored
+// and workspace lists merge as sets and are sorted. Nil inputs are treated
+// as empty manifests.
+func Merge(base, ours, theirs *ManifestFile) (*ManifestFile, []Conflict) {
+	if base == nil {
+		base = New()
+	}
+	if ours == nil {
+		ours = New()
+	}
+	if theirs == nil {
+		theirs = New()
+	}
+
+	out := New()
+	var conflicts []Conflict
+
+	slugs := make(map[string]bool)
+	for _, mf := range []*ManifestFile{base, ours, theirs} {
+		for slug := range mf.Skills {
+			slugs[slug] = true
+		}
+	}
+	for slug := range slugs {
+		var pick *ManifestFile
+		switch {
+		case sameEntry(ours, theirs, slug):
+			pick = ours
+		case sameEntry(base, ours, slug):
+			pick = theirs
+		case sameEntry(base, theirs, slug):
+			pick = ours
+		default:
+			pick = ours
+			conflicts = append(conflicts, Conflict{
+				Slug:   slug,
+				Field:  "skills",
+				Base:   describeEntry(base, slug),
+				Ours:   describeEntry(ours, slug),
+				Theirs: describeEntry(theirs, slug),
+			})
+		}
+		if source, ok := pick.Skills[slug]; ok {
+			out.Skills[slug] = source
+			if opts := pick.SkillOptions(slug); !opts.IsZero() {
+				out.Options[slug] = opts
+			}
+		}
+	}
+	sort.Slice(conflicts, func(i, j int) bool { return conflicts[i].Slug < conflicts[j].Slug })
+
+	out.Ignored = mergeSet(base.Ignored, ours.Ignored, theirs.Ignored)
+	out.Workspace = mergeSet(base.Workspace, ours.Workspace, theirs.Workspace)
+
+	switch {
+	case ours.Extends == theirs.Extends || theirs.Extends == base.Extends:
+		out.Extends = ours.Extends
+	case ours.Extends == base.Extends:
+		out.Extends = theirs.Extends
+	default:
+		out.Extends = ours.Extends
+		conflicts = append(conflicts, Conflict{Field: "extends", Base: base.Extends, Ours: ours.Extends, Theirs: theirs.Extends})
+	}
+
+	return out, conflicts
+}
+
+// sameEntry reports whether slug has the same source and options in a and b,
+// or is absent from both.
+func sameEntry(a, b *ManifestFile, slug string) bool {
+	sa, inA := a.Skills[slug]
+	sb, inB := b.Skills[slug]
+	if inA != inB {
+		return false
+	}
+	return !inA || (sa == sb && a.SkillOptions(slug).Equal(b.SkillOptions(slug)))
+}
+
+// describeEntry renders a skill entry for conflict messages.
+func describeEntry(mf *ManifestFile, slug string) string {
+	source, ok := mf.Skills[slug]
+	if !ok {
+		return ""
+	}
+	switch opts := mf.SkillOptions(slug); {
+	case opts.Ref != "":
+		source += "@" + opts.Ref
+	case !opts.IsZero():
+		source += " (with options)"
+	}
+	return source
+}
+
+// mergeSet three-way merges string sets: an item is kept if both sides have
+// it, or if one side added it. Removing an item on either side removes it.
+// The result is sorted.
+func mergeSet(base, ours, theirs []string) []string {
+	inBase := toSet(base)
+	inOurs := toSet(ours)
+	inTheirs := toSet(theirs)
+
+	seen := make(map[string]bool)
+	out := []string{}
+	for _, item := range append(append([]string{}, ours...), theirs...) {
+		if seen[item] {
+			continue
+		}
+		seen[item] = true
+		if (inOurs[item] && inTheirs[item]) || !inBase[item] {
+			out = append(out, item)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+func toSet(items []string) map[string]bool {
+	set := make(map[string]bool, len(items))
+	for _, item := range items {
+		set[item] = true
+	}
+	return set
+}
@@ -0,0 +1,103 @@
+package manifest
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMerge_Clean(t *testing.T) {
+	base := New()
+	base.Skills["kept"] = "acme/skills"
+	base.Skills["removed-by-ours"] = "acme/skills"
+	base.Skills["changed-by-theirs"] = "acme/skills"
+	base.Ignored = []string{"old", "shared"}
+
+	ours := New()
+	ours.Skills["kept"] = "acme/skills"
+	ours.Skills["changed-by-theirs"] = "acme/skills"
+	ours.Skills["added-by-ours"] = "acme/ours"
+	ours.Ignored = []string{"shared", "zeta"}
+
+	theirs := New()
+	theirs.Skills["kept"] = "acme/skills"
+	theirs.Skills["removed-by-ours"] = "acme/skills"
+	theirs.Skills["changed-by-theirs"] = "acme/skills"
+	theirs.Options["changed-by-theirs"] = SkillOptions{Ref: "v2"}
+	theirs.Skills["added-by-theirs"] = "acme/theirs"
+	theirs.Ignored = []string{"old", "shared", "alpha"}
+
+	got, conflicts := Merge(base, ours, theirs)
+	if len(conflicts) != 0 {
+		t.Fatalf("unexpected conflicts: %v", conflicts)
+	}
+	wantSkills := map[string]string{
+		"kept":              "acme/skills",
+		"changed-by-theirs": "acme/skills",
+		"added-by-ours":     "acme/ours",
+		"added-by-theirs":   "acme/theirs",
+	}
+	if !reflect.DeepEqual(got.Skills, wantSkills) {
+		t.Errorf("skills = %v, want %v", got.Skills, wantSkills)
+	}
+	if got.SkillOptions("changed-by-theirs").Ref != "v2" {
+		t.Error("their option change was lost")
+	}
+	wantIgnored := []string{"alpha", "shared", "zeta"}
+	if !reflect.DeepEqual(got.Ignored, wantIgnored) {
+		t.Errorf("ignored = %v, want %v", got.Ignored, wantIgnored)
+	}
+}
+
+func TestMerge_Conflicts(t *testing.T) {
+	base := New()
+	base.Skills["edited"] = "acme/skills"
+	base.Skills["deleted"] = "acme/skills"
+
+	ours := New()
+	ours.Skills["edited"] = "acme/fork"
+	ours.Skills["added"] = "acme/ours"
+	ours.Extends = "acme/base@v1"
+
+	theirs := New()
+	theirs.Skills["edited"] = "other/fork"
+	theirs.Skills["deleted"] = "acme/moved"
+	theirs.Skills["added"] = "acme/theirs"
+	theirs.Extends = "acme/base@v2"
+
+	got, conflicts := Merge(base, ours, theirs)
+	var names []string
+	for _, c := range conflicts {
+		if c.Slug != "" {
+			names = append(names, c.Slug)
+		} else {
+			names = append(names, c.Field)
+		}
+	}
+	want := []string{"added", "deleted", "edited", "extends"}
+	if !reflect.DeepEqual(names, want) {
+		t.Fatalf("conflicts = %v, want %v", names, want)
+	}
+
+	// Conflicts resolve to our side
+	if got.Skills["edited"] != "acme/fork" || got.Skills["added"] != "acme/ours" || got.Extends != "acme/base@v1" {
+		t.Errorf("conflicted entries should keep ours: %v %q", got.Skills, got.Extends)
+	}
+	if _, ok := got.Skills["deleted"]; ok {
+		t.Error("deleted entry should stay deleted on our side")
+	}
+	if conflicts[1].Ours != "" || conflicts[1].Theirs != "acme/moved" {
+		t.Errorf("delete/modify conflict = %+v", conflicts[1])
+	}
+}
+
+func TestMerge_NilBase(t *testing.T) {
+	ours := New()
+	ours.Skills["a"] = "acme/skills"
+	theirs := New()
+	theirs.Skills["b"] = "acme/skills"
+
+	got, conflicts := Merge(nil, ours, theirs)
+	if len(conflicts) != 0 || got.SkillCount() != 2 {
+		t.Errorf("Merge(nil, ...) = %v, %v", got.Skills, conflicts)
+	}
+}
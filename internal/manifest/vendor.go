@@ -0,0 +1,28 @@
+package manifest
+
+import (
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// VendorDir is where `skulto save --vendor` copies local-only skills,
+// relative to the project directory.
+const VendorDir = ".skulto/vendor"
+
+// VendorSource returns the manifest source for a skill vendored into
+// VendorDir/<slug>.
+func VendorSource(slug string) string {
+	return "./" + path.Join(VendorDir, slug)
+}
+
+// VendorPath returns the directory a skill is vendored into under dir.
+func VendorPath(dir, slug string) string {
+	return filepath.Join(dir, filepath.FromSlash(VendorDir), slug)
+}
+
+// IsPathSource reports whether a manifest source is a directory relative to
+// the manifest (such as a vendored skill) rather than a repository.
+func IsPathSource(source string) bool {
+	return strings.HasPrefix(source, "./") || strings.HasPrefix(source, "../")
+}
@@ -0,0 +1,132 @@
+package manifest
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/asteroid-belt/skulto/internal/log"
+)
+
+// IsWorkspace reports whether the manifest is a workspace root.
+func (mf *ManifestFile) IsWorkspace() bool {
+	return len(mf.Workspace) > 0
+}
+
+// Members resolves the workspace globs of the manifest in rootDir to member
+// directories, sorted and without duplicates. Matches that are files, the
+// root itself, or outside the root are skipped.
+func (mf *ManifestFile) Members(rootDir string) ([]string, error) {
+	root, err := filepath.Abs(rootDir)
+	if err != nil {
+		return nil, fmt.Errorf("resolve workspace root: %w", err)
+	}
+
+	seen := make(map[string]bool)
+	var members []string
+	for _, pattern := range mf.Workspace {
+		matches, err := filepath.Glob(filepath.Join(root, filepath.FromSlash(pattern)))
+		if err != nil {
+			return nil, fmt.Errorf("workspace pattern %q: %w", pattern, err)
+		}
+		for _, match := range matches {
+			rel, err := filepath.Rel(root, match)
+			if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+				continue
+			}
+			if info, err := os.Stat(match); err != nil || !info.IsDir() {
+				continue
+			}
+			if !seen[match] {
+				seen[match] = true
+				members = append(members, match)
+			}
+		}
+	}
+	sort.Strings(members)
+	return members, nil
+}
+
+// Inherit returns the effective manifest of a workspace member: the member's
+// own entries plus every root skill the member neither lists nor ignores.
+// Member entries win over root entries. A nil member inherits everything.
+func Inherit(root, member *ManifestFile) *ManifestFile {
+	out := New()
+	if member != nil {
+		for slug, source := range member.Skills {
+			out.Skills[slug] = source
+		}
+		for slug, opts := range member.Options {
+			out.Options[slug] = opts
+		}
+		out.Ignored = append([]string{}, member.Ignored...)
+	}
+
+	ignored := make(map[string]bool, len(out.Ignored))
+	for _, slug := range out.Ignored {
+		ignored[slug] = true
+	}
+	for slug, source := range root.Skills {
+		if _, listed := out.Skills[slug]; listed || ignored[slug] {
+			continue
+		}
+		out.Skills[slug] = source
+		if opts := root.SkillOptions(slug); !opts.IsZero() {
+			out.Options[slug] = opts
+		}
+	}
+	return out
+}
+
+// FindWorkspaceRoot looks for the nearest workspace manifest in the parents
+// of dir and returns its directory and manifest if its members include dir.
+// Workspaces further up are not considered, so a nested workspace is its own
+// root. Returns "", nil, nil if dir is not a member. Manifests along the way
+// that can't be read are skipped with a warning.
+func FindWorkspaceRoot(dir string) (string, *ManifestFile, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", nil, fmt.Errorf("resolve directory: %w", err)
+	}
+
+	for parent := filepath.Dir(abs); ; parent = filepath.Dir(parent) {
+		mf, err := Read(parent)
+		if err != nil {
+			log.Printf("skulto: warning: skipping %s while looking for a workspace root: %v\n", Path(parent), err)
+		} else if mf != nil && mf.IsWorkspace() {
+			members, err := mf.Members(parent)
+			if err != nil {
+				return "", nil, err
+			}
+			for _, member := range members {
+				if member == abs {
+					return parent, mf, nil
+				}
+			}
+			return "", nil, nil
+		}
+		if filepath.Dir(parent) == parent {
+			return "", nil, nil
+		}
+	}
+}
+
+// DropInherited removes skills a workspace member would inherit from root
+// unchanged, so saving a member does not copy root entries into it. Skills
+// the member's existing manifest already lists are kept.
+func (mf *ManifestFile) DropInherited(root, existing *ManifestFile) {
+	for slug, source := range root.Skills {
+		if mf.Skills[slug] != source {
+			continue
+		}
+		if existing != nil {
+			if _, listed := existing.Skills[slug]; listed {
+				continue
+			}
+		}
+		delete(mf.Skills, slug)
+		delete(mf.Options, slug)
+	}
+}
@@ -0,0 +1,180 @@
+package manifest
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestWorkspace_RoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	mf := New()
+	mf.Workspace = []string{"packages/*", "apps/web"}
+	if err := Write(dir, mf); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	got, err := Read(dir)
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if !got.IsWorkspace() {
+		t.Fatal("expected workspace manifest")
+	}
+	if !ManifestEqual(mf, got) {
+		t.Errorf("round trip changed workspace: %v", got.Workspace)
+	}
+}
+
+func TestMembers(t *testing.T) {
+	root := t.TempDir()
+	for _, d := range []string{"packages/api", "packages/web", "apps/cli"} {
+		if err := os.MkdirAll(filepath.Join(root, d), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(root, "packages", "README.md"), []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	mf := New()
+	mf.Workspace = []string{"packages/*", "packages/api", ".", "../*"}
+	members, err := mf.Members(root)
+	if err != nil {
+		t.Fatalf("Members() error = %v", err)
+	}
+	want := []string{filepath.Join(root, "packages", "api"), filepath.Join(root, "packages", "web")}
+	if !reflect.DeepEqual(members, want) {
+		t.Errorf("Members() = %v, want %v", members, want)
+	}
+}
+
+func TestInherit(t *testing.T) {
+	root := New()
+	root.Skills["shared"] = "acme/skills"
+	root.Skills["overridden"] = "acme/skills"
+	root.Skills["skipped"] = "acme/skills"
+	root.Options["shared"] = SkillOptions{Ref: "v1"}
+
+	member := New()
+	member.Skills["own"] = "acme/other"
+	member.Skills["overridden"] = "acme/fork"
+	member.Ignored = []string{"skipped"}
+
+	got := Inherit(root, member)
+	want := map[string]string{"shared": "acme/skills", "overridden": "acme/fork", "own": "acme/other"}
+	if !reflect.DeepEqual(got.Skills, want) {
+		t.Errorf("Inherit() skills = %v, want %v", got.Skills, want)
+	}
+	if got.SkillOptions("shared").Ref != "v1" {
+		t.Errorf("inherited options lost: %+v", got.SkillOptions("shared"))
+	}
+	if len(member.Skills) != 2 {
+		t.Error("Inherit() modified the member manifest")
+	}
+
+	if all := Inherit(root, nil); all.SkillCount() != 3 {
+		t.Errorf("Inherit(nil) skill count = %d, want 3", all.SkillCount())
+	}
+}
+
+func TestFindWorkspaceRoot(t *testing.T) {
+	root := t.TempDir()
+	member := filepath.Join(root, "packages", "api")
+	other := filepath.Join(root, "tools")
+	for _, d := range []string{member, other} {
+		if err := os.MkdirAll(d, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	mf := New()
+	mf.Workspace = []string{"packages/*"}
+	if err := Write(root, mf); err != nil {
+		t.Fatal(err)
+	}
+
+	gotDir, gotMf, err := FindWorkspaceRoot(member)
+	if err != nil {
+		t.Fatalf("FindWorkspaceRoot() error = %v", err)
+	}
+	if gotDir != root || gotMf == nil {
+		t.Errorf("FindWorkspaceRoot() = %q, want %q", gotDir, root)
+	}
+
+	gotDir, gotMf, err = FindWorkspaceRoot(other)
+	if err != nil || gotDir != "" || gotMf != nil {
+		t.Errorf("FindWorkspaceRoot(non-member) = %q, %v, %v", gotDir, gotMf, err)
+	}
+}
+
+func TestFindWorkspaceRoot_NearestWorkspaceOnly(t *testing.T) {
+	outer := t.TempDir()
+	inner := filepath.Join(outer, "vendor", "mono")
+	member := filepath.Join(inner, "packages", "api")
+	if err := os.MkdirAll(member, 0755); err != nil {
+		t.Fatal(err)
+	}
+	outerMf := New()
+	outerMf.Workspace = []string{"vendor/mono/packages/*"}
+	if err := Write(outer, outerMf); err != nil {
+		t.Fatal(err)
+	}
+	innerMf := New()
+	innerMf.Workspace = []string{"apps/*"}
+	if err := Write(inner, innerMf); err != nil {
+		t.Fatal(err)
+	}
+
+	// The inner workspace doesn't list member, and the outer one is not consulted
+	gotDir, gotMf, err := FindWorkspaceRoot(member)
+	if err != nil || gotDir != "" || gotMf != nil {
+		t.Errorf("FindWorkspaceRoot() = %q, %v, %v, want no root", gotDir, gotMf, err)
+	}
+}
+
+func TestFindWorkspaceRoot_SkipsUnreadableManifests(t *testing.T) {
+	root := t.TempDir()
+	member := filepath.Join(root, "packages", "api")
+	if err := os.MkdirAll(member, 0755); err != nil {
+		t.Fatal(err)
+	}
+	mf := New()
+	mf.Workspace = []string{"packages/*"}
+	if err := Write(root, mf); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(Path(filepath.Join(root, "packages")), []byte("{"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	gotDir, gotMf, err := FindWorkspaceRoot(member)
+	if err != nil {
+		t.Fatalf("FindWorkspaceRoot() error = %v", err)
+	}
+	if gotDir != root || gotMf == nil {
+		t.Errorf("FindWorkspaceRoot() = %q, want %q", gotDir, root)
+	}
+}
+
+func TestDropInherited(t *testing.T) {
+	root := New()
+	root.Skills["shared"] = "acme/skills"
+	root.Skills["pinned"] = "acme/skills"
+	root.Skills["forked"] = "acme/skills"
+
+	existing := New()
+	existing.Skills["pinned"] = "acme/skills"
+
+	mf := New()
+	mf.Skills["shared"] = "acme/skills"
+	mf.Skills["pinned"] = "acme/skills"
+	mf.Skills["forked"] = "acme/fork"
+	mf.Skills["own"] = "acme/other"
+
+	mf.DropInherited(root, existing)
+	want := map[string]string{"pinned": "acme/skills", "forked": "acme/fork", "own": "acme/other"}
+	if !reflect.DeepEqual(mf.Skills, want) {
+		t.Errorf("DropInherited() skills = %v, want %v", mf.Skills, want)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"os"
 	"time"
 
 	"github.com/asteroid-belt/skulto/internal/db"
@@ -62,6 +63,9 @@ type SkillResponse struct {
 	Stars       int             `json:"stars"`
 	IsInstalled bool            `json:"is_installed"`
 	Rank        float64         `json:"rank,omitempty"`
+
+	// Compatibility is set by skulto_get_skill from the skill's frontmatter
+	Compatibility *installer.Compatibility `json:"compatibility,omitempty"`
 }
 
 // SourceResponse represents a source repository in MCP responses.
@@ -99,6 +103,7 @@ type InstallResult struct {
 	SecurityStatus    string                 `json:"security_status,omitempty"`    // "CLEAN" or "QUARANTINED"
 	ThreatLevel       string                 `json:"threat_level,omitempty"`       // "NONE", "LOW", "MEDIUM", "HIGH", "CRITICAL"
 	ThreatSummary     string                 `json:"threat_summary,omitempty"`     // Human-readable summary
+	Dependencies      []string               `json:"dependencies,omitempty"`       // Required skills installed alongside
 }
 
 // DetectedPlatformInfo describes a detected platform returned to the LLM for user selection.
@@ -244,6 +249,8 @@ func (s *Server) handleGetSkill(ctx context.Context, req mcp.CallToolRequest) (*
 	_ = s.db.UpdateSkill(skill)
 
 	resp := toSkillResponseWithDB(skill, true, s.db)
+	compat := installer.CheckCompatibility(skill)
+	resp.Compatibility = &compat
 
 	data, err := json.Marshal(resp)
 	if err != nil {
@@ -424,6 +431,25 @@ func (s *Server) handleInstall(ctx context.Context, req mcp.CallToolRequest) (*m
 		}
 	}
 
+	// Parse optional scope - default to project for MCP (local to current workspace)
+	scopes := []installer.InstallScope{installer.ScopeProject}
+	scopeArg, _ := req.Params.Arguments["scope"].(string)
+	if scope := installer.InstallScope(scopeArg); scope == installer.ScopeGlobal || scope == installer.ScopeProject {
+		scopes = []installer.InstallScope{scope}
+	}
+
+	// Without platforms, use the locations saved for this project
+	if len(platforms) == 0 {
+		if cwd, err := os.Getwd(); err == nil {
+			if saved, ok := s.installService.ProjectPreferenceOptions(cwd); ok {
+				platforms = saved.Platforms
+				if scopeArg == "" {
+					scopes = saved.Scopes
+				}
+			}
+		}
+	}
+
 	// When no platforms specified, use detection to find available platforms
 	if len(platforms) == 0 {
 		detected := detect.DetectAll()
@@ -459,15 +485,6 @@ func (s *Server) handleInstall(ctx context.Context, req mcp.CallToolRequest) (*m
 		}
 	}
 
-	// Parse optional scope - default to project for MCP (local to current workspace)
-	scopes := []installer.InstallScope{installer.ScopeProject}
-	if scopeArg, ok := req.Params.Arguments["scope"].(string); ok && scopeArg != "" {
-		scope := installer.InstallScope(scopeArg)
-		if scope == installer.ScopeGlobal || scope == installer.ScopeProject {
-			scopes = []installer.InstallScope{scope}
-		}
-	}
-
 	// Build install options
 	opts := installer.InstallOptions{
 		Platforms: platforms,
@@ -495,6 +512,7 @@ func (s *Server) handleInstall(ctx context.Context, req mcp.CallToolRequest) (*m
 		SecurityStatus: string(result.Skill.SecurityStatus),
 		ThreatLevel:    string(result.Scan.ThreatLevel),
 		ThreatSummary:  result.Scan.ThreatSummary,
+		Dependencies:   result.Dependencies,
 	}
 
 	data, _ := json.Marshal(installResult)
@@ -525,6 +543,7 @@ func (s *Server) handleUninstall(ctx context.Context, req mcp.CallToolRequest) (
 
 	// Parse optional scope
 	scopeArg, _ := req.Params.Arguments["scope"].(string)
+	force, _ := req.Params.Arguments["force"].(bool)
 
 	// Get current install locations
 	locations, err := s.installService.GetInstallLocations(ctx, slug)
@@ -567,8 +586,10 @@ func (s *Server) handleUninstall(ctx context.Context, req mcp.CallToolRequest) (
 		return mcp.NewToolResultError("no matching installation locations found"), nil
 	}
 
-	// Perform uninstallation (telemetry tracked via InstallService)
-	if err := s.installService.Uninstall(ctx, slug, toUninstall); err != nil {
+	// Perform uninstallation (telemetry tracked via InstallService), recording
+	// it so 'skulto undo' can reverse it
+	entry, err := s.installService.UninstallJournaled(ctx, slug, toUninstall, force)
+	if err != nil {
 		s.trackToolCall("skulto_uninstall", start, false)
 		return mcp.NewToolResultError(fmt.Sprintf("failed to uninstall: %v", err)), nil
 	}
@@ -577,6 +598,9 @@ func (s *Server) handleUninstall(ctx context.Context, req mcp.CallToolRequest) (
 		Success: true,
 		Message: fmt.Sprintf("Skill '%s' uninstalled from %d location(s)", slug, len(toUninstall)),
 	}
+	if entry != nil {
+		result.Message += "; run 'skulto undo' to reverse this"
+	}
 
 	data, _ := json.Marshal(result)
 	s.trackToolCall("skulto_uninstall", start, true)
@@ -790,12 +814,7 @@ func (s *Server) handleAdd(ctx context.Context, req mcp.CallToolRequest) (*mcp.C
 	}
 
 	// Create scraper and sync
-	scraperCfg := scraper.ScraperConfig{
-		Token:        s.cfg.GitHub.Token,
-		DataDir:      s.cfg.BaseDir,
-		RepoCacheTTL: s.cfg.GitHub.RepoCacheTTL,
-		UseGitClone:  s.cfg.GitHub.UseGitClone,
-	}
+	scraperCfg := scraper.ScraperConfigFromConfig(s.cfg)
 	sc := scraper.NewScraperWithConfig(scraperCfg, s.db)
 
 	syncCtx, cancel := context.WithTimeout(ctx, 5*time.Minute)
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/asteroid-belt/skulto/internal/config"
+	"github.com/asteroid-belt/skulto/internal/installer"
 	"github.com/asteroid-belt/skulto/internal/models"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/stretchr/testify/assert"
@@ -132,6 +133,8 @@ func TestHandleGetSkill(t *testing.T) {
 
 		assert.Equal(t, "test-react-hooks", skill.Slug)
 		assert.Contains(t, skill.Content, "# React Hooks")
+		require.NotNil(t, skill.Compatibility)
+		assert.True(t, skill.Compatibility.VersionOK)
 	})
 
 	t.Run("get skill updates viewed_at", func(t *testing.T) {
@@ -638,6 +641,25 @@ func TestHandleInstall(t *testing.T) {
 		require.True(t, ok)
 		assert.Contains(t, textContent.Text, "cannot install skill without source")
 	})
+
+	t.Run("install uses saved project preference without platforms", func(t *testing.T) {
+		dir := t.TempDir()
+		t.Chdir(dir)
+		require.NoError(t, server.installService.SaveProjectPreference(dir, []string{"claude"}, installer.ScopeProject))
+
+		req := mcp.CallToolRequest{}
+		req.Params.Arguments = map[string]any{
+			"slug": "test-react-hooks",
+		}
+
+		// Reaches the install (no platform selection request) and fails on the missing source
+		result, err := server.handleInstall(ctx, req)
+		require.NoError(t, err)
+		assert.True(t, result.IsError)
+		textContent, ok := result.Content[0].(mcp.TextContent)
+		require.True(t, ok)
+		assert.Contains(t, textContent.Text, "cannot install skill without source")
+	})
 }
 
 func TestHandleUninstall(t *testing.T) {
@@ -74,16 +74,16 @@ func getRecentTool() mcp.Tool {
 // installTool returns the skulto_install tool definition.
 func installTool() mcp.Tool {
 	return mcp.NewTool("skulto_install",
-		mcp.WithDescription("Install a skill to Claude Code. Creates a symlink in the project's skills directory by default. When platforms are not specified, detects available platforms on the system. If multiple are found, returns them for selection instead of installing. Re-call with the chosen platforms to complete installation."),
+		mcp.WithDescription("Install a skill to Claude Code. Creates a symlink in the project's skills directory by default. When platforms are not specified, detects available platforms on the system. If multiple are found, returns them for selection instead of installing. Re-call with the chosen platforms to complete installation. Skills listed in the skill's requires: frontmatter are installed too."),
 		mcp.WithString("slug",
 			mcp.Required(),
 			mcp.Description("The skill's unique slug identifier"),
 		),
 		mcp.WithArray("platforms",
-			mcp.Description("Platforms to install to. Options: claude, cursor, windsurf, copilot, codex, opencode. Default: user's configured platforms."),
+			mcp.Description("Platforms to install to. Options: claude, cursor, windsurf, copilot, codex, opencode. Default: platforms saved for the project (skulto prefs), else detected platforms."),
 		),
 		mcp.WithString("scope",
-			mcp.Description("Installation scope: 'global' (user-wide) or 'project' (current directory). Default: project."),
+			mcp.Description("Installation scope: 'global' (user-wide) or 'project' (current directory). Default: the project's saved scope, else project."),
 		),
 	)
 }
@@ -102,6 +102,9 @@ func uninstallTool() mcp.Tool {
 		mcp.WithString("scope",
 			mcp.Description("Scope to uninstall from: 'global', 'project', or 'all'. Default: all."),
 		),
+		mcp.WithBoolean("force",
+			mcp.Description("Uninstall even if other installed skills require this skill. Default: false."),
+		),
 	)
 }
 
@@ -140,7 +143,7 @@ func checkTool() mcp.Tool {
 // addTool returns the skulto_add tool definition.
 func addTool() mcp.Tool {
 	return mcp.NewTool("skulto_add",
-		mcp.WithDescription("Add a skill repository and sync its skills. Supports multiple URL formats: owner/repo, https://github.com/owner/repo, https://github.com/owner/repo.git, git@github.com:owner/repo.git"),
+		mcp.WithDescription("Add a skill repository and sync its skills. Supports multiple URL formats: owner/repo, https://github.com/owner/repo, https://github.com/owner/repo.git, git@github.com:owner/repo.git, and https or ssh URLs on other git hosts (GitLab, Bitbucket, Gitea)"),
 		mcp.WithString("url",
 			mcp.Required(),
 			mcp.Description("Repository URL in any supported format (e.g., 'owner/repo' or full GitHub URL)"),
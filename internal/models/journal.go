@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+// JournalEntry records one destructive command (uninstall, remove, manifest
+// write) with enough state to reverse it via `skulto undo`.
+type JournalEntry struct {
+	ID        uint       `gorm:"primaryKey;autoIncrement" json:"id"`
+	Command   string     `gorm:"size:50;index" json:"command"`
+	Summary   string     `gorm:"size:500" json:"summary"`
+	Payload   string     `gorm:"type:text" json:"-"` // JSON-encoded journal.Operation
+	CreatedAt time.Time  `gorm:"index" json:"created_at"`
+	UndoneAt  *time.Time `json:"undone_at,omitempty"`
+}
+
+// TableName specifies the table name for GORM.
+func (JournalEntry) TableName() string {
+	return "journal_entries"
+}
+
+// IsUndone returns true if the entry has already been reversed.
+func (e *JournalEntry) IsUndone() bool {
+	return e.UndoneAt != nil
+}
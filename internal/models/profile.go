@@ -0,0 +1,58 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// Profile is a named set of skills with target platforms and scope that can
+// be applied to a project with `skulto profile apply`.
+type Profile struct {
+	Name        string    `gorm:"primaryKey;size:100" json:"name"`
+	Description string    `gorm:"size:500" json:"description,omitempty"`
+	Skills      string    `gorm:"type:text" json:"skills"`              // Comma-delimited slugs
+	Platforms   string    `gorm:"type:text" json:"platforms,omitempty"` // Comma-delimited; empty = remembered locations
+	Scope       string    `gorm:"size:20;default:project" json:"scope"` // "global" or "project"
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// TableName specifies the table name for GORM.
+func (Profile) TableName() string {
+	return "profiles"
+}
+
+// GetSkills returns the profile's skill slugs.
+func (p *Profile) GetSkills() []string {
+	return splitList(p.Skills)
+}
+
+// SetSkills sets the profile's skill slugs.
+func (p *Profile) SetSkills(slugs []string) {
+	p.Skills = strings.Join(slugs, ",")
+}
+
+// GetPlatforms returns the profile's platform IDs.
+func (p *Profile) GetPlatforms() []string {
+	return splitList(p.Platforms)
+}
+
+// SetPlatforms sets the profile's platform IDs.
+func (p *Profile) SetPlatforms(platforms []string) {
+	p.Platforms = strings.Join(platforms, ",")
+}
+
+// splitList splits a comma-delimited string, trimming whitespace and empties.
+func splitList(s string) []string {
+	if s == "" {
+		return []string{}
+	}
+	parts := strings.Split(s, ",")
+	out := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if trimmed := strings.TrimSpace(part); trimmed != "" {
+			out = append(out, trimmed)
+		}
+	}
+	return out
+}
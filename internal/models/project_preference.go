@@ -0,0 +1,30 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// ProjectPreference stores the platforms and scope used for non-interactive
+// installs in one project directory (`sync -y`, `install -y`, MCP install).
+type ProjectPreference struct {
+	Path      string    `gorm:"primaryKey;size:1024" json:"path"`     // Absolute project directory
+	Platforms string    `gorm:"type:text" json:"platforms"`           // Comma-delimited platform IDs
+	Scope     string    `gorm:"size:20;default:project" json:"scope"` // "global" or "project"
+	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// TableName specifies the table name for GORM.
+func (ProjectPreference) TableName() string {
+	return "project_preferences"
+}
+
+// GetPlatforms returns the preferred platform IDs.
+func (p *ProjectPreference) GetPlatforms() []string {
+	return splitList(p.Platforms)
+}
+
+// SetPlatforms sets the preferred platform IDs.
+func (p *ProjectPreference) SetPlatforms(platforms []string) {
+	p.Platforms = strings.Join(platforms, ",")
+}
@@ -87,14 +87,15 @@ func (s *Skill) ComputeContentHash() string {
 
 // SkillMeta contains extracted metadata from SKILL.md frontmatter.
 type SkillMeta struct {
-	Name        string   `yaml:"name"`
-	Description string   `yaml:"description"`
-	Version     string   `yaml:"version"`
-	Author      string   `yaml:"author"`
-	License     string   `yaml:"license"`
-	Tags        []string `yaml:"tags"`
-	Platforms   []string `yaml:"platforms"`
-	MinVersion  string   `yaml:"min_version"`
+	Name        string             `yaml:"name"`
+	Description string             `yaml:"description"`
+	Version     string             `yaml:"version"`
+	Author      string             `yaml:"author"`
+	License     string             `yaml:"license"`
+	Tags        []string           `yaml:"tags"`
+	Platforms   []string           `yaml:"platforms"`
+	MinVersion  string             `yaml:"min_version"`
+	Requires    []SkillRequirement `yaml:"requires"`
 }
 
 // SkillStats provides aggregate statistics.
@@ -0,0 +1,22 @@
+package models
+
+// SkillRequirement is a dependency declared in a skill's `requires:` frontmatter.
+// Entries may be a bare slug ("changelog") or a mapping with an optional
+// source ("owner/repo") and semver version constraint (">=1.2").
+type SkillRequirement struct {
+	Slug    string `yaml:"slug" json:"slug"`
+	Source  string `yaml:"source,omitempty" json:"source,omitempty"`
+	Version string `yaml:"version,omitempty" json:"version,omitempty"`
+}
+
+// String renders the requirement as slug[@source][ version].
+func (r SkillRequirement) String() string {
+	s := r.Slug
+	if r.Source != "" {
+		s += "@" + r.Source
+	}
+	if r.Version != "" {
+		s += " " + r.Version
+	}
+	return s
+}
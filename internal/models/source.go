@@ -1,17 +1,21 @@
 package models
 
 import (
+	"strings"
 	"time"
 
 	"gorm.io/gorm"
 )
 
-// Source represents a GitHub repository containing skills.
+// Source represents a git repository containing skills. Repositories on
+// GitHub use the account name as Owner; on other hosts Owner starts with the
+// host ("gitlab.example.com/team"), so ID stays Owner/Repo everywhere.
 type Source struct {
 	ID       string `gorm:"primaryKey;size:255" json:"id"` // owner/repo
-	Owner    string `gorm:"size:100;index" json:"owner"`
+	Owner    string `gorm:"size:255;index" json:"owner"`
 	Repo     string `gorm:"size:100;index" json:"repo"`
 	FullName string `gorm:"size:255" json:"full_name"`
+	Host     string `gorm:"size:255" json:"host,omitempty"` // Git host, or "local"; empty means github.com
 
 	// Repository metadata
 	Description string `gorm:"size:1000" json:"description"`
@@ -28,11 +32,22 @@ type Source struct {
 	LastCommitSHA string `gorm:"size:64" json:"last_commit_sha"`
 	SkillCount    int    `gorm:"default:0" json:"skill_count"`
 
+	// What to scan, set with `skulto add --ref --path`
+	Ref       string `gorm:"size:255" json:"ref,omitempty"`        // Branch or tag to follow; empty follows the default branch
+	SkillPath string `gorm:"size:500" json:"skill_path,omitempty"` // Subdirectory to scan; empty scans the whole repository
+
+	// Keep the clone's full history for `skulto log` (set with `skulto add --full-history`)
+	FullHistory bool `gorm:"default:false" json:"full_history,omitempty"`
+
 	// Scraping metadata
 	Priority   int  `gorm:"default:5;index" json:"priority"` // 1-10, higher = more important
 	IsCurated  bool `gorm:"default:false" json:"is_curated"`
 	IsOfficial bool `gorm:"default:false" json:"is_official"`
 
+	// Set when the source is listed in a registry index (see `skulto registry`)
+	Registry string `gorm:"size:500;index" json:"registry,omitempty"` // Index URL or file the listing came from
+	Tags     string `gorm:"size:500" json:"tags,omitempty"`           // Comma-separated tags from the listing
+
 	// License information
 	LicenseType string `gorm:"size:50" json:"license_type"`  // Detected SPDX identifier (e.g., "MIT", "Apache-2.0")
 	LicenseURL  string `gorm:"size:500" json:"license_url"`  // Direct link to LICENSE file in repository
@@ -53,6 +68,19 @@ func (Source) TableName() string {
 	return "sources"
 }
 
+// SourceHostLocal is the Host of sources read straight from a local
+// directory instead of a clone. Their CloneURL is the directory's file:// URL.
+const SourceHostLocal = "local"
+
+// LocalDir returns the live directory of a local source, or "" for sources
+// that are cloned.
+func (s *Source) LocalDir() string {
+	if s == nil || s.Host != SourceHostLocal {
+		return ""
+	}
+	return strings.TrimPrefix(s.CloneURL, "file://")
+}
+
 // SourceType categorizes repositories.
 type SourceType string
 
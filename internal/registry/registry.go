@@ -0,0 +1,259 @@
+// Package registry reads skill registry indexes: static JSON files, served
+// over https or read from disk, listing source repositories with a trust
+// tier, description and tags. Organizations host their own index to publish
+// the repositories their teams should use; skulto merges each configured
+// index into its sources.
+//
+// An index looks like:
+//
+//	{
+//	  "version": 1,
+//	  "name": "Acme skills",
+//	  "sources": [
+//	    {
+//	      "url": "https://gitlab.acme.dev/platform/skills",
+//	      "tier": "curated",
+//	      "description": "Platform team conventions",
+//	      "tags": ["go", "kubernetes"],
+//	      "ref": "stable",
+//	      "path": "skills",
+//	      "priority": 9
+//	    }
+//	  ]
+//	}
+//
+// url accepts anything `skulto add` does except local directories. tier is
+// "official", "curated" or "community" (the default); ref, path and
+// priority are optional.
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/asteroid-belt/skulto/internal/db"
+	"github.com/asteroid-belt/skulto/internal/models"
+	"github.com/asteroid-belt/skulto/internal/scraper"
+)
+
+// FormatVersion is the newest index format version this package reads.
+const FormatVersion = 1
+
+const (
+	fetchTimeout = 30 * time.Second
+	maxIndexSize = 10 << 20 // 10MB
+)
+
+// httpClient fetches indexes; tests swap in one that trusts their server.
+var httpClient = http.DefaultClient
+
+// Default priorities by tier, matching the built-in seeds.
+var tierPriority = map[models.SourceType]int{
+	models.SourceTypeOfficial:  10,
+	models.SourceTypeCurated:   8,
+	models.SourceTypeCommunity: 5,
+}
+
+// Index is a registry index file.
+type Index struct {
+	Version int     `json:"version"`
+	Name    string  `json:"name,omitempty"`
+	Sources []Entry `json:"sources"`
+}
+
+// Entry is one source listed in an index.
+type Entry struct {
+	URL         string            `json:"url"`
+	Tier        models.SourceType `json:"tier,omitempty"`
+	Description string            `json:"description,omitempty"`
+	Tags        []string          `json:"tags,omitempty"`
+	Ref         string            `json:"ref,omitempty"`
+	Path        string            `json:"path,omitempty"`
+	Priority    int               `json:"priority,omitempty"` // 1-10; defaults by tier
+}
+
+// Fetch reads the index at location: an https URL, a file:// URL or a
+// local path. Plain http is refused, since an index decides which
+// repositories are trusted.
+func Fetch(ctx context.Context, location string) (*Index, error) {
+	var data []byte
+	var err error
+	switch {
+	case strings.HasPrefix(location, "http://"):
+		return nil, fmt.Errorf("fetch %s: registry indexes must be served over https", location)
+	case strings.HasPrefix(location, "https://"):
+		data, err = fetchHTTP(ctx, location)
+	default:
+		data, err = os.ReadFile(strings.TrimPrefix(location, "file://"))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("fetch %s: %w", location, err)
+	}
+	return Parse(data)
+}
+
+// fetchHTTP downloads an index.
+func fetchHTTP(ctx context.Context, url string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, fetchTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s", resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxIndexSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxIndexSize {
+		return nil, fmt.Errorf("index is larger than %d bytes", maxIndexSize)
+	}
+	return data, nil
+}
+
+// Parse decodes and checks an index.
+func Parse(data []byte) (*Index, error) {
+	var index Index
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil, fmt.Errorf("parse index: %w", err)
+	}
+	if index.Version > FormatVersion {
+		return nil, fmt.Errorf("index version %d is newer than supported version %d; upgrade skulto", index.Version, FormatVersion)
+	}
+	for i := range index.Sources {
+		e := &index.Sources[i]
+		if strings.TrimSpace(e.URL) == "" {
+			return nil, fmt.Errorf("source %d has no url", i+1)
+		}
+		switch e.Tier {
+		case "", models.SourceTypeOfficial, models.SourceTypeCurated, models.SourceTypeCommunity:
+		default:
+			return nil, fmt.Errorf("source %s: unknown tier %q (want official, curated or community)", e.URL, e.Tier)
+		}
+		if e.Priority < 0 || e.Priority > 10 {
+			return nil, fmt.Errorf("source %s: priority %d is outside 1-10", e.URL, e.Priority)
+		}
+		path, err := scraper.NormalizeSkillPath(e.Path)
+		if err != nil {
+			return nil, fmt.Errorf("source %s: %w", e.URL, err)
+		}
+		e.Path = path
+	}
+	return &index, nil
+}
+
+// MergeResult lists what Merge changed.
+type MergeResult struct {
+	Added   []string // Sources new to the database
+	Updated []string // Existing sources whose listing was applied
+	Removed []string // Sources no longer listed, demoted to ordinary sources
+	Skipped []string // Entries that could not be used, with the reason
+}
+
+// Merge records index, fetched from location, in the sources table. Listed
+// sources are added if new; new and existing ones get the entry's tier
+// (official and curated sources are marked IsOfficial and IsCurated),
+// priority, description and tags, which outrank the built-in seeds. Sources
+// this location listed before but no longer does are demoted, not removed:
+// their skills and installs stay, and built-in seeds fall back to their
+// seed tier, as the next pull would set it.
+// A ref or path in the entry only applies to sources it adds, so a user's
+// own `skulto add --ref/--path` choice wins.
+func Merge(database *db.DB, location string, index *Index) (*MergeResult, error) {
+	result := &MergeResult{}
+	listed := make(map[string]bool, len(index.Sources))
+
+	for _, e := range index.Sources {
+		parsed, err := scraper.ParseRepositoryURL(e.URL)
+		if err != nil {
+			result.Skipped = append(result.Skipped, fmt.Sprintf("%s: %v", e.URL, err))
+			continue
+		}
+		if parsed.LocalDir() != "" {
+			result.Skipped = append(result.Skipped, fmt.Sprintf("%s: local directories can't be listed in a registry", e.URL))
+			continue
+		}
+
+		source, err := database.GetSource(parsed.ID)
+		if err != nil {
+			return result, fmt.Errorf("check source %s: %w", parsed.ID, err)
+		}
+		if source == nil {
+			source = parsed
+			source.Ref = e.Ref
+			source.SkillPath = e.Path
+			result.Added = append(result.Added, source.ID)
+		} else {
+			result.Updated = append(result.Updated, source.ID)
+		}
+		applyEntry(source, location, e)
+		if err := database.UpsertSource(source); err != nil {
+			return result, fmt.Errorf("save source %s: %w", source.ID, err)
+		}
+		listed[source.ID] = true
+	}
+
+	sources, err := database.ListSources()
+	if err != nil {
+		return result, fmt.Errorf("list sources: %w", err)
+	}
+	for i := range sources {
+		source := &sources[i]
+		if source.Registry != location || listed[source.ID] {
+			continue
+		}
+		source.Registry = ""
+		source.Tags = ""
+		source.IsOfficial = false
+		source.IsCurated = false
+		source.Priority = tierPriority[models.SourceTypeCommunity]
+		scraper.ApplySeedTier(source)
+		if err := database.UpsertSource(source); err != nil {
+			return result, fmt.Errorf("save source %s: %w", source.ID, err)
+		}
+		result.Removed = append(result.Removed, source.ID)
+	}
+	return result, nil
+}
+
+// applyEntry sets a source's listing fields from e.
+func applyEntry(source *models.Source, location string, e Entry) {
+	tier := e.Tier
+	if tier == "" {
+		tier = models.SourceTypeCommunity
+	}
+	source.Registry = location
+	source.IsOfficial = tier == models.SourceTypeOfficial
+	source.IsCurated = tier == models.SourceTypeOfficial || tier == models.SourceTypeCurated
+	source.Priority = e.Priority
+	if source.Priority == 0 {
+		source.Priority = tierPriority[tier]
+	}
+	if e.Description != "" {
+		source.Description = e.Description
+	}
+
+	tags := make([]string, 0, len(e.Tags))
+	for _, t := range e.Tags {
+		if t = strings.ToLower(strings.TrimSpace(t)); t != "" {
+			tags = append(tags, t)
+		}
+	}
+	source.Tags = strings.Join(tags, ",")
+}
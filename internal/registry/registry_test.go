@@ -0,0 +1,194 @@
+package registry
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/asteroid-belt/skulto/internal/db"
+	"github.com/asteroid-belt/skulto/internal/models"
+	"github.com/asteroid-belt/skulto/internal/scraper"
+)
+
+func setupTestDB(t *testing.T) *db.DB {
+	t.Helper()
+	database, err := db.New(db.Config{Path: filepath.Join(t.TempDir(), "test.db")})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = database.Close() })
+	return database
+}
+
+const testIndex = `{
+  "version": 1,
+  "name": "Acme skills",
+  "sources": [
+    {"url": "https://gitlab.acme.dev/platform/skills", "tier": "curated", "description": "Platform conventions", "tags": ["Go", " kubernetes "], "ref": "stable", "path": "skills/"},
+    {"url": "acme/official-skills", "tier": "official"},
+    {"url": "acme/community-skills", "priority": 3}
+  ]
+}`
+
+// serveIndex serves body as a registry index and returns its URL.
+func serveIndex(t *testing.T, body *string) string {
+	t.Helper()
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/index.json" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(*body))
+	}))
+	t.Cleanup(srv.Close)
+	client := httpClient
+	httpClient = srv.Client()
+	t.Cleanup(func() { httpClient = client })
+	return srv.URL + "/index.json"
+}
+
+func TestFetch_HTTPAndFile(t *testing.T) {
+	body := testIndex
+	url := serveIndex(t, &body)
+
+	index, err := Fetch(context.Background(), url)
+	require.NoError(t, err)
+	assert.Equal(t, "Acme skills", index.Name)
+	require.Len(t, index.Sources, 3)
+	assert.Equal(t, "skills", index.Sources[0].Path)
+
+	path := filepath.Join(t.TempDir(), "index.json")
+	require.NoError(t, os.WriteFile(path, []byte(testIndex), 0o644))
+	for _, location := range []string{path, "file://" + path} {
+		index, err := Fetch(context.Background(), location)
+		require.NoError(t, err, location)
+		assert.Len(t, index.Sources, 3)
+	}
+
+	_, err = Fetch(context.Background(), url+".missing")
+	assert.ErrorContains(t, err, "404")
+
+	_, err = Fetch(context.Background(), "http://"+strings.TrimPrefix(url, "https://"))
+	assert.ErrorContains(t, err, "https")
+}
+
+func TestParse_Rejects(t *testing.T) {
+	tests := map[string]string{
+		"bad json":     `{`,
+		"newer":        `{"version": 2, "sources": []}`,
+		"no url":       `{"version": 1, "sources": [{"tier": "curated"}]}`,
+		"unknown tier": `{"version": 1, "sources": [{"url": "a/b", "tier": "gold"}]}`,
+		"priority":     `{"version": 1, "sources": [{"url": "a/b", "priority": 11}]}`,
+		"escaping":     `{"version": 1, "sources": [{"url": "a/b", "path": "../x"}]}`,
+	}
+	for name, data := range tests {
+		_, err := Parse([]byte(data))
+		assert.Error(t, err, name)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	database := setupTestDB(t)
+	body := testIndex
+	url := serveIndex(t, &body)
+
+	// A source the user added themselves, with their own ref
+	mine := models.Source{ID: "acme/official-skills", Owner: "acme", Repo: "official-skills", FullName: "acme/official-skills", Ref: "main", Priority: 5}
+	require.NoError(t, database.CreateSource(&mine))
+
+	index, err := Fetch(context.Background(), url)
+	require.NoError(t, err)
+	result, err := Merge(database, url, index)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"gitlab.acme.dev/platform/skills", "acme/community-skills"}, result.Added)
+	assert.Equal(t, []string{"acme/official-skills"}, result.Updated)
+	assert.Empty(t, result.Removed)
+
+	curated, err := database.GetSource("gitlab.acme.dev/platform/skills")
+	require.NoError(t, err)
+	require.NotNil(t, curated)
+	assert.Equal(t, url, curated.Registry)
+	assert.True(t, curated.IsCurated)
+	assert.False(t, curated.IsOfficial)
+	assert.Equal(t, 8, curated.Priority)
+	assert.Equal(t, "Platform conventions", curated.Description)
+	assert.Equal(t, "go,kubernetes", curated.Tags)
+	assert.Equal(t, "stable", curated.Ref)
+	assert.Equal(t, "skills", curated.SkillPath)
+
+	official, err := database.GetSource("acme/official-skills")
+	require.NoError(t, err)
+	assert.True(t, official.IsOfficial)
+	assert.True(t, official.IsCurated)
+	assert.Equal(t, 10, official.Priority)
+	assert.Equal(t, "main", official.Ref, "the user's ref is kept")
+
+	community, err := database.GetSource("acme/community-skills")
+	require.NoError(t, err)
+	assert.False(t, community.IsCurated)
+	assert.Equal(t, 3, community.Priority)
+
+	// Dropping a listing demotes the source but keeps it
+	body = `{"version": 1, "sources": [{"url": "acme/official-skills", "tier": "official"}]}`
+	index, err = Fetch(context.Background(), url)
+	require.NoError(t, err)
+	result, err = Merge(database, url, index)
+	require.NoError(t, err)
+	assert.Empty(t, result.Added)
+	assert.ElementsMatch(t, []string{"gitlab.acme.dev/platform/skills", "acme/community-skills"}, result.Removed)
+
+	curated, err = database.GetSource("gitlab.acme.dev/platform/skills")
+	require.NoError(t, err)
+	require.NotNil(t, curated)
+	assert.Empty(t, curated.Registry)
+	assert.Empty(t, curated.Tags)
+	assert.False(t, curated.IsCurated)
+	assert.Equal(t, 5, curated.Priority)
+}
+
+func TestMerge_OutranksSeeds(t *testing.T) {
+	database := setupTestDB(t)
+	body := `{"version": 1, "sources": [{"url": "asteroid-belt/skills", "tier": "community"}]}`
+	url := serveIndex(t, &body)
+	seed := scraper.OfficialSeeds[0]
+	require.Equal(t, "asteroid-belt/skills", seed.Owner+"/"+seed.Repo)
+
+	// Listed as community, the seed loses its official tier
+	index, err := Fetch(context.Background(), url)
+	require.NoError(t, err)
+	_, err = Merge(database, url, index)
+	require.NoError(t, err)
+	source, err := database.GetSource("asteroid-belt/skills")
+	require.NoError(t, err)
+	assert.False(t, source.IsOfficial)
+	assert.Equal(t, 5, source.Priority)
+
+	// Delisted, it is a seed again
+	body = `{"version": 1, "sources": []}`
+	index, err = Fetch(context.Background(), url)
+	require.NoError(t, err)
+	_, err = Merge(database, url, index)
+	require.NoError(t, err)
+	source, err = database.GetSource("asteroid-belt/skills")
+	require.NoError(t, err)
+	assert.Empty(t, source.Registry)
+	assert.True(t, source.IsOfficial)
+	assert.Equal(t, seed.Priority, source.Priority)
+}
+
+func TestMerge_SkipsUnusableEntries(t *testing.T) {
+	database := setupTestDB(t)
+	index, err := Parse([]byte(`{"version": 1, "sources": [{"url": "not a repo"}, {"url": "` + t.TempDir() + `"}, {"url": "acme/skills"}]}`))
+	require.NoError(t, err)
+
+	result, err := Merge(database, "index.json", index)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"acme/skills"}, result.Added)
+	assert.Len(t, result.Skipped, 2)
+}
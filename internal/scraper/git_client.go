@@ -3,8 +3,11 @@ package scraper
 import (
 	"context"
 	"fmt"
+	"path/filepath"
 	"sync"
 	"time"
+
+	"github.com/asteroid-belt/skulto/internal/models"
 )
 
 // GitClient wraps git repository operations with caching.
@@ -33,13 +36,38 @@ func NewGitClient(token string, cloneDir string) *GitClient {
 	}
 }
 
+// SetHostTokens sets the tokens used to clone from hosts other than GitHub.
+func (gc *GitClient) SetHostTokens(tokens map[string]string) {
+	gc.repoManager.SetHostTokens(tokens)
+}
+
+// SetSSHKey sets the private key file used for ssh remotes.
+func (gc *GitClient) SetSSHKey(path, passphrase string) {
+	gc.repoManager.SetSSHKey(path, passphrase)
+}
+
+// ConfigureSource applies source's clone URL, ref and history settings to
+// its clone.
+func (gc *GitClient) ConfigureSource(source *models.Source) {
+	gc.repoManager.ConfigureSource(source)
+}
+
+// fileURL links to a file in a repository: a file:// URL into the live
+// directory for local sources, a web link at ref otherwise.
+func (gc *GitClient) fileURL(owner, repo, localPath, ref, path string) string {
+	if IsLocalOwner(owner) {
+		return "file://" + filepath.ToSlash(filepath.Join(localPath, path))
+	}
+	return BlobURL(owner, repo, ref, path)
+}
+
 // GetRepositoryInfo fetches repository metadata using git clone.
 // Returns the same RepoInfo structure as GitHubClient for compatibility.
 func (gc *GitClient) GetRepositoryInfo(ctx context.Context, owner, repo string) (*RepoInfo, error) {
 	cacheKey := fmt.Sprintf("git:repo:%s/%s", owner, repo)
 
-	// Check cache
-	if cached, ok := gc.cache.Get(cacheKey); ok {
+	// Check cache; local directories are always read fresh
+	if cached, ok := gc.cache.Get(cacheKey); ok && !IsLocalOwner(owner) {
 		gc.mu.Lock()
 		gc.cacheHits++
 		gc.mu.Unlock()
@@ -89,7 +117,7 @@ func (gc *GitClient) ListSkillFiles(ctx context.Context, owner, repo, path strin
 	cacheKey := fmt.Sprintf("git:tree:%s/%s:%s", owner, repo, path)
 
 	// Check cache
-	if cached, ok := gc.cache.Get(cacheKey); ok {
+	if cached, ok := gc.cache.Get(cacheKey); ok && !IsLocalOwner(owner) {
 		gc.mu.Lock()
 		gc.cacheHits++
 		gc.mu.Unlock()
@@ -126,7 +154,7 @@ func (gc *GitClient) ListSkillFiles(ctx context.Context, owner, repo, path strin
 			RepoName: repoName,
 			Owner:    owner,
 			Repo:     repo,
-			URL:      fmt.Sprintf("https://github.com/%s/blob/%s/%s", repoName, commitSHA, filePath),
+			URL:      gc.fileURL(owner, repo, localPath, commitSHA, filePath),
 			SHA:      commitSHA, // Use commit SHA as file SHA
 		}
 		skillFiles = append(skillFiles, sf)
@@ -148,7 +176,7 @@ func (gc *GitClient) GetFileContent(ctx context.Context, owner, repo, path, ref
 	cacheKey := fmt.Sprintf("git:content:%s/%s:%s@%s", owner, repo, path, refKey)
 
 	// Check cache
-	if cached, ok := gc.cache.Get(cacheKey); ok {
+	if cached, ok := gc.cache.Get(cacheKey); ok && !IsLocalOwner(owner) {
 		gc.mu.Lock()
 		gc.cacheHits++
 		gc.mu.Unlock()
@@ -188,7 +216,7 @@ func (gc *GitClient) GetLicenseFile(ctx context.Context, owner, repo, ref string
 	cacheKey := fmt.Sprintf("git:license:%s/%s@%s", owner, repo, refKey)
 
 	// Check cache
-	if cached, ok := gc.cache.Get(cacheKey); ok {
+	if cached, ok := gc.cache.Get(cacheKey); ok && !IsLocalOwner(owner) {
 		gc.mu.Lock()
 		gc.cacheHits++
 		gc.mu.Unlock()
@@ -227,8 +255,11 @@ func (gc *GitClient) GetLicenseFile(ctx context.Context, owner, repo, ref string
 	}
 
 	// Build URLs
-	licenseURL := fmt.Sprintf("https://github.com/%s/%s/blob/%s/%s", owner, repo, commitSHA, fileName)
-	rawURL := fmt.Sprintf("https://raw.githubusercontent.com/%s/%s/%s/%s", owner, repo, commitSHA, fileName)
+	licenseURL := gc.fileURL(owner, repo, localPath, commitSHA, fileName)
+	var rawURL string
+	if IsGitHubOwner(owner) {
+		rawURL = fmt.Sprintf("https://raw.githubusercontent.com/%s/%s/%s/%s", owner, repo, commitSHA, fileName)
+	}
 
 	// Detect license type from content
 	licenseType := DetectLicenseType(content)
@@ -251,7 +282,7 @@ func (gc *GitClient) ListDirectoryContents(ctx context.Context, owner, repo, dir
 	cacheKey := fmt.Sprintf("git:dir:%s/%s:%s", owner, repo, dirPath)
 
 	// Check cache
-	if cached, ok := gc.cache.Get(cacheKey); ok {
+	if cached, ok := gc.cache.Get(cacheKey); ok && !IsLocalOwner(owner) {
 		gc.mu.Lock()
 		gc.cacheHits++
 		gc.mu.Unlock()
@@ -286,7 +317,7 @@ func (gc *GitClient) ReadFileBytes(ctx context.Context, owner, repo, path string
 	cacheKey := fmt.Sprintf("git:bytes:%s/%s:%s", owner, repo, path)
 
 	// Check cache
-	if cached, ok := gc.cache.Get(cacheKey); ok {
+	if cached, ok := gc.cache.Get(cacheKey); ok && !IsLocalOwner(owner) {
 		gc.mu.Lock()
 		gc.cacheHits++
 		gc.mu.Unlock()
@@ -350,6 +350,57 @@ func (c *GitHubClient) GetRepositoryInfo(ctx context.Context, owner, repo string
 	return info, nil
 }
 
+// GetRepositoryStats fetches a repository's star, fork and watcher counts
+// with a single request. A cached GetRepositoryInfo result is used if there
+// is one.
+func (c *GitHubClient) GetRepositoryStats(ctx context.Context, owner, repo string) (*RepoMetadata, error) {
+	cacheKey := fmt.Sprintf("stats:%s/%s", owner, repo)
+
+	// Check cache
+	if cached, ok := c.cache.Get(cacheKey); ok {
+		c.mu.Lock()
+		c.cacheHits++
+		c.mu.Unlock()
+		return cached.(*RepoMetadata), nil
+	}
+	if cached, ok := c.cache.Get(fmt.Sprintf("repo:%s/%s", owner, repo)); ok {
+		c.mu.Lock()
+		c.cacheHits++
+		c.mu.Unlock()
+		info := cached.(*RepoInfo)
+		return &RepoMetadata{Stars: info.Stars, Forks: info.Forks, Watchers: info.Watchers}, nil
+	}
+
+	c.mu.Lock()
+	c.cacheMisses++
+	c.mu.Unlock()
+
+	// Wait for rate limiter
+	if err := c.limiter.Wait(ctx); err != nil {
+		return nil, fmt.Errorf("rate limit wait: %w", err)
+	}
+
+	c.mu.Lock()
+	c.requestCount++
+	c.mu.Unlock()
+
+	repository, _, err := c.rest.Repositories.Get(ctx, owner, repo)
+	if err != nil {
+		return nil, fmt.Errorf("get repository: %w", err)
+	}
+
+	stats := &RepoMetadata{
+		Stars:    repository.GetStargazersCount(),
+		Forks:    repository.GetForksCount(),
+		Watchers: repository.GetWatchersCount(),
+	}
+
+	// Cache result
+	c.cache.Set(cacheKey, stats)
+
+	return stats, nil
+}
+
 // ListSkillFiles lists all skill files in a repository.
 func (c *GitHubClient) ListSkillFiles(ctx context.Context, owner, repo, path string) ([]*SkillFile, error) {
 	cacheKey := fmt.Sprintf("tree:%s/%s:%s", owner, repo, path)
@@ -0,0 +1,193 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	gitConfig "github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+
+	"github.com/asteroid-belt/skulto/internal/models"
+)
+
+var (
+	// ErrShallowHistory is returned for sources cloned without their
+	// history; set FullHistory on the source and pull again.
+	ErrShallowHistory = errors.New("the clone has no history")
+
+	// ErrNoHistory is returned for local directory sources that aren't in a
+	// git repository.
+	ErrNoHistory = errors.New("not a git repository")
+)
+
+// Commit is one commit in a skill's history.
+type Commit struct {
+	SHA     string
+	Author  string
+	Email   string
+	Date    time.Time
+	Message string // First line of the commit message
+}
+
+// ShortSHA returns the abbreviated commit hash.
+func (c Commit) ShortSHA() string {
+	if len(c.SHA) > 7 {
+		return c.SHA[:7]
+	}
+	return c.SHA
+}
+
+// DeepenHistory fetches the full history behind an existing clone's current
+// commit, like 'git fetch --unshallow', without moving the clone: its
+// working tree stays on the revision that was scanned and installed.
+func (rm *RepositoryManager) DeepenHistory(ctx context.Context, owner, repo string) error {
+	repoLock := rm.getRepoLock(owner, repo)
+	repoLock.Lock()
+	defer repoLock.Unlock()
+
+	r, err := git.PlainOpen(rm.GetRepoPath(owner, repo))
+	if err != nil {
+		return &RepoError{Owner: owner, Repo: repo, Op: "open", Err: err}
+	}
+	head, err := r.Head()
+	if err != nil {
+		return &RepoError{Owner: owner, Repo: repo, Op: "resolve-ref", Err: err}
+	}
+
+	var cancel context.CancelFunc
+	if deadline, ok := ctx.Deadline(); !ok || time.Until(deadline) > DefaultRepoTimeout {
+		ctx, cancel = context.WithTimeout(ctx, DefaultRepoTimeout)
+		defer cancel()
+	}
+
+	fetchOpts := &git.FetchOptions{
+		RefSpecs: []gitConfig.RefSpec{gitConfig.RefSpec(head.Hash().String() + ":refs/skulto/history")},
+		Depth:    unshallowDepth,
+		Force:    true,
+		Tags:     git.NoTags,
+		Auth:     rm.originAuth(r),
+	}
+	err = r.FetchContext(ctx, fetchOpts)
+	if errors.Is(err, git.ErrExactSHA1NotSupported) {
+		// Servers that refuse fetching by SHA deepen through the branches
+		fetchOpts.RefSpecs = []gitConfig.RefSpec{"+refs/heads/*:refs/remotes/origin/*"}
+		err = r.FetchContext(ctx, fetchOpts)
+	}
+	if err != nil && err != git.NoErrAlreadyUpToDate {
+		return &RepoError{Owner: owner, Repo: repo, Op: "fetch", Err: err}
+	}
+	if err := dropFilledShallows(r); err != nil {
+		return &RepoError{Owner: owner, Repo: repo, Op: "fetch", Err: err}
+	}
+	return nil
+}
+
+// SkillLog returns up to limit commits (all if limit <= 0), newest first,
+// that changed the directory of the skill at skillFilePath in source. Clones
+// must keep their full history; local directory sources are read from the
+// git repository they live in.
+func (rm *RepositoryManager) SkillLog(source *models.Source, skillFilePath string, limit int) ([]Commit, error) {
+	root := rm.GetRepoPath(source.Owner, source.Repo)
+	if dir := source.LocalDir(); dir != "" {
+		root = filepath.FromSlash(dir)
+	}
+
+	r, err := git.PlainOpenWithOptions(root, &git.PlainOpenOptions{DetectDotGit: true})
+	if errors.Is(err, git.ErrRepositoryNotExists) && source.LocalDir() != "" {
+		return nil, ErrNoHistory
+	}
+	if err != nil {
+		return nil, &RepoError{Owner: source.Owner, Repo: source.Repo, Op: "open", Err: err}
+	}
+	dir, err := skillDirInRepo(r, root, skillFilePath)
+	if err != nil {
+		return nil, &RepoError{Owner: source.Owner, Repo: source.Repo, Op: "read", Err: err}
+	}
+
+	head, err := r.Head()
+	if err != nil {
+		return nil, &RepoError{Owner: source.Owner, Repo: source.Repo, Op: "resolve-ref", Err: err}
+	}
+	opts := &git.LogOptions{From: head.Hash(), Order: git.LogOrderCommitterTime}
+	if dir != "" {
+		opts.PathFilter = func(p string) bool {
+			return strings.HasPrefix(p, dir+"/")
+		}
+	}
+	iter, err := r.Log(opts)
+	if err != nil {
+		return nil, &RepoError{Owner: source.Owner, Repo: source.Repo, Op: "log", Err: err}
+	}
+	defer iter.Close()
+
+	var commits []Commit
+	err = iter.ForEach(func(c *object.Commit) error {
+		commits = append(commits, Commit{
+			SHA:     c.Hash.String(),
+			Author:  c.Author.Name,
+			Email:   c.Author.Email,
+			Date:    c.Author.When,
+			Message: firstLine(c.Message),
+		})
+		if limit > 0 && len(commits) >= limit {
+			return storer.ErrStop
+		}
+		return nil
+	})
+	if errors.Is(err, plumbing.ErrObjectNotFound) {
+		// The walk reached the edge of a shallow clone
+		return nil, ErrShallowHistory
+	}
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, &RepoError{Owner: source.Owner, Repo: source.Repo, Op: "log", Err: err}
+	}
+	return commits, nil
+}
+
+// LastChange returns the newest commit that changed the skill at
+// skillFilePath in source, or nil if none did.
+func (rm *RepositoryManager) LastChange(source *models.Source, skillFilePath string) (*Commit, error) {
+	commits, err := rm.SkillLog(source, skillFilePath, 1)
+	if err != nil || len(commits) == 0 {
+		return nil, err
+	}
+	return &commits[0], nil
+}
+
+// skillDirInRepo returns the skill's directory relative to the root of
+// repository r, slash-separated, or "" for the root itself. root is where
+// the source's files are read from, which for a local source may be below
+// the repository root.
+func skillDirInRepo(r *git.Repository, root, skillFilePath string) (string, error) {
+	w, err := r.Worktree()
+	if err != nil {
+		return "", err
+	}
+	dir := filepath.Join(root, filepath.FromSlash(path.Dir(filepath.ToSlash(skillFilePath))))
+	rel, err := filepath.Rel(w.Filesystem.Root(), dir)
+	if err != nil {
+		return "", err
+	}
+	if !filepath.IsLocal(rel) && rel != "." {
+		return "", fmt.Errorf("%s is outside the repository", dir)
+	}
+	if rel == "." {
+		return "", nil
+	}
+	return filepath.ToSlash(rel), nil
+}
+
+// firstLine returns the first line of a commit message.
+func firstLine(message string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(message), "\n")
+	return strings.TrimSpace(line)
+}
@@ -0,0 +1,161 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"github.com/asteroid-belt/skulto/internal/models"
+)
+
+// commitAt writes name (which may be in a subdirectory) into a local
+// repository and commits it with the given message and time.
+func commitAt(t *testing.T, r *git.Repository, dir, name, message string, when time.Time) plumbing.Hash {
+	t.Helper()
+	path := filepath.Join(dir, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(message+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	w, err := r.Worktree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Add(name); err != nil {
+		t.Fatal(err)
+	}
+	hash, err := w.Commit(message+"\n\nMore detail.", &git.CommitOptions{
+		Author: &object.Signature{Name: "Ada", Email: "ada@example.com", When: when},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return hash
+}
+
+func TestSkillLog(t *testing.T) {
+	upstreamDir := t.TempDir()
+	upstream, err := git.PlainInit(upstreamDir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	addA := commitAt(t, upstream, upstreamDir, "skills/a/SKILL.md", "Add a", start)
+	addB := commitAt(t, upstream, upstreamDir, "skills/b/SKILL.md", "Add b", start.Add(time.Hour))
+	fixA := commitAt(t, upstream, upstreamDir, "skills/a/notes.md", "Fix a", start.Add(2*time.Hour))
+	commitAt(t, upstream, upstreamDir, "README.md", "Readme", start.Add(3*time.Hour))
+
+	rm := NewRepositoryManager(t.TempDir(), "")
+	rm.SetCloneURL("acme", "skills", upstreamDir)
+	source := &models.Source{ID: "acme/skills", Owner: "acme", Repo: "skills"}
+	ctx := context.Background()
+
+	// Shallow clones can't answer
+	if _, err := rm.CloneOrUpdate(ctx, "acme", "skills"); err != nil {
+		t.Fatalf("clone: %v", err)
+	}
+	if _, err := rm.SkillLog(source, "skills/a/SKILL.md", 0); !errors.Is(err, ErrShallowHistory) {
+		t.Fatalf("SkillLog on a shallow clone = %v, want ErrShallowHistory", err)
+	}
+
+	// Opting in deepens the clone on the next update
+	rm.SetFullHistory("acme", "skills", true)
+	rm.mu.Lock()
+	delete(rm.recentlyUpdated, "acme/skills")
+	rm.mu.Unlock()
+	if _, err := rm.CloneOrUpdate(ctx, "acme", "skills"); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+
+	commits, err := rm.SkillLog(source, "skills/a/SKILL.md", 0)
+	if err != nil {
+		t.Fatalf("SkillLog: %v", err)
+	}
+	if len(commits) != 2 || commits[0].SHA != fixA.String() || commits[1].SHA != addA.String() {
+		t.Fatalf("SkillLog(a) = %+v, want [Fix a, Add a]", commits)
+	}
+	if c := commits[0]; c.Message != "Fix a" || c.Author != "Ada" || c.Email != "ada@example.com" || !c.Date.Equal(start.Add(2*time.Hour)) {
+		t.Errorf("commit = %+v", c)
+	}
+	if got := commits[0].ShortSHA(); got != fixA.String()[:7] {
+		t.Errorf("ShortSHA = %q", got)
+	}
+
+	last, err := rm.LastChange(source, "skills/b/SKILL.md")
+	if err != nil || last == nil || last.SHA != addB.String() {
+		t.Errorf("LastChange(b) = %+v, %v; want Add b", last, err)
+	}
+	if commits, _ := rm.SkillLog(source, "SKILL.md", 2); len(commits) != 2 {
+		t.Errorf("SkillLog(root, 2) returned %d commits, want 2", len(commits))
+	}
+}
+
+func TestDeepenHistory(t *testing.T) {
+	upstreamDir := t.TempDir()
+	upstream, err := git.PlainInit(upstreamDir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	addA := commitAt(t, upstream, upstreamDir, "skills/a/SKILL.md", "Add a", start)
+	fixA := commitAt(t, upstream, upstreamDir, "skills/a/notes.md", "Fix a", start.Add(time.Hour))
+
+	rm := NewRepositoryManager(t.TempDir(), "")
+	rm.SetCloneURL("acme", "skills", upstreamDir)
+	source := &models.Source{ID: "acme/skills", Owner: "acme", Repo: "skills"}
+	ctx := context.Background()
+	localPath, err := rm.CloneOrUpdate(ctx, "acme", "skills")
+	if err != nil {
+		t.Fatalf("clone: %v", err)
+	}
+
+	// Upstream moves on; deepening must not pull that in
+	commitAt(t, upstream, upstreamDir, "skills/a/SKILL.md", "Rewrite a", start.Add(2*time.Hour))
+	if err := rm.DeepenHistory(ctx, "acme", "skills"); err != nil {
+		t.Fatalf("DeepenHistory: %v", err)
+	}
+	if sha, _ := rm.GetCommitSHA(localPath); sha != fixA.String() {
+		t.Errorf("HEAD = %s, want %s", sha, fixA)
+	}
+
+	commits, err := rm.SkillLog(source, "skills/a/SKILL.md", 0)
+	if err != nil {
+		t.Fatalf("SkillLog: %v", err)
+	}
+	if len(commits) != 2 || commits[0].SHA != fixA.String() || commits[1].SHA != addA.String() {
+		t.Fatalf("SkillLog(a) = %+v, want [Fix a, Add a]", commits)
+	}
+}
+
+func TestSkillLogLocalSource(t *testing.T) {
+	repoDir := t.TempDir()
+	r, err := git.PlainInit(repoDir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	add := commitAt(t, r, repoDir, "skills/a/SKILL.md", "Add a", start)
+	commitAt(t, r, repoDir, "skills/b/SKILL.md", "Add b", start.Add(time.Hour))
+
+	// A local source reading a subdirectory of the repository
+	rm := NewRepositoryManager(t.TempDir(), "")
+	source := &models.Source{Owner: "local/0123abcd", Repo: "skills", Host: models.SourceHostLocal, CloneURL: "file://" + filepath.ToSlash(filepath.Join(repoDir, "skills"))}
+	last, err := rm.LastChange(source, "a/SKILL.md")
+	if err != nil || last == nil || last.SHA != add.String() {
+		t.Errorf("LastChange = %+v, %v; want Add a", last, err)
+	}
+
+	plain := &models.Source{Owner: "local/4567abcd", Repo: "plain", Host: models.SourceHostLocal, CloneURL: "file://" + filepath.ToSlash(t.TempDir())}
+	if _, err := rm.SkillLog(plain, "a/SKILL.md", 0); !errors.Is(err, ErrNoHistory) {
+		t.Errorf("SkillLog outside git = %v, want ErrNoHistory", err)
+	}
+}
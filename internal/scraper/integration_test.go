@@ -329,22 +329,6 @@ func TestIntegrationClientInterfaceCompatibility(t *testing.T) {
 	}
 }
 
-func TestIntegrationHTMLScraping(t *testing.T) {
-	rm := NewRepositoryManager(t.TempDir(), "")
-
-	// Test with a popular repository that definitely has stars
-	stars, forks := rm.ScrapeGitHubMetadata("golang", "go")
-
-	t.Logf("golang/go: stars=%d, forks=%d", stars, forks)
-
-	// The Go repo has many stars, so we should get positive numbers
-	// (unless GitHub changes their HTML structure)
-	if stars == 0 && forks == 0 {
-		t.Log("Warning: HTML scraping returned 0 for both stars and forks")
-		t.Log("This may indicate GitHub's HTML structure has changed")
-	}
-}
-
 // Helper function to calculate directory size
 func dirSize(path string) (int64, error) {
 	var size int64
@@ -0,0 +1,257 @@
+package scraper
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Metadata provider kinds, as set per host with SKULTO_METADATA_PROVIDERS.
+const (
+	MetadataGitHub = "github" // GitHub REST API (github.com or GitHub Enterprise)
+	MetadataGitLab = "gitlab" // GitLab REST API (gitlab.com or self-managed)
+	MetadataNone   = "none"   // No lookups, e.g. on machines without network access
+)
+
+const (
+	// MetadataCacheTTL is how long stars and forks fetched for a repository
+	// are reused before asking its host again.
+	MetadataCacheTTL = 24 * time.Hour
+
+	// metadataTimeout bounds one lookup, so a slow or rate-limited API
+	// doesn't hold up a pull.
+	metadataTimeout = 10 * time.Second
+
+	// metadataKeyPrefix prefixes sync_meta keys holding cached metadata.
+	metadataKeyPrefix = "repo_metadata:"
+)
+
+// RepoMetadata is the popularity data a git host reports for a repository.
+type RepoMetadata struct {
+	Stars    int `json:"stars"`
+	Forks    int `json:"forks"`
+	Watchers int `json:"watchers"`
+}
+
+// MetadataProvider looks up repository metadata on a git host.
+type MetadataProvider interface {
+	// Metadata returns owner/repo's metadata, or nil if the provider has none
+	Metadata(ctx context.Context, owner, repo string) (*RepoMetadata, error)
+}
+
+// GitHubMetadataProvider reads metadata from the GitHub REST API.
+type GitHubMetadataProvider struct {
+	client *GitHubClient
+}
+
+// NewGitHubMetadataProvider creates a provider backed by client, sharing its
+// rate limiter and response cache.
+func NewGitHubMetadataProvider(client *GitHubClient) *GitHubMetadataProvider {
+	return &GitHubMetadataProvider{client: client}
+}
+
+// Metadata implements MetadataProvider.
+func (p *GitHubMetadataProvider) Metadata(ctx context.Context, owner, repo string) (*RepoMetadata, error) {
+	_, namespace := SplitOwner(owner)
+	return p.client.GetRepositoryStats(ctx, namespace, repo)
+}
+
+// GitLabMetadataProvider reads metadata from the GitLab REST API.
+type GitLabMetadataProvider struct {
+	baseURL string // e.g. "https://gitlab.com"
+	token   string
+	http    *http.Client
+	cache   *ResponseCache
+}
+
+// NewGitLabMetadataProvider creates a provider for the GitLab instance at
+// baseURL. token, if set, is sent as a personal, group or project access
+// token so private projects can be read.
+func NewGitLabMetadataProvider(baseURL, token string) *GitLabMetadataProvider {
+	return &GitLabMetadataProvider{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		token:   token,
+		http:    &http.Client{Timeout: metadataTimeout},
+		cache:   NewResponseCache(DefaultCacheTTL),
+	}
+}
+
+// gitlabProject is the part of GitLab's project response we use.
+type gitlabProject struct {
+	StarCount  int `json:"star_count"`
+	ForksCount int `json:"forks_count"`
+}
+
+// Metadata implements MetadataProvider.
+func (p *GitLabMetadataProvider) Metadata(ctx context.Context, owner, repo string) (*RepoMetadata, error) {
+	_, namespace := SplitOwner(owner)
+	project := namespace + "/" + repo
+	if cached, ok := p.cache.Get(project); ok {
+		return cached.(*RepoMetadata), nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.baseURL+"/api/v4/projects/"+url.PathEscape(project), nil)
+	if err != nil {
+		return nil, err
+	}
+	if p.token != "" {
+		req.Header.Set("PRIVATE-TOKEN", p.token)
+	}
+
+	resp, err := p.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("get project: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("get project: %s", resp.Status)
+	}
+
+	var body gitlabProject
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&body); err != nil {
+		return nil, fmt.Errorf("decode project: %w", err)
+	}
+	meta := &RepoMetadata{Stars: body.StarCount, Forks: body.ForksCount}
+	p.cache.Set(project, meta)
+	return meta, nil
+}
+
+// NoopMetadataProvider never looks anything up. It is used for hosts
+// without a known API, local directory sources, and when lookups are
+// turned off.
+type NoopMetadataProvider struct{}
+
+// Metadata implements MetadataProvider.
+func (NoopMetadataProvider) Metadata(context.Context, string, string) (*RepoMetadata, error) {
+	return nil, nil
+}
+
+// MetadataProviderKind returns the kind of provider used for host: the one
+// configured for it (or for "*"), else GitHub for github.com, GitLab for
+// gitlab.com and hosts named gitlab.*, and none for anything else.
+func MetadataProviderKind(host string, configured map[string]string) string {
+	host = strings.ToLower(host)
+	if kind := strings.ToLower(configured[host]); kind != "" {
+		return kind
+	}
+	if kind := strings.ToLower(configured["*"]); kind != "" {
+		return kind
+	}
+	switch {
+	case host == GitHubHost:
+		return MetadataGitHub
+	case host == "gitlab.com" || strings.HasPrefix(host, "gitlab."):
+		return MetadataGitLab
+	default:
+		return MetadataNone
+	}
+}
+
+// metadataProviders creates one provider per host on first use, so each
+// host's rate limit and response cache are shared across repositories.
+type metadataProviders struct {
+	mu         sync.Mutex
+	byHost     map[string]MetadataProvider
+	configured map[string]string // host -> kind overrides
+	token      string            // GitHub token
+	hostTokens map[string]string
+	github     *GitHubClient // Shared with the scraper when it reads through the API
+}
+
+// providerFor returns the provider for owner's host. A nil set (a Scraper
+// not built by NewScraperWithConfig) looks nothing up.
+func (m *metadataProviders) providerFor(owner string) MetadataProvider {
+	if m == nil || IsLocalOwner(owner) {
+		return NoopMetadataProvider{}
+	}
+	host, _ := SplitOwner(owner)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if p, ok := m.byHost[host]; ok {
+		return p
+	}
+	if m.byHost == nil {
+		m.byHost = make(map[string]MetadataProvider)
+	}
+
+	var p MetadataProvider = NoopMetadataProvider{}
+	switch MetadataProviderKind(host, m.configured) {
+	case MetadataGitHub:
+		p = NewGitHubMetadataProvider(m.githubClient(host))
+	case MetadataGitLab:
+		p = NewGitLabMetadataProvider("https://"+host, hostSecret(m.hostTokens[host]))
+	}
+	m.byHost[host] = p
+	return p
+}
+
+// githubClient returns a GitHub API client for host: github.com or a GitHub
+// Enterprise server.
+func (m *metadataProviders) githubClient(host string) *GitHubClient {
+	if host == GitHubHost {
+		if m.github == nil {
+			m.github = NewGitHubClient(m.token, 0)
+		}
+		return m.github
+	}
+	client := NewGitHubClient(hostSecret(m.hostTokens[host]), 0)
+	if rest, err := client.rest.WithEnterpriseURLs("https://"+host+"/", "https://"+host+"/"); err == nil {
+		client.rest = rest
+	}
+	return client
+}
+
+// hostSecret drops the username from a "user:token" host token.
+func hostSecret(token string) string {
+	if _, secret, ok := strings.Cut(token, ":"); ok {
+		return secret
+	}
+	return token
+}
+
+// cachedMetadata is RepoMetadata as stored in sync_meta.
+type cachedMetadata struct {
+	RepoMetadata
+	FetchedAt time.Time `json:"fetched_at"`
+}
+
+// repositoryMetadata returns owner/repo's metadata: from sync_meta if it was
+// fetched within MetadataCacheTTL, else from the host's provider. If the
+// provider fails or has nothing, the last cached value is used, then
+// fallback (what the source last recorded), so a host outage doesn't reset
+// counts to zero.
+func (s *Scraper) repositoryMetadata(ctx context.Context, owner, repo string, fallback RepoMetadata) RepoMetadata {
+	key := metadataKeyPrefix + owner + "/" + repo
+	var cached *cachedMetadata
+	if value, err := s.db.GetSyncMeta(key); err == nil && value != "" {
+		var c cachedMetadata
+		if json.Unmarshal([]byte(value), &c) == nil {
+			cached = &c
+		}
+	}
+	if cached != nil && time.Since(cached.FetchedAt) < MetadataCacheTTL {
+		return cached.RepoMetadata
+	}
+
+	lookupCtx, cancel := context.WithTimeout(ctx, metadataTimeout)
+	defer cancel()
+	meta, err := s.metadata.providerFor(owner).Metadata(lookupCtx, owner, repo)
+	if err != nil || meta == nil {
+		if cached != nil {
+			return cached.RepoMetadata
+		}
+		return fallback
+	}
+
+	if data, err := json.Marshal(cachedMetadata{RepoMetadata: *meta, FetchedAt: time.Now().UTC()}); err == nil {
+		_ = s.db.SetSyncMeta(key, string(data))
+	}
+	return *meta
+}
@@ -0,0 +1,164 @@
+package scraper
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/asteroid-belt/skulto/internal/db"
+)
+
+func TestGitHubMetadataProvider(t *testing.T) {
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.URL.Path != "/repos/acme/skills" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(`{"full_name":"acme/skills","stargazers_count":42,"forks_count":7,"watchers_count":42}`))
+	}))
+	defer srv.Close()
+
+	client := NewGitHubClient("", 60)
+	client.rest.BaseURL, _ = url.Parse(srv.URL + "/")
+	p := NewGitHubMetadataProvider(client)
+
+	for i := 0; i < 2; i++ {
+		meta, err := p.Metadata(context.Background(), "acme", "skills")
+		if err != nil {
+			t.Fatalf("Metadata: %v", err)
+		}
+		if *meta != (RepoMetadata{Stars: 42, Forks: 7, Watchers: 42}) {
+			t.Errorf("Metadata = %+v", *meta)
+		}
+	}
+	if n := requests.Load(); n != 1 {
+		t.Errorf("requests = %d, want 1 (second lookup cached)", n)
+	}
+
+	if _, err := p.Metadata(context.Background(), "acme", "missing"); err == nil {
+		t.Error("expected an error for a missing repository")
+	}
+}
+
+func TestGitLabMetadataProvider(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("PRIVATE-TOKEN") != "glpat-1" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.EscapedPath() != "/api/v4/projects/platform%2Fteam%2Fskills" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(`{"path_with_namespace":"platform/team/skills","star_count":12,"forks_count":3}`))
+	}))
+	defer srv.Close()
+
+	p := NewGitLabMetadataProvider(srv.URL+"/", "glpat-1")
+	meta, err := p.Metadata(context.Background(), "gitlab.example.com/platform/team", "skills")
+	if err != nil {
+		t.Fatalf("Metadata: %v", err)
+	}
+	if *meta != (RepoMetadata{Stars: 12, Forks: 3}) {
+		t.Errorf("Metadata = %+v", *meta)
+	}
+
+	if _, err := p.Metadata(context.Background(), "gitlab.example.com/platform", "missing"); err == nil {
+		t.Error("expected an error for a missing project")
+	}
+	if _, err := NewGitLabMetadataProvider(srv.URL, "").Metadata(context.Background(), "gitlab.example.com/platform/team", "skills"); err == nil {
+		t.Error("expected an error without a token")
+	}
+}
+
+func TestMetadataProviderKind(t *testing.T) {
+	tests := []struct {
+		host       string
+		configured map[string]string
+		want       string
+	}{
+		{"github.com", nil, MetadataGitHub},
+		{"gitlab.com", nil, MetadataGitLab},
+		{"gitlab.example.com", nil, MetadataGitLab},
+		{"bitbucket.org", nil, MetadataNone},
+		{"git.corp.com", map[string]string{"git.corp.com": "GitLab"}, MetadataGitLab},
+		{"github.com", map[string]string{"*": "none"}, MetadataNone},
+		{"ghe.corp.com", map[string]string{"*": "none", "ghe.corp.com": "github"}, MetadataGitHub},
+	}
+	for _, tt := range tests {
+		if got := MetadataProviderKind(tt.host, tt.configured); got != tt.want {
+			t.Errorf("MetadataProviderKind(%q, %v) = %q, want %q", tt.host, tt.configured, got, tt.want)
+		}
+	}
+}
+
+// fakeMetadataProvider returns meta or err and counts lookups.
+type fakeMetadataProvider struct {
+	meta  *RepoMetadata
+	err   error
+	calls int
+}
+
+func (f *fakeMetadataProvider) Metadata(context.Context, string, string) (*RepoMetadata, error) {
+	f.calls++
+	return f.meta, f.err
+}
+
+func TestRepositoryMetadataCache(t *testing.T) {
+	database, err := db.New(db.Config{Path: ":memory:", MaxIdleConn: 1, MaxOpenConn: 1})
+	if err != nil {
+		t.Fatalf("db.New: %v", err)
+	}
+	defer func() { _ = database.Close() }()
+
+	s := NewScraperWithConfig(ScraperConfig{DataDir: t.TempDir(), UseGitClone: true}, database)
+	fake := &fakeMetadataProvider{meta: &RepoMetadata{Stars: 5, Forks: 2}}
+	s.metadata.byHost = map[string]MetadataProvider{GitHubHost: fake}
+	ctx := context.Background()
+	fallback := RepoMetadata{Stars: 1}
+
+	// Fetched once, then served from sync_meta
+	for i := 0; i < 2; i++ {
+		if got := s.repositoryMetadata(ctx, "acme", "skills", fallback); got != *fake.meta {
+			t.Fatalf("repositoryMetadata = %+v, want %+v", got, *fake.meta)
+		}
+	}
+	if fake.calls != 1 {
+		t.Errorf("provider calls = %d, want 1", fake.calls)
+	}
+
+	// Once stale, the provider is asked again; if it fails, the stale
+	// value beats the fallback
+	stale, _ := json.Marshal(cachedMetadata{RepoMetadata: RepoMetadata{Stars: 9}, FetchedAt: time.Now().Add(-2 * MetadataCacheTTL)})
+	if err := database.SetSyncMeta(metadataKeyPrefix+"acme/skills", string(stale)); err != nil {
+		t.Fatalf("SetSyncMeta: %v", err)
+	}
+	fake.err = errors.New("rate limited")
+	if got := s.repositoryMetadata(ctx, "acme", "skills", fallback); got.Stars != 9 {
+		t.Errorf("stale lookup = %+v, want the cached value", got)
+	}
+	if fake.calls != 2 {
+		t.Errorf("provider calls = %d, want 2", fake.calls)
+	}
+
+	// Nothing cached and nothing fetched: keep what the source had
+	if got := s.repositoryMetadata(ctx, "acme", "other", fallback); got != fallback {
+		t.Errorf("uncached lookup = %+v, want fallback %+v", got, fallback)
+	}
+
+	// Local directory sources never look anything up
+	if got := s.repositoryMetadata(ctx, "local/0123abcd", "skills", fallback); got != fallback {
+		t.Errorf("local lookup = %+v, want fallback", got)
+	}
+	if fake.calls != 3 {
+		t.Errorf("provider calls = %d, want 3", fake.calls)
+	}
+}
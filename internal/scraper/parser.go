@@ -13,6 +13,7 @@ import (
 	meta "github.com/yuin/goldmark-meta"
 	"github.com/yuin/goldmark/extension"
 	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/text"
 )
 
 // SkillFile represents a discovered skill file from GitHub.
@@ -87,22 +88,7 @@ func (p *SkillParser) Parse(content string, source *SkillFile) (*models.Skill, e
 
 	// Parse metadata from nested metadata object
 	if metadataRaw, ok := frontmatter["metadata"]; ok {
-		// goldmark-meta may return interface{} so we need to handle type conversion
-		var metadata map[string]interface{}
-		switch m := metadataRaw.(type) {
-		case map[string]interface{}:
-			metadata = m
-		case map[interface{}]interface{}:
-			// Convert map[interface{}]interface{} to map[string]interface{}
-			metadata = make(map[string]interface{})
-			for k, v := range m {
-				if str, ok := k.(string); ok {
-					metadata[str] = v
-				}
-			}
-		}
-
-		if metadata != nil {
+		if metadata := stringKeys(metadataRaw); metadata != nil {
 			if version, ok := metadata["version"].(string); ok {
 				skill.Version = version
 			}
@@ -126,6 +112,149 @@ func (p *SkillParser) Parse(content string, source *SkillFile) (*models.Skill, e
 	return skill, nil
 }
 
+// ParseMeta reads the SkillMeta in the frontmatter of SKILL.md content.
+// Top-level fields take precedence over the nested `metadata` block.
+// Content without frontmatter yields an empty SkillMeta.
+func (p *SkillParser) ParseMeta(content string) (*models.SkillMeta, error) {
+	context := parser.NewContext()
+	p.md.Parser().Parse(text.NewReader([]byte(content)), parser.WithContext(context))
+	frontmatter, err := meta.TryGet(context)
+	if err != nil {
+		return nil, fmt.Errorf("parse frontmatter: %w", err)
+	}
+
+	result := metaFrom(frontmatter)
+	if nested := stringKeys(frontmatter["metadata"]); nested != nil {
+		fallback := metaFrom(nested)
+		if result.Version == "" {
+			result.Version = fallback.Version
+		}
+		if result.Author == "" {
+			result.Author = fallback.Author
+		}
+		if result.License == "" {
+			result.License = fallback.License
+		}
+		if len(result.Tags) == 0 {
+			result.Tags = fallback.Tags
+		}
+		if len(result.Platforms) == 0 {
+			result.Platforms = fallback.Platforms
+		}
+		if result.MinVersion == "" {
+			result.MinVersion = fallback.MinVersion
+		}
+		if len(result.Requires) == 0 {
+			result.Requires = fallback.Requires
+		}
+	}
+	return &result, nil
+}
+
+// metaParser backs SkillMeta. goldmark parsers are safe for concurrent use;
+// ParseMeta gives each call its own parser context.
+var metaParser = NewSkillParser()
+
+// SkillMeta parses the skill's SKILL.md frontmatter. Parse errors yield an
+// empty SkillMeta so malformed frontmatter never blocks callers.
+func SkillMeta(skill *models.Skill) *models.SkillMeta {
+	result, err := metaParser.ParseMeta(skill.Content)
+	if err != nil {
+		return &models.SkillMeta{}
+	}
+	return result
+}
+
+// metaFrom reads SkillMeta fields from decoded frontmatter.
+func metaFrom(m map[string]interface{}) models.SkillMeta {
+	return models.SkillMeta{
+		Name:        metaString(m["name"]),
+		Description: metaString(m["description"]),
+		Version:     metaString(m["version"]),
+		Author:      metaString(m["author"]),
+		License:     metaString(m["license"]),
+		Tags:        metaStrings(m["tags"]),
+		Platforms:   metaStrings(m["platforms"]),
+		MinVersion:  metaString(m["min_version"]),
+		Requires:    metaRequirements(m["requires"]),
+	}
+}
+
+// stringKeys returns a decoded YAML mapping with string keys, or nil if v
+// is not a mapping. goldmark-meta decodes nested mappings with interface{}
+// keys.
+func stringKeys(v interface{}) map[string]interface{} {
+	switch m := v.(type) {
+	case map[string]interface{}:
+		return m
+	case map[interface{}]interface{}:
+		out := make(map[string]interface{}, len(m))
+		for k, v := range m {
+			if str, ok := k.(string); ok {
+				out[str] = v
+			}
+		}
+		return out
+	}
+	return nil
+}
+
+// metaString returns a scalar frontmatter value as a string, so that
+// "version: 1.2" reads like "version: '1.2'".
+func metaString(v interface{}) string {
+	switch s := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return strings.TrimSpace(s)
+	case bool, int, int64, uint64, float64:
+		return fmt.Sprint(s)
+	}
+	return ""
+}
+
+// metaStrings returns a list of scalars, or a single scalar, as strings.
+func metaStrings(v interface{}) []string {
+	items, ok := v.([]interface{})
+	if !ok {
+		if s := metaString(v); s != "" {
+			return []string{s}
+		}
+		return nil
+	}
+	var out []string
+	for _, item := range items {
+		if s := metaString(item); s != "" {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// metaRequirements reads a requires: list, whose entries are a bare slug or
+// a {slug, source, version} mapping. Entries without a slug are dropped.
+func metaRequirements(v interface{}) []models.SkillRequirement {
+	items, ok := v.([]interface{})
+	if !ok {
+		return nil
+	}
+	var out []models.SkillRequirement
+	for _, item := range items {
+		req := models.SkillRequirement{Slug: metaString(item)}
+		if m := stringKeys(item); m != nil {
+			req = models.SkillRequirement{
+				Slug:    metaString(m["slug"]),
+				Source:  metaString(m["source"]),
+				Version: metaString(m["version"]),
+			}
+		}
+		if req.Slug != "" {
+			out = append(out, req)
+		}
+	}
+	return out
+}
+
 // extractFirstHeading finds the first H1 or H2 heading in the markdown.
 // Returns "Untitled Skill" if no heading is found.
 func extractFirstHeading(content string) string {
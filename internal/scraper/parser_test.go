@@ -1,8 +1,11 @@
 package scraper
 
 import (
+	"reflect"
 	"strings"
 	"testing"
+
+	"github.com/asteroid-belt/skulto/internal/models"
 )
 
 // Test fixtures inlined to avoid external file dependencies
@@ -488,3 +491,78 @@ func BenchmarkParse(b *testing.B) {
 		_, _ = parser.Parse(content, skillFile)
 	}
 }
+
+func TestParseMeta(t *testing.T) {
+	content := `---
+name: Release
+description: Cut a release
+version: 1.2.0
+platforms: [claude, cursor]
+min_version: 0.9.0
+requires:
+  - changelog
+  - slug: semver-bump
+    source: acme/skills
+    version: ">=2.0"
+  - ""
+---
+
+# Release
+`
+	meta, err := NewSkillParser().ParseMeta(content)
+	if err != nil {
+		t.Fatalf("ParseMeta failed: %v", err)
+	}
+	if meta.Name != "Release" || meta.Version != "1.2.0" || meta.MinVersion != "0.9.0" {
+		t.Errorf("ParseMeta = %+v", meta)
+	}
+	if !reflect.DeepEqual(meta.Platforms, []string{"claude", "cursor"}) {
+		t.Errorf("Platforms = %v", meta.Platforms)
+	}
+	want := []models.SkillRequirement{
+		{Slug: "changelog"},
+		{Slug: "semver-bump", Source: "acme/skills", Version: ">=2.0"},
+	}
+	if !reflect.DeepEqual(meta.Requires, want) {
+		t.Errorf("Requires = %+v, want %+v", meta.Requires, want)
+	}
+}
+
+func TestParseMeta_NestedMetadata(t *testing.T) {
+	content := "---\nname: x\nversion: 1.5\nmetadata:\n  version: 2.0.0\n  author: Alice\n  platforms:\n    - claude\n  requires:\n    - base\n---\nbody"
+	meta, err := NewSkillParser().ParseMeta(content)
+	if err != nil {
+		t.Fatalf("ParseMeta failed: %v", err)
+	}
+	// Top-level fields win; unquoted numbers read as written
+	if meta.Version != "1.5" {
+		t.Errorf("Version = %q, want 1.5", meta.Version)
+	}
+	if meta.Author != "Alice" {
+		t.Errorf("Author = %q, want Alice", meta.Author)
+	}
+	if !reflect.DeepEqual(meta.Platforms, []string{"claude"}) {
+		t.Errorf("Platforms = %v", meta.Platforms)
+	}
+	if len(meta.Requires) != 1 || meta.Requires[0].Slug != "base" {
+		t.Errorf("Requires = %+v", meta.Requires)
+	}
+}
+
+func TestParseMeta_NoFrontmatter(t *testing.T) {
+	meta, err := NewSkillParser().ParseMeta("# Just markdown")
+	if err != nil {
+		t.Fatalf("ParseMeta failed: %v", err)
+	}
+	if len(meta.Requires) != 0 {
+		t.Errorf("Requires = %+v, want none", meta.Requires)
+	}
+
+	malformed := "---\nrequires: [unclosed\n---\n"
+	if _, err := NewSkillParser().ParseMeta(malformed); err == nil {
+		t.Error("expected an error for malformed frontmatter")
+	}
+	if got := SkillMeta(&models.Skill{Content: malformed}); len(got.Requires) != 0 {
+		t.Errorf("SkillMeta of malformed frontmatter = %+v, want empty", got)
+	}
+}
@@ -0,0 +1,171 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/asteroid-belt/skulto/internal/models"
+)
+
+// Defaults for PullSources.
+const (
+	DefaultPullConcurrency = 8               // Repositories pulled at once
+	DefaultPullPerHost     = 4               // Repositories pulled at once from one host
+	DefaultPullTimeout     = 5 * time.Minute // Limit for each repository
+)
+
+// PullOptions configures PullSources.
+type PullOptions struct {
+	// MaxConcurrency limits how many repositories are pulled at once
+	MaxConcurrency int
+	// MaxPerHost limits how many repositories are pulled at once from one
+	// git host, so a large pull doesn't trip a host's rate limits. Local
+	// directory sources are not limited.
+	MaxPerHost int
+	// RepoTimeout bounds each repository's clone, fetch and scan
+	RepoTimeout time.Duration
+	// OnStart is called when a worker starts on a source (from the worker's goroutine)
+	OnStart func(source *models.Source)
+	// OnDone is called when a source finishes (from the worker's goroutine)
+	OnDone func(result SourceResult)
+}
+
+// SourceResult is the outcome of pulling one source.
+type SourceResult struct {
+	Source   *models.Source
+	Result   *ScrapeResult
+	Err      error
+	Duration time.Duration
+}
+
+// PullSources scrapes sources with a bounded pool of workers and returns
+// one result per source, in the order given. Each repository runs under its
+// own timeout; the per-repository locks in RepositoryManager keep two
+// workers from touching the same clone. A failed source doesn't stop the
+// others; use PullErrors to collect the failures.
+func (s *Scraper) PullSources(ctx context.Context, sources []models.Source, opts PullOptions) []SourceResult {
+	return runPullPool(ctx, sources, opts, func(ctx context.Context, source *models.Source) (*ScrapeResult, error) {
+		return s.ScrapeRepository(ctx, source.Owner, source.Repo)
+	})
+}
+
+// PullErrors joins the failures in results, each prefixed with its source,
+// or returns nil if every source succeeded.
+func PullErrors(results []SourceResult) error {
+	var errs []error
+	for _, r := range results {
+		if r.Err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", r.Source.ID, r.Err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// pullHost returns the host whose limit a source counts against, or "" for
+// sources that aren't fetched over the network.
+func pullHost(source *models.Source) string {
+	if IsLocalOwner(source.Owner) {
+		return ""
+	}
+	host, _ := SplitOwner(source.Owner)
+	return host
+}
+
+// runPullPool runs pull for every source under the limits in opts.
+func runPullPool(
+	ctx context.Context,
+	sources []models.Source,
+	opts PullOptions,
+	pull func(ctx context.Context, source *models.Source) (*ScrapeResult, error),
+) []SourceResult {
+	workers := opts.MaxConcurrency
+	if workers <= 0 {
+		workers = DefaultPullConcurrency
+	}
+	if workers > len(sources) {
+		workers = len(sources)
+	}
+	perHost := opts.MaxPerHost
+	if perHost <= 0 {
+		perHost = DefaultPullPerHost
+	}
+	timeout := opts.RepoTimeout
+	if timeout <= 0 {
+		timeout = DefaultPullTimeout
+	}
+
+	// Per-host semaphores, created on first use
+	var hostMu sync.Mutex
+	hostSems := make(map[string]chan struct{})
+	hostSem := func(host string) chan struct{} {
+		hostMu.Lock()
+		defer hostMu.Unlock()
+		sem, ok := hostSems[host]
+		if !ok {
+			sem = make(chan struct{}, perHost)
+			hostSems[host] = sem
+		}
+		return sem
+	}
+
+	results := make([]SourceResult, len(sources))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				source := &sources[i]
+				results[i] = SourceResult{Source: source}
+
+				var sem chan struct{}
+				if host := pullHost(source); host != "" {
+					sem = hostSem(host)
+					select {
+					case sem <- struct{}{}:
+					case <-ctx.Done():
+						results[i].Err = ctx.Err()
+						if opts.OnDone != nil {
+							opts.OnDone(results[i])
+						}
+						continue
+					}
+				}
+
+				if opts.OnStart != nil {
+					opts.OnStart(source)
+				}
+				start := time.Now()
+				repoCtx, cancel := context.WithTimeout(ctx, timeout)
+				res, err := pull(repoCtx, source)
+				if err != nil && errors.Is(repoCtx.Err(), context.DeadlineExceeded) && ctx.Err() == nil {
+					err = fmt.Errorf("timed out after %s: %w", timeout, err)
+				}
+				cancel()
+				if sem != nil {
+					<-sem
+				}
+
+				results[i].Result = res
+				results[i].Err = err
+				results[i].Duration = time.Since(start)
+				if opts.OnDone != nil {
+					opts.OnDone(results[i])
+				}
+			}
+		}()
+	}
+
+	for i := range sources {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
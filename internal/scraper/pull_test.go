@@ -0,0 +1,115 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/asteroid-belt/skulto/internal/models"
+)
+
+func TestRunPullPoolLimits(t *testing.T) {
+	var sources []models.Source
+	for i := 0; i < 6; i++ {
+		sources = append(sources, models.Source{ID: fmt.Sprintf("gh%d/repo", i), Owner: fmt.Sprintf("gh%d", i), Repo: "repo"})
+		sources = append(sources, models.Source{ID: fmt.Sprintf("gitlab.com/g%d/repo", i), Owner: fmt.Sprintf("gitlab.com/g%d", i), Repo: "repo"})
+	}
+
+	var mu sync.Mutex
+	running, maxRunning := 0, 0
+	perHost, maxPerHost := map[string]int{}, map[string]int{}
+
+	results := runPullPool(context.Background(), sources, PullOptions{MaxConcurrency: 4, MaxPerHost: 2},
+		func(ctx context.Context, source *models.Source) (*ScrapeResult, error) {
+			host := pullHost(source)
+			mu.Lock()
+			running++
+			perHost[host]++
+			maxRunning = max(maxRunning, running)
+			maxPerHost[host] = max(maxPerHost[host], perHost[host])
+			mu.Unlock()
+
+			time.Sleep(10 * time.Millisecond)
+
+			mu.Lock()
+			running--
+			perHost[host]--
+			mu.Unlock()
+			return &ScrapeResult{SkillsFound: 1}, nil
+		})
+
+	if len(results) != len(sources) {
+		t.Fatalf("got %d results, want %d", len(results), len(sources))
+	}
+	for i, r := range results {
+		if r.Source.ID != sources[i].ID {
+			t.Errorf("results[%d] = %s, want %s (input order)", i, r.Source.ID, sources[i].ID)
+		}
+		if r.Err != nil || r.Result == nil {
+			t.Errorf("results[%d]: err=%v result=%v", i, r.Err, r.Result)
+		}
+	}
+	if maxRunning > 4 {
+		t.Errorf("%d pulls ran at once, limit 4", maxRunning)
+	}
+	for host, n := range maxPerHost {
+		if n > 2 {
+			t.Errorf("%d pulls ran at once on %s, limit 2", n, host)
+		}
+	}
+}
+
+func TestRunPullPoolTimeoutAndErrors(t *testing.T) {
+	sources := []models.Source{
+		{ID: "a/slow", Owner: "a", Repo: "slow"},
+		{ID: "b/broken", Owner: "b", Repo: "broken"},
+		{ID: "c/fine", Owner: "c", Repo: "fine"},
+	}
+
+	var done []string
+	var mu sync.Mutex
+	results := runPullPool(context.Background(), sources, PullOptions{
+		RepoTimeout: 20 * time.Millisecond,
+		OnDone: func(r SourceResult) {
+			mu.Lock()
+			done = append(done, r.Source.ID)
+			mu.Unlock()
+		},
+	}, func(ctx context.Context, source *models.Source) (*ScrapeResult, error) {
+		switch source.Repo {
+		case "slow":
+			<-ctx.Done()
+			return nil, ctx.Err()
+		case "broken":
+			return nil, errors.New("clone failed")
+		}
+		return &ScrapeResult{}, nil
+	})
+
+	if len(done) != 3 {
+		t.Errorf("OnDone called for %v, want all three", done)
+	}
+	if results[0].Err == nil || !strings.Contains(results[0].Err.Error(), "timed out") {
+		t.Errorf("slow: err = %v, want a timeout", results[0].Err)
+	}
+	if results[2].Err != nil {
+		t.Errorf("fine: err = %v", results[2].Err)
+	}
+
+	err := PullErrors(results)
+	if err == nil {
+		t.Fatal("PullErrors = nil, want the two failures")
+	}
+	for _, want := range []string{"a/slow: timed out", "b/broken: clone failed"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("PullErrors = %q, missing %q", err, want)
+		}
+	}
+	if PullErrors(results[2:]) != nil {
+		t.Error("PullErrors of successes should be nil")
+	}
+}
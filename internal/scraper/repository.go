@@ -2,26 +2,37 @@ package scraper
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
-	"net/http"
 	"os"
 	"path/filepath"
-	"regexp"
-	"strconv"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/go-git/go-git/v5"
+	gitConfig "github.com/go-git/go-git/v5/config"
 	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
 	"github.com/go-git/go-git/v5/plumbing/object"
-	gitHttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+
+	"github.com/asteroid-belt/skulto/internal/config"
+	"github.com/asteroid-belt/skulto/internal/models"
 )
 
 // DefaultRepoTimeout is the per-repository timeout for clone/fetch operations.
 const DefaultRepoTimeout = 2 * time.Minute
 
+// unshallowDepth is the fetch depth that converts a shallow clone to a full one,
+// matching git's --unshallow.
+const unshallowDepth = 2147483647
+
+// pinsDir is the directory under baseDir holding checkouts of pinned
+// commits (see PinCommit).
+const pinsDir = ".pins"
+
 // RecentUpdateTTL is how long to skip fetches after a successful update.
 // This prevents redundant network calls when reading multiple files from the same repo.
 const RecentUpdateTTL = 60 * time.Second
@@ -30,9 +41,17 @@ const RecentUpdateTTL = 60 * time.Second
 type RepositoryManager struct {
 	baseDir         string
 	token           string
-	mu              sync.RWMutex           // Protects repoLocks and recentlyUpdated maps
+	hostTokens      map[string]string      // Tokens for hosts other than GitHub
+	mu              sync.RWMutex           // Protects repoLocks, recentlyUpdated, cloneURLs, refs, fullHistory and authCache
 	repoLocks       map[string]*sync.Mutex // Per-repository locks
 	recentlyUpdated map[string]time.Time   // Tracks when repos were last updated
+	cloneURLs       map[string]string      // Recorded clone URLs by owner/repo
+	refs            map[string]string      // Branch or tag to follow by owner/repo
+	fullHistory     map[string]bool        // Repositories cloned with their full history, by owner/repo
+
+	sshKey           string                          // Private key for ssh remotes
+	sshKeyPassphrase string                          // Passphrase for sshKey
+	authCache        map[string]transport.AuthMethod // Resolved credentials by scheme, user and host
 }
 
 // RepositoryInfo contains metadata extracted from a local repository.
@@ -73,7 +92,168 @@ func NewRepositoryManager(baseDir string, token string) *RepositoryManager {
 		token:           token,
 		repoLocks:       make(map[string]*sync.Mutex),
 		recentlyUpdated: make(map[string]time.Time),
+		cloneURLs:       make(map[string]string),
+		refs:            make(map[string]string),
+		fullHistory:     make(map[string]bool),
+		authCache:       make(map[string]transport.AuthMethod),
+	}
+}
+
+// NewRepositoryManagerFromConfig creates a repository manager for the
+// repositories directory in cfg, using its git credentials.
+func NewRepositoryManagerFromConfig(cfg *config.Config) *RepositoryManager {
+	rm := NewRepositoryManager(config.GetPaths(cfg).Repositories, cfg.GitHub.Token)
+	rm.SetHostTokens(cfg.Git.HostTokens)
+	rm.SetSSHKey(cfg.Git.SSHKey, cfg.Git.SSHKeyPassphrase)
+	return rm
+}
+
+// SetHostTokens sets the tokens used for https remotes on hosts other than
+// GitHub, keyed by host.
+func (rm *RepositoryManager) SetHostTokens(tokens map[string]string) {
+	rm.hostTokens = tokens
+	rm.clearAuthCache()
+}
+
+// SetCloneURL records where to clone owner/repo from. Repositories without a
+// recorded URL are cloned from DefaultCloneURL. Existing clones keep fetching
+// from their origin remote.
+func (rm *RepositoryManager) SetCloneURL(owner, repo, cloneURL string) {
+	if cloneURL == "" {
+		return
+	}
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	rm.cloneURLs[owner+"/"+repo] = cloneURL
+}
+
+// SetRef makes owner/repo follow a branch or tag instead of the remote's
+// default branch. An empty ref restores the default.
+func (rm *RepositoryManager) SetRef(owner, repo, ref string) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	if ref == "" {
+		delete(rm.refs, owner+"/"+repo)
+		return
+	}
+	rm.refs[owner+"/"+repo] = ref
+}
+
+// trackedRef returns the branch or tag owner/repo follows, or "" for the
+// default branch.
+func (rm *RepositoryManager) trackedRef(owner, repo string) string {
+	rm.mu.RLock()
+	defer rm.mu.RUnlock()
+	return rm.refs[owner+"/"+repo]
+}
+
+// SetFullHistory makes owner/repo keep its full history instead of a
+// shallow clone. An existing shallow clone is deepened on its next update.
+func (rm *RepositoryManager) SetFullHistory(owner, repo string, full bool) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	if !full {
+		delete(rm.fullHistory, owner+"/"+repo)
+		return
+	}
+	rm.fullHistory[owner+"/"+repo] = true
+}
+
+// ConfigureSource applies source's clone URL, ref and history settings to
+// its clone, so it is cloned and updated the way the scraper does.
+func (rm *RepositoryManager) ConfigureSource(source *models.Source) {
+	rm.SetCloneURL(source.Owner, source.Repo, source.CloneURL)
+	rm.SetRef(source.Owner, source.Repo, source.Ref)
+	rm.SetFullHistory(source.Owner, source.Repo, source.FullHistory)
+}
+
+// wantsFullHistory reports whether owner/repo keeps its full history.
+func (rm *RepositoryManager) wantsFullHistory(owner, repo string) bool {
+	rm.mu.RLock()
+	defer rm.mu.RUnlock()
+	return rm.fullHistory[owner+"/"+repo]
+}
+
+// fetchDepth returns the depth to fetch owner/repo at: 1 for shallow
+// clones, 0 (everything new) for full-history ones, or unshallowDepth when
+// a full-history source still has a shallow clone.
+func (rm *RepositoryManager) fetchDepth(r *git.Repository, owner, repo string) int {
+	if !rm.wantsFullHistory(owner, repo) {
+		return 1
 	}
+	if shallow, err := r.Storer.Shallow(); err == nil && len(shallow) > 0 {
+		return unshallowDepth
+	}
+	return 0
+}
+
+// dropFilledShallows forgets shallow commits whose parents a deepening fetch
+// has since brought in. go-git records new shallow commits but never clears
+// old ones, which would make a deepened clone still look shallow.
+func dropFilledShallows(r *git.Repository) error {
+	shallows, err := r.Storer.Shallow()
+	if err != nil || len(shallows) == 0 {
+		return err
+	}
+	var kept []plumbing.Hash
+	for _, hash := range shallows {
+		commit, err := r.CommitObject(hash)
+		if err != nil {
+			kept = append(kept, hash)
+			continue
+		}
+		for _, parent := range commit.ParentHashes {
+			if _, err := r.CommitObject(parent); err != nil {
+				kept = append(kept, hash)
+				break
+			}
+		}
+	}
+	if len(kept) == len(shallows) {
+		return nil
+	}
+	return r.Storer.SetShallow(kept)
+}
+
+// localDir returns the live directory of a local source, recorded with
+// SetCloneURL as a file:// URL.
+func (rm *RepositoryManager) localDir(owner, repo string) (string, error) {
+	rm.mu.RLock()
+	u := rm.cloneURLs[owner+"/"+repo]
+	rm.mu.RUnlock()
+	if !strings.HasPrefix(u, "file://") {
+		return "", &RepoError{Owner: owner, Repo: repo, Op: "open", Err: fmt.Errorf("no directory recorded for local source")}
+	}
+	dir := filepath.FromSlash(strings.TrimPrefix(u, "file://"))
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return "", &RepoError{Owner: owner, Repo: repo, Op: "open", Err: fmt.Errorf("local directory %s is missing", dir)}
+	}
+	return dir, nil
+}
+
+// localSourceAt reports whether localPath is the live directory of a
+// registered local source, and which one.
+func (rm *RepositoryManager) localSourceAt(localPath string) (string, string, bool) {
+	fileURL := "file://" + filepath.ToSlash(filepath.Clean(localPath))
+	rm.mu.RLock()
+	defer rm.mu.RUnlock()
+	for key, u := range rm.cloneURLs {
+		if u == fileURL && IsLocalOwner(key) {
+			i := strings.LastIndex(key, "/")
+			return key[:i], key[i+1:], true
+		}
+	}
+	return "", "", false
+}
+
+// cloneURL returns the URL to clone owner/repo from.
+func (rm *RepositoryManager) cloneURL(owner, repo string) string {
+	rm.mu.RLock()
+	defer rm.mu.RUnlock()
+	if u := rm.cloneURLs[owner+"/"+repo]; u != "" {
+		return u
+	}
+	return DefaultCloneURL(owner, repo)
 }
 
 // GetRepoPath returns the local path for a repository.
@@ -123,6 +303,11 @@ func (rm *RepositoryManager) markRecentlyUpdated(owner, repo string) {
 // Uses per-repository locking and timeout for better concurrency.
 // Skips fetch if the repo was updated within RecentUpdateTTL to avoid redundant network calls.
 func (rm *RepositoryManager) CloneOrUpdate(ctx context.Context, owner, repo string) (string, error) {
+	// Local directory sources are read in place; there is nothing to fetch
+	if IsLocalOwner(owner) {
+		return rm.localDir(owner, repo)
+	}
+
 	localPath := rm.GetRepoPath(owner, repo)
 
 	// Fast path: if repo exists and was recently updated, skip the fetch entirely
@@ -169,38 +354,44 @@ func (rm *RepositoryManager) CloneOrUpdate(ctx context.Context, owner, repo stri
 	return localPath, nil
 }
 
-// cloneRepository clones a new repository as a working tree (shallow clone).
+// cloneRepository clones a new repository as a working tree: a shallow
+// clone unless the repository keeps its full history.
 func (rm *RepositoryManager) cloneRepository(ctx context.Context, localPath, owner, repo string) error {
 	// Ensure parent directory exists
 	if err := os.MkdirAll(localPath, 0755); err != nil {
 		return &RepoError{Owner: owner, Repo: repo, Op: "clone", Err: err}
 	}
 
-	cloneURL := fmt.Sprintf("https://github.com/%s/%s.git", owner, repo)
+	cloneURL := rm.cloneURL(owner, repo)
 
 	cloneOpts := &git.CloneOptions{
 		URL:          cloneURL,
 		SingleBranch: true, // Only fetch default branch
 		Tags:         git.NoTags,
 		Depth:        1, // Shallow clone for efficiency
+		Auth:         rm.authFor(cloneURL),
 	}
-
-	// Add authentication if token provided
-	if rm.token != "" {
-		cloneOpts.Auth = &gitHttp.BasicAuth{
-			Username: "oauth2",
-			Password: rm.token,
-		}
+	if rm.wantsFullHistory(owner, repo) {
+		cloneOpts.Depth = 0
 	}
 
 	// Clone as working tree repository
-	_, err := git.PlainCloneContext(ctx, localPath, false, cloneOpts)
+	r, err := git.PlainCloneContext(ctx, localPath, false, cloneOpts)
 	if err != nil {
 		// Clean up partial clone on failure (best-effort)
 		_ = os.RemoveAll(localPath)
 		return &RepoError{Owner: owner, Repo: repo, Op: "clone", Err: err}
 	}
 
+	// Move to the tracked branch or tag; a clone without it would be
+	// mistaken for a good one on the next update
+	if ref := rm.trackedRef(owner, repo); ref != "" {
+		if _, err := rm.checkoutRef(ctx, r, owner, repo, ref); err != nil {
+			_ = os.RemoveAll(localPath)
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -211,17 +402,23 @@ func (rm *RepositoryManager) updateRepository(ctx context.Context, localPath, ow
 		return &RepoError{Owner: owner, Repo: repo, Op: "open", Err: err}
 	}
 
+	// Sources following a branch or tag fetch just that ref
+	if ref := rm.trackedRef(owner, repo); ref != "" {
+		if _, err := rm.checkoutRef(ctx, r, owner, repo, ref); err != nil {
+			return err
+		}
+		now := time.Now()
+		_ = os.Chtimes(localPath, now, now)
+		return nil
+	}
+
 	fetchOpts := &git.FetchOptions{
 		Force: true,
 		Tags:  git.NoTags,
+		Auth:  rm.originAuth(r),
 	}
-
-	// Add authentication if token provided
-	if rm.token != "" {
-		fetchOpts.Auth = &gitHttp.BasicAuth{
-			Username: "oauth2",
-			Password: rm.token,
-		}
+	if depth := rm.fetchDepth(r, owner, repo); depth == unshallowDepth {
+		fetchOpts.Depth = depth
 	}
 
 	// Fetch latest changes
@@ -229,6 +426,11 @@ func (rm *RepositoryManager) updateRepository(ctx context.Context, localPath, ow
 	if err != nil && err != git.NoErrAlreadyUpToDate {
 		return &RepoError{Owner: owner, Repo: repo, Op: "fetch", Err: err}
 	}
+	if fetchOpts.Depth == unshallowDepth {
+		if err := dropFilledShallows(r); err != nil {
+			return &RepoError{Owner: owner, Repo: repo, Op: "fetch", Err: err}
+		}
+	}
 
 	// Update working tree to match remote HEAD
 	// This is critical for symlinks to reflect the latest content
@@ -293,18 +495,323 @@ func (rm *RepositoryManager) GetCommitSHA(localPath string) (string, error) {
 	return ref.Hash().String(), nil
 }
 
-// GetRepositoryInfo extracts metadata from local repository and scrapes GitHub for stars/forks.
+// ResolveRef fetches a tag, branch or commit into an existing clone and
+// returns its commit SHA, leaving the working tree where it is. Tags are
+// tried before branches.
+func (rm *RepositoryManager) ResolveRef(ctx context.Context, owner, repo, ref string) (string, error) {
+	localPath := rm.GetRepoPath(owner, repo)
+
+	repoLock := rm.getRepoLock(owner, repo)
+	repoLock.Lock()
+	defer repoLock.Unlock()
+
+	r, err := git.PlainOpen(localPath)
+	if err != nil {
+		return "", &RepoError{Owner: owner, Repo: repo, Op: "open", Err: err}
+	}
+	hash, err := rm.resolveRef(ctx, r, owner, repo, ref)
+	if err != nil {
+		return "", err
+	}
+	return hash.String(), nil
+}
+
+// checkoutCommit resets the working tree to a commit, fetching it first if
+// the clone does not contain it; the caller holds the repo lock.
+func (rm *RepositoryManager) checkoutCommit(ctx context.Context, r *git.Repository, owner, repo string, hash plumbing.Hash) error {
+	if head, err := r.Head(); err == nil && head.Hash() == hash {
+		return nil
+	}
+	if err := rm.fetchCommit(ctx, r, owner, repo, hash); err != nil {
+		return err
+	}
+
+	w, err := r.Worktree()
+	if err != nil {
+		return &RepoError{Owner: owner, Repo: repo, Op: "worktree", Err: err}
+	}
+	if err := w.Reset(&git.ResetOptions{Commit: hash, Mode: git.HardReset}); err != nil {
+		return &RepoError{Owner: owner, Repo: repo, Op: "reset", Err: err}
+	}
+	return nil
+}
+
+// fetchCommit fetches a commit the clone does not contain yet, such as one
+// older than a shallow clone's history; the caller holds the repo lock.
+func (rm *RepositoryManager) fetchCommit(ctx context.Context, r *git.Repository, owner, repo string, hash plumbing.Hash) error {
+	if _, err := r.CommitObject(hash); err == nil {
+		return nil
+	}
+
+	var cancel context.CancelFunc
+	if deadline, ok := ctx.Deadline(); !ok || time.Until(deadline) > DefaultRepoTimeout {
+		ctx, cancel = context.WithTimeout(ctx, DefaultRepoTimeout)
+		defer cancel()
+	}
+
+	fetchOpts := &git.FetchOptions{
+		RefSpecs: []gitConfig.RefSpec{gitConfig.RefSpec(hash.String() + ":refs/skulto/locked")},
+		Depth:    rm.fetchDepth(r, owner, repo),
+		Force:    true,
+		Tags:     git.NoTags,
+		Auth:     rm.originAuth(r),
+	}
+	err := r.FetchContext(ctx, fetchOpts)
+	if errors.Is(err, git.ErrExactSHA1NotSupported) {
+		// Servers that refuse fetching by SHA need the full history instead
+		fetchOpts.RefSpecs = []gitConfig.RefSpec{"+refs/heads/*:refs/remotes/origin/*"}
+		fetchOpts.Depth = unshallowDepth
+		err = r.FetchContext(ctx, fetchOpts)
+	}
+	if err != nil && err != git.NoErrAlreadyUpToDate {
+		return &RepoError{Owner: owner, Repo: repo, Op: "fetch", Err: err}
+	}
+	if fetchOpts.Depth == unshallowDepth {
+		if err := dropFilledShallows(r); err != nil {
+			return &RepoError{Owner: owner, Repo: repo, Op: "fetch", Err: err}
+		}
+	}
+	return nil
+}
+
+// checkoutRef resets the working tree to a tag, branch or commit and
+// returns the resulting commit SHA, for sources that follow a branch or tag
+// (SetRef); the caller holds the repo lock.
+func (rm *RepositoryManager) checkoutRef(ctx context.Context, r *git.Repository, owner, repo, ref string) (string, error) {
+	hash, err := rm.resolveRef(ctx, r, owner, repo, ref)
+	if err != nil {
+		return "", err
+	}
+	w, err := r.Worktree()
+	if err != nil {
+		return "", &RepoError{Owner: owner, Repo: repo, Op: "worktree", Err: err}
+	}
+	if err := w.Reset(&git.ResetOptions{Commit: hash, Mode: git.HardReset}); err != nil {
+		return "", &RepoError{Owner: owner, Repo: repo, Op: "reset", Err: err}
+	}
+	return hash.String(), nil
+}
+
+// resolveRef does the work of ResolveRef; the caller holds the repo lock.
+func (rm *RepositoryManager) resolveRef(ctx context.Context, r *git.Repository, owner, repo, ref string) (plumbing.Hash, error) {
+	if plumbing.IsHash(ref) {
+		hash := plumbing.NewHash(ref)
+		return hash, rm.fetchCommit(ctx, r, owner, repo, hash)
+	}
+
+	var cancel context.CancelFunc
+	if deadline, ok := ctx.Deadline(); !ok || time.Until(deadline) > DefaultRepoTimeout {
+		ctx, cancel = context.WithTimeout(ctx, DefaultRepoTimeout)
+		defer cancel()
+	}
+
+	candidates := []plumbing.ReferenceName{
+		plumbing.NewTagReferenceName(ref),
+		plumbing.NewRemoteReferenceName("origin", ref),
+	}
+	refSpecs := []gitConfig.RefSpec{
+		gitConfig.RefSpec("+refs/tags/" + ref + ":refs/tags/" + ref),
+		gitConfig.RefSpec("+refs/heads/" + ref + ":refs/remotes/origin/" + ref),
+	}
+
+	var lastErr error
+	for i, spec := range refSpecs {
+		fetchOpts := &git.FetchOptions{
+			RefSpecs: []gitConfig.RefSpec{spec},
+			Depth:    rm.fetchDepth(r, owner, repo),
+			Force:    true,
+			Tags:     git.NoTags,
+			Auth:     rm.originAuth(r),
+		}
+		err := r.FetchContext(ctx, fetchOpts)
+		if errors.Is(err, git.NoMatchingRefSpecError{}) {
+			lastErr = err
+			continue
+		}
+		if err != nil && err != git.NoErrAlreadyUpToDate {
+			return plumbing.ZeroHash, &RepoError{Owner: owner, Repo: repo, Op: "fetch", Err: err}
+		}
+		if fetchOpts.Depth == unshallowDepth {
+			if err := dropFilledShallows(r); err != nil {
+				return plumbing.ZeroHash, &RepoError{Owner: owner, Repo: repo, Op: "fetch", Err: err}
+			}
+		}
+
+		hash, err := peelReference(r, candidates[i])
+		if err != nil {
+			return plumbing.ZeroHash, &RepoError{Owner: owner, Repo: repo, Op: "resolve-ref", Err: err}
+		}
+		return hash, nil
+	}
+
+	return plumbing.ZeroHash, &RepoError{Owner: owner, Repo: repo, Op: "resolve-ref", Err: fmt.Errorf("no tag or branch named %q: %w", ref, lastErr)}
+}
+
+// peelReference returns the commit a reference points at. Annotated tags
+// point at a tag object and are peeled to their commit.
+func peelReference(r *git.Repository, name plumbing.ReferenceName) (plumbing.Hash, error) {
+	resolved, err := r.Reference(name, true)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	hash := resolved.Hash()
+	if tag, err := r.TagObject(hash); err == nil {
+		commit, err := tag.Commit()
+		if err != nil {
+			return plumbing.ZeroHash, err
+		}
+		hash = commit.Hash
+	}
+	return hash, nil
+}
+
+// ReadFileAt returns a file as of a tag, branch or commit already in an
+// existing clone, without touching the working tree. Tags are tried before
+// branches; ResolveRef fetches a ref that is not there yet.
+func (rm *RepositoryManager) ReadFileAt(owner, repo, ref, relativePath string) ([]byte, error) {
+	repoLock := rm.getRepoLock(owner, repo)
+	repoLock.Lock()
+	defer repoLock.Unlock()
+
+	r, err := git.PlainOpen(rm.GetRepoPath(owner, repo))
+	if err != nil {
+		return nil, &RepoError{Owner: owner, Repo: repo, Op: "open", Err: err}
+	}
+
+	hash := plumbing.NewHash(ref)
+	if !plumbing.IsHash(ref) {
+		hash, err = peelReference(r, plumbing.NewTagReferenceName(ref))
+		if err != nil {
+			hash, err = peelReference(r, plumbing.NewRemoteReferenceName("origin", ref))
+		}
+		if err != nil {
+			return nil, &RepoError{Owner: owner, Repo: repo, Op: "resolve-ref", Err: fmt.Errorf("no tag or branch named %q in the clone: %w", ref, err)}
+		}
+	}
+	commit, err := r.CommitObject(hash)
+	if err != nil {
+		return nil, &RepoError{Owner: owner, Repo: repo, Op: "resolve-ref", Err: err}
+	}
+	file, err := commit.File(relativePath)
+	if err != nil {
+		return nil, fmt.Errorf("%s at %s: %w", relativePath, ref, err)
+	}
+	content, err := file.Contents()
+	if err != nil {
+		return nil, fmt.Errorf("%s at %s: %w", relativePath, ref, err)
+	}
+	return []byte(content), nil
+}
+
+// PinPath returns the directory PinCommit checks a commit out to.
+func (rm *RepositoryManager) PinPath(owner, repo, sha string) string {
+	return filepath.Join(rm.baseDir, pinsDir, owner, repo, sha)
+}
+
+// PinCommit checks out a commit of an existing clone into a directory of
+// its own, keyed by SHA, and returns that directory. Installs pinned by
+// skulto.lock or a manifest ref link there, so the shared clone keeps
+// following its source and a pin in one project does not move the skills
+// installed from the clone elsewhere. The commit is fetched into the clone
+// first if needed.
+func (rm *RepositoryManager) PinCommit(ctx context.Context, owner, repo, sha string) (string, error) {
+	pinPath := rm.PinPath(owner, repo, sha)
+	if _, err := os.Stat(pinPath); err == nil {
+		return pinPath, nil
+	}
+
+	repoLock := rm.getRepoLock(owner, repo)
+	repoLock.Lock()
+	defer repoLock.Unlock()
+
+	if _, err := os.Stat(pinPath); err == nil {
+		return pinPath, nil
+	}
+
+	r, err := git.PlainOpen(rm.GetRepoPath(owner, repo))
+	if err != nil {
+		return "", &RepoError{Owner: owner, Repo: repo, Op: "open", Err: err}
+	}
+	hash := plumbing.NewHash(sha)
+	if err := rm.fetchCommit(ctx, r, owner, repo, hash); err != nil {
+		return "", err
+	}
+	commit, err := r.CommitObject(hash)
+	if err != nil {
+		return "", &RepoError{Owner: owner, Repo: repo, Op: "pin", Err: err}
+	}
+
+	// Write into a scratch directory and rename it into place, so an
+	// interrupted pin is never mistaken for a complete one
+	if err := os.MkdirAll(filepath.Dir(pinPath), 0755); err != nil {
+		return "", &RepoError{Owner: owner, Repo: repo, Op: "pin", Err: err}
+	}
+	tmp, err := os.MkdirTemp(filepath.Dir(pinPath), ".pin-")
+	if err != nil {
+		return "", &RepoError{Owner: owner, Repo: repo, Op: "pin", Err: err}
+	}
+	if err := writeCommitTree(commit, tmp); err != nil {
+		_ = os.RemoveAll(tmp)
+		return "", &RepoError{Owner: owner, Repo: repo, Op: "pin", Err: err}
+	}
+	if err := os.Rename(tmp, pinPath); err != nil {
+		_ = os.RemoveAll(tmp)
+		return "", &RepoError{Owner: owner, Repo: repo, Op: "pin", Err: err}
+	}
+	return pinPath, nil
+}
+
+// writeCommitTree writes the files of commit below dir. Writes go through
+// an os.Root, so paths and symlinks in the tree cannot reach outside dir.
+func writeCommitTree(commit *object.Commit, dir string) error {
+	root, err := os.OpenRoot(dir)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = root.Close() }()
+
+	files, err := commit.Files()
+	if err != nil {
+		return err
+	}
+	return files.ForEach(func(f *object.File) error {
+		name := filepath.FromSlash(f.Name)
+		if !filepath.IsLocal(name) {
+			return fmt.Errorf("unsafe path %q in commit %s", f.Name, commit.Hash)
+		}
+		if err := root.MkdirAll(filepath.Dir(name), 0755); err != nil {
+			return err
+		}
+		content, err := f.Contents()
+		if err != nil {
+			return err
+		}
+		switch f.Mode {
+		case filemode.Symlink:
+			return root.Symlink(content, name)
+		case filemode.Executable:
+			return root.WriteFile(name, []byte(content), 0755)
+		default:
+			return root.WriteFile(name, []byte(content), 0644)
+		}
+	})
+}
+
+// GetRepositoryInfo extracts metadata from a local repository. Stars and
+// forks are left zero; the scraper gets them from the host's metadata
+// provider.
 func (rm *RepositoryManager) GetRepositoryInfo(localPath string) (*RepositoryInfo, error) {
-	// Extract owner/repo from path
-	parts := strings.Split(filepath.Clean(localPath), string(filepath.Separator))
-	if len(parts) < 2 {
-		return nil, fmt.Errorf("invalid repository path: %s", localPath)
+	owner, repo, err := rm.ownerRepoFromPath(localPath)
+	if err != nil {
+		return nil, err
 	}
-	repo := parts[len(parts)-1]
-	owner := parts[len(parts)-2]
 
 	r, err := git.PlainOpen(localPath)
 	if err != nil {
+		// Local directory sources need not be git checkouts
+		if IsLocalOwner(owner) {
+			return rm.localRepositoryInfo(localPath, owner, repo)
+		}
 		return nil, err
 	}
 
@@ -325,18 +832,19 @@ func (rm *RepositoryManager) GetRepositoryInfo(localPath string) (*RepositoryInf
 	// Read description from README.md
 	description := rm.extractDescription(commit)
 
-	// Scrape stars/forks from GitHub HTML
-	stars, forks := rm.ScrapeGitHubMetadata(owner, repo)
-
-	cloneURL := fmt.Sprintf("https://github.com/%s/%s.git", owner, repo)
+	cloneURL := DefaultCloneURL(owner, repo)
+	if remote, err := r.Remote("origin"); err == nil && len(remote.Config().URLs) > 0 {
+		cloneURL = remote.Config().URLs[0]
+	}
+	if IsLocalOwner(owner) {
+		cloneURL = "file://" + filepath.ToSlash(localPath)
+	}
 
 	return &RepositoryInfo{
 		Owner:         owner,
 		Repo:          repo,
 		FullName:      fmt.Sprintf("%s/%s", owner, repo),
 		Description:   description,
-		Stars:         stars,
-		Forks:         forks,
 		DefaultBranch: defaultBranch,
 		CommitSHA:     ref.Hash().String(),
 		CloneURL:      cloneURL,
@@ -345,6 +853,45 @@ func (rm *RepositoryManager) GetRepositoryInfo(localPath string) (*RepositoryInf
 	}, nil
 }
 
+// localRepositoryInfo describes a local directory source that is not a git
+// checkout. It has no commit, so it is rescanned on every pull.
+func (rm *RepositoryManager) localRepositoryInfo(localPath, owner, repo string) (*RepositoryInfo, error) {
+	info, err := os.Stat(localPath)
+	if err != nil {
+		return nil, err
+	}
+	return &RepositoryInfo{
+		Owner:     owner,
+		Repo:      repo,
+		FullName:  owner + "/" + repo,
+		CloneURL:  "file://" + filepath.ToSlash(localPath),
+		LocalPath: localPath,
+		UpdatedAt: info.ModTime(),
+	}, nil
+}
+
+// ownerRepoFromPath recovers owner and repo from a clone path. Clones under
+// baseDir may have multi-element owners (host/namespace); local directory
+// sources are looked up by their recorded directory; other paths fall back
+// to the last two elements.
+func (rm *RepositoryManager) ownerRepoFromPath(localPath string) (string, string, error) {
+	localPath = filepath.Clean(localPath)
+	if owner, repo, ok := rm.localSourceAt(localPath); ok {
+		return owner, repo, nil
+	}
+	if rel, err := filepath.Rel(filepath.Clean(rm.baseDir), localPath); err == nil && !strings.HasPrefix(rel, "..") {
+		rel = filepath.ToSlash(rel)
+		if i := strings.LastIndex(rel, "/"); i > 0 {
+			return rel[:i], rel[i+1:], nil
+		}
+	}
+	parts := strings.Split(localPath, string(filepath.Separator))
+	if len(parts) < 2 {
+		return "", "", fmt.Errorf("invalid repository path: %s", localPath)
+	}
+	return parts[len(parts)-2], parts[len(parts)-1], nil
+}
+
 // extractDescription reads the first paragraph from README.md.
 func (rm *RepositoryManager) extractDescription(commit *object.Commit) string {
 	tree, err := commit.Tree()
@@ -393,111 +940,14 @@ func (rm *RepositoryManager) extractDescription(commit *object.Commit) string {
 	return ""
 }
 
-// ScrapeGitHubMetadata fetches stars and forks count from GitHub HTML page.
-// Returns (0, 0) on any error (graceful fallback).
-func (rm *RepositoryManager) ScrapeGitHubMetadata(owner, repo string) (int, int) {
-	url := fmt.Sprintf("https://github.com/%s/%s", owner, repo)
-
-	client := &http.Client{
-		Timeout: 10 * time.Second,
-	}
-
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return 0, 0
-	}
-
-	// Set a user agent to avoid being blocked
-	req.Header.Set("User-Agent", "Mozilla/5.0 (compatible; Skulto/1.0)")
-
-	resp, err := client.Do(req)
-	if err != nil {
-		return 0, 0
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	if resp.StatusCode != http.StatusOK {
-		return 0, 0
-	}
-
-	// Read the response body (limit to 500KB to avoid memory issues)
-	body, err := io.ReadAll(io.LimitReader(resp.Body, 500*1024))
-	if err != nil {
-		return 0, 0
-	}
-
-	html := string(body)
-
-	stars := parseMetricFromHTML(html, "stargazer")
-	forks := parseMetricFromHTML(html, "fork")
-
-	return stars, forks
-}
-
-// parseMetricFromHTML extracts a metric count from GitHub HTML.
-// Looks for patterns like "1.2k" or "123" near the metric keyword.
-func parseMetricFromHTML(html, metric string) int {
-	// Pattern 1: Look for id="repo-stars-counter-star" or similar with a title attribute
-	// Example: <span id="repo-stars-counter-star" ... title="1,234" ...>1.2k</span>
-	titlePattern := regexp.MustCompile(fmt.Sprintf(`(?i)%s[^>]*title="([0-9,]+)"`, metric))
-	if matches := titlePattern.FindStringSubmatch(html); len(matches) > 1 {
-		return parseCount(matches[1])
-	}
-
-	// Pattern 2: Look for data attributes
-	// Example: <span ... data-view-component="true">1.2k</span> near "Star" or "Fork"
-	dataPattern := regexp.MustCompile(fmt.Sprintf(`(?i)%s[^<]*</[^>]+>\s*<[^>]*>([0-9,.kKmM]+)`, metric))
-	if matches := dataPattern.FindStringSubmatch(html); len(matches) > 1 {
-		return parseCount(matches[1])
-	}
-
-	// Pattern 3: Look for "X stars" or "X forks" text patterns
-	textPattern := regexp.MustCompile(fmt.Sprintf(`([0-9,.kKmM]+)\s*%ss?`, metric))
-	if matches := textPattern.FindStringSubmatch(html); len(matches) > 1 {
-		return parseCount(matches[1])
-	}
-
-	// Pattern 4: aria-label pattern (common in newer GitHub UI)
-	ariaPattern := regexp.MustCompile(fmt.Sprintf(`aria-label="([0-9,]+)\s+%s`, metric))
-	if matches := ariaPattern.FindStringSubmatch(html); len(matches) > 1 {
-		return parseCount(matches[1])
-	}
-
-	return 0
-}
-
-// parseCount converts a count string (like "1.2k", "1,234") to an integer.
-func parseCount(s string) int {
-	s = strings.TrimSpace(s)
-	s = strings.ReplaceAll(s, ",", "")
-
-	// Handle k/m suffixes
-	multiplier := 1
-	if strings.HasSuffix(strings.ToLower(s), "k") {
-		multiplier = 1000
-		s = s[:len(s)-1]
-	} else if strings.HasSuffix(strings.ToLower(s), "m") {
-		multiplier = 1000000
-		s = s[:len(s)-1]
-	}
-
-	// Parse as float to handle "1.2k" style
-	if f, err := strconv.ParseFloat(s, 64); err == nil {
-		return int(f * float64(multiplier))
-	}
-
-	// Try parsing as int
-	if i, err := strconv.Atoi(s); err == nil {
-		return i * multiplier
-	}
-
-	return 0
-}
-
 // ListSkillFiles finds all skill files in the repository using git tree traversal.
 // Searches the entire repository tree for skill files (SKILL.md, skill.md, CLAUDE.md).
 // If skillPath is set, only searches within that specific path.
 func (rm *RepositoryManager) ListSkillFiles(localPath string, skillPath string) ([]string, error) {
+	if _, _, ok := rm.localSourceAt(localPath); ok {
+		return listWorkingTreeSkillFiles(localPath, skillPath)
+	}
+
 	var skillFiles []string
 
 	r, err := git.PlainOpen(localPath)
@@ -573,6 +1023,11 @@ func IsSkillFilePath(path string) bool {
 
 // ReadFile reads a file from the local bare repository.
 func (rm *RepositoryManager) ReadFile(localPath, relativePath string) (string, error) {
+	if _, _, ok := rm.localSourceAt(localPath); ok {
+		content, _, err := readWorkingTreeFile(localPath, relativePath)
+		return string(content), err
+	}
+
 	r, err := git.PlainOpen(localPath)
 	if err != nil {
 		return "", err
@@ -616,6 +1071,10 @@ type DirEntry struct {
 // ListDirectory lists files and directories in a path within the repository.
 // Returns nil if the directory doesn't exist.
 func (rm *RepositoryManager) ListDirectory(localPath, dirPath string) ([]DirEntry, error) {
+	if _, _, ok := rm.localSourceAt(localPath); ok {
+		return listWorkingTreeDirectory(localPath, dirPath)
+	}
+
 	r, err := git.PlainOpen(localPath)
 	if err != nil {
 		return nil, err
@@ -667,6 +1126,10 @@ func (rm *RepositoryManager) ListDirectory(localPath, dirPath string) ([]DirEntr
 
 // ReadFileBytes reads a file from the local repository and returns bytes.
 func (rm *RepositoryManager) ReadFileBytes(localPath, relativePath string) ([]byte, int64, error) {
+	if _, _, ok := rm.localSourceAt(localPath); ok {
+		return readWorkingTreeFile(localPath, relativePath)
+	}
+
 	r, err := git.PlainOpen(localPath)
 	if err != nil {
 		return nil, 0, err
@@ -715,6 +1178,15 @@ func (rm *RepositoryManager) GetLicenseFile(localPath string) (string, string, e
 		"LICENSE.rst", "LICENCE", "LICENCE.md",
 	}
 
+	if _, _, ok := rm.localSourceAt(localPath); ok {
+		for _, name := range licenseNames {
+			if content, _, err := readWorkingTreeFile(localPath, name); err == nil {
+				return name, string(content), nil
+			}
+		}
+		return "", "", nil
+	}
+
 	r, err := git.PlainOpen(localPath)
 	if err != nil {
 		return "", "", err
@@ -758,50 +1230,27 @@ func (rm *RepositoryManager) CleanupOldRepos(maxAge time.Duration) error {
 	cutoff := time.Now().Add(-maxAge)
 
 	// Check if base directory exists
-	entries, err := os.ReadDir(rm.baseDir)
-	if err != nil {
+	if _, err := os.ReadDir(rm.baseDir); err != nil {
 		if os.IsNotExist(err) {
 			return nil // Base dir doesn't exist, nothing to clean
 		}
 		return err
 	}
 
-	for _, ownerEntry := range entries {
-		if !ownerEntry.IsDir() {
-			continue
-		}
-
-		ownerPath := filepath.Join(rm.baseDir, ownerEntry.Name())
-		repoEntries, err := os.ReadDir(ownerPath)
+	for _, clone := range rm.Clones() {
+		info, err := os.Stat(clone.Path)
 		if err != nil {
 			continue
 		}
 
-		for _, repoEntry := range repoEntries {
-			if !repoEntry.IsDir() {
-				continue
-			}
-
-			repoPath := filepath.Join(ownerPath, repoEntry.Name())
-			info, err := os.Stat(repoPath)
-			if err != nil {
-				continue
-			}
-
-			// Check modification time
-			if info.ModTime().Before(cutoff) {
-				// Acquire per-repo lock before deletion to prevent race with CloneOrUpdate
-				repoLock := rm.getRepoLock(ownerEntry.Name(), repoEntry.Name())
-				repoLock.Lock()
-				_ = os.RemoveAll(repoPath)
-				repoLock.Unlock()
-			}
-		}
-
-		// Clean up empty owner directories (best-effort)
-		remaining, _ := os.ReadDir(ownerPath)
-		if len(remaining) == 0 {
-			_ = os.Remove(ownerPath)
+		// Check modification time
+		if info.ModTime().Before(cutoff) {
+			// Acquire per-repo lock before deletion to prevent race with CloneOrUpdate
+			repoLock := rm.getRepoLock(clone.Owner, clone.Repo)
+			repoLock.Lock()
+			_ = os.RemoveAll(clone.Path)
+			repoLock.Unlock()
+			rm.removeEmptyParents(clone.Path)
 		}
 	}
 
@@ -828,12 +1277,91 @@ func (rm *RepositoryManager) RemoveRepository(owner, repo string) error {
 		return fmt.Errorf("remove repository %s/%s: %w", owner, repo, err)
 	}
 
-	// Clean up empty owner directory (best-effort)
-	ownerPath := filepath.Dir(repoPath)
-	remaining, _ := os.ReadDir(ownerPath)
-	if len(remaining) == 0 {
-		_ = os.Remove(ownerPath)
+	// Clean up empty owner directories (best-effort)
+	rm.removeEmptyParents(repoPath)
+
+	// Pinned checkouts go with the clone they came from
+	pins := filepath.Join(rm.baseDir, pinsDir, owner, repo)
+	if err := os.RemoveAll(pins); err != nil {
+		return fmt.Errorf("remove pinned checkouts of %s/%s: %w", owner, repo, err)
 	}
+	rm.removeEmptyParents(pins)
 
 	return nil
 }
+
+// Clone is a repository directory found under baseDir.
+type Clone struct {
+	Owner string
+	Repo  string
+	Path  string
+}
+
+// Clones finds repository directories under baseDir. GitHub clones sit
+// at owner/repo; clones from other hosts sit deeper, under host/namespace,
+// so a directory below the top level is a clone unless it has no .git of
+// its own and holds clones further down.
+func (rm *RepositoryManager) Clones() []Clone {
+	var clones []Clone
+	var walk func(dir, rel string)
+	walk = func(dir, rel string) {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return
+		}
+		for _, e := range entries {
+			if !e.IsDir() || e.Name() == ".git" || (rel == "" && e.Name() == pinsDir) {
+				continue
+			}
+			path := filepath.Join(dir, e.Name())
+			if rel == "" || (!isClone(path) && containsClone(path, 8)) {
+				walk(path, strings.TrimPrefix(rel+"/"+e.Name(), "/"))
+				continue
+			}
+			clones = append(clones, Clone{Owner: rel, Repo: e.Name(), Path: path})
+		}
+	}
+	walk(rm.baseDir, "")
+	return clones
+}
+
+// isClone reports whether dir is a git working tree.
+func isClone(dir string) bool {
+	_, err := os.Stat(filepath.Join(dir, ".git"))
+	return err == nil
+}
+
+// containsClone reports whether a git working tree exists below dir,
+// looking at most depth levels down.
+func containsClone(dir string, depth int) bool {
+	if depth == 0 {
+		return false
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return false
+	}
+	for _, e := range entries {
+		if !e.IsDir() || e.Name() == ".git" {
+			continue
+		}
+		path := filepath.Join(dir, e.Name())
+		if isClone(path) || containsClone(path, depth-1) {
+			return true
+		}
+	}
+	return false
+}
+
+// removeEmptyParents removes the now-empty owner (and host) directories
+// above a deleted clone, stopping at baseDir.
+func (rm *RepositoryManager) removeEmptyParents(repoPath string) {
+	base := filepath.Clean(rm.baseDir)
+	for dir := filepath.Dir(repoPath); dir != base && strings.HasPrefix(dir, base+string(filepath.Separator)); dir = filepath.Dir(dir) {
+		remaining, _ := os.ReadDir(dir)
+		if len(remaining) > 0 {
+			return
+		}
+		_ = os.Remove(dir)
+	}
+}
@@ -6,6 +6,12 @@ import (
 	"path/filepath"
 	"testing"
 	"time"
+
+	"github.com/go-git/go-git/v5"
+	gitConfig "github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	gitHttp "github.com/go-git/go-git/v5/plumbing/transport/http"
 )
 
 func TestIsSkillFilePath(t *testing.T) {
@@ -100,38 +106,6 @@ func TestRepoError(t *testing.T) {
 	}
 }
 
-func TestScrapeGitHubMetadata(t *testing.T) {
-	rm := NewRepositoryManager(t.TempDir(), "")
-
-	// Test with a well-known repository
-	// This is an integration test that requires network access
-	stars, forks := rm.ScrapeGitHubMetadata("golang", "go")
-
-	// The Go repo has many stars, so this should return positive numbers
-	// We don't check exact values since they change
-	if stars < 0 {
-		t.Errorf("Expected non-negative stars, got %d", stars)
-	}
-	if forks < 0 {
-		t.Errorf("Expected non-negative forks, got %d", forks)
-	}
-}
-
-func TestScrapeGitHubMetadataInvalidRepo(t *testing.T) {
-	rm := NewRepositoryManager(t.TempDir(), "")
-
-	// Test with a non-existent repository
-	stars, forks := rm.ScrapeGitHubMetadata("nonexistent-owner-12345", "nonexistent-repo-67890")
-
-	// Should return 0, 0 for non-existent repos (graceful fallback)
-	if stars != 0 {
-		t.Errorf("Expected 0 stars for non-existent repo, got %d", stars)
-	}
-	if forks != 0 {
-		t.Errorf("Expected 0 forks for non-existent repo, got %d", forks)
-	}
-}
-
 func TestCleanupOldRepos(t *testing.T) {
 	baseDir := t.TempDir()
 	rm := NewRepositoryManager(baseDir, "")
@@ -552,113 +526,6 @@ func TestListDirectoryWorkingTree(t *testing.T) {
 	t.Logf("Found %d entries in root", len(entries))
 }
 
-// TestParseCount tests the parseCount helper function for parsing metric values.
-func TestParseCount(t *testing.T) {
-	tests := []struct {
-		input    string
-		expected int
-	}{
-		// Basic numbers
-		{"0", 0},
-		{"1", 1},
-		{"123", 123},
-		{"1234", 1234},
-
-		// Numbers with commas
-		{"1,234", 1234},
-		{"1,234,567", 1234567},
-
-		// K suffix (thousands)
-		{"1k", 1000},
-		{"1K", 1000},
-		{"1.2k", 1200},
-		{"1.5K", 1500},
-		{"12.3k", 12300},
-
-		// M suffix (millions)
-		{"1m", 1000000},
-		{"1M", 1000000},
-		{"1.5m", 1500000},
-		{"2.5M", 2500000},
-
-		// Edge cases
-		{"", 0},
-		{"abc", 0},
-		{" 123 ", 123},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.input, func(t *testing.T) {
-			result := parseCount(tt.input)
-			if result != tt.expected {
-				t.Errorf("parseCount(%q) = %d, want %d", tt.input, result, tt.expected)
-			}
-		})
-	}
-}
-
-// TestParseMetricFromHTML tests the parseMetricFromHTML helper function.
-func TestParseMetricFromHTML(t *testing.T) {
-	tests := []struct {
-		name     string
-		html     string
-		metric   string
-		expected int
-	}{
-		{
-			name:     "title attribute pattern",
-			html:     `<span id="repo-stars-counter-star" title="1,234">1.2k</span>`,
-			metric:   "star",
-			expected: 1234,
-		},
-		{
-			name:     "aria-label pattern",
-			html:     `<a aria-label="5678 stargazers">Stars</a>`,
-			metric:   "stargazer",
-			expected: 5678,
-		},
-		{
-			name:     "text pattern with stars",
-			html:     `<span>100 stars</span>`,
-			metric:   "star",
-			expected: 100,
-		},
-		{
-			name:     "text pattern with forks",
-			html:     `<span>50 forks</span>`,
-			metric:   "fork",
-			expected: 50,
-		},
-		{
-			name:     "k suffix in text",
-			html:     `<span>1.5k stars</span>`,
-			metric:   "star",
-			expected: 1500,
-		},
-		{
-			name:     "no match",
-			html:     `<span>Hello World</span>`,
-			metric:   "star",
-			expected: 0,
-		},
-		{
-			name:     "empty html",
-			html:     "",
-			metric:   "star",
-			expected: 0,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			result := parseMetricFromHTML(tt.html, tt.metric)
-			if result != tt.expected {
-				t.Errorf("parseMetricFromHTML() = %d, want %d", result, tt.expected)
-			}
-		})
-	}
-}
-
 // TestRepoErrorUnwrap tests that RepoError properly unwraps to the underlying error.
 func TestRepoErrorUnwrap(t *testing.T) {
 	underlyingErr := os.ErrPermission
@@ -905,3 +772,340 @@ func TestUpdateRepositoryResetsWorktree(t *testing.T) {
 			string(originalContent), string(updatedContent))
 	}
 }
+
+// commitFile writes a file into a local repository and commits it.
+func commitFile(t *testing.T, r *git.Repository, dir, name, content string) plumbing.Hash {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	w, err := r.Worktree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Add(name); err != nil {
+		t.Fatal(err)
+	}
+	hash, err := w.Commit("update "+name, &git.CommitOptions{
+		Author: &object.Signature{Name: "test", Email: "test@example.com", When: time.Now()},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return hash
+}
+
+func TestPinCommit(t *testing.T) {
+	upstreamDir := t.TempDir()
+	upstream, err := git.PlainInit(upstreamDir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	first := commitFile(t, upstream, upstreamDir, "SKILL.md", "v1\n")
+	second := commitFile(t, upstream, upstreamDir, "SKILL.md", "v2\n")
+
+	baseDir := t.TempDir()
+	rm := NewRepositoryManager(baseDir, "")
+	localPath := rm.GetRepoPath("owner", "repo")
+	if _, err := git.PlainClone(localPath, false, &git.CloneOptions{URL: upstreamDir, Depth: 1}); err != nil {
+		t.Fatalf("clone: %v", err)
+	}
+
+	// The shallow clone lacks the first commit, so it must be fetched
+	pinPath, err := rm.PinCommit(context.Background(), "owner", "repo", first.String())
+	if err != nil {
+		t.Fatalf("PinCommit(first): %v", err)
+	}
+	if pinPath != rm.PinPath("owner", "repo", first.String()) {
+		t.Errorf("pin path = %s, want %s", pinPath, rm.PinPath("owner", "repo", first.String()))
+	}
+	content, _ := os.ReadFile(filepath.Join(pinPath, "SKILL.md"))
+	if string(content) != "v1\n" {
+		t.Errorf("pinned SKILL.md = %q, want v1", content)
+	}
+
+	// The clone itself stays on its revision
+	content, _ = os.ReadFile(filepath.Join(localPath, "SKILL.md"))
+	if string(content) != "v2\n" {
+		t.Errorf("clone SKILL.md = %q, want v2", content)
+	}
+	if sha, _ := rm.GetCommitSHA(localPath); sha != second.String() {
+		t.Errorf("HEAD = %s, want %s", sha, second)
+	}
+
+	// Pins are not clones, and go away with their repository
+	if clones := rm.Clones(); len(clones) != 1 || clones[0].Path != localPath {
+		t.Errorf("Clones() = %+v, want only %s", clones, localPath)
+	}
+	if err := rm.RemoveRepository("owner", "repo"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(pinPath); !os.IsNotExist(err) {
+		t.Errorf("pin survived RemoveRepository: %v", err)
+	}
+}
+
+func TestResolveRef(t *testing.T) {
+	upstreamDir := t.TempDir()
+	upstream, err := git.PlainInit(upstreamDir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tagged := commitFile(t, upstream, upstreamDir, "SKILL.md", "v1\n")
+	if _, err := upstream.CreateTag("v1.0.0", tagged, &git.CreateTagOptions{
+		Tagger:  &object.Signature{Name: "test", Email: "test@example.com", When: time.Now()},
+		Message: "release",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	head := commitFile(t, upstream, upstreamDir, "SKILL.md", "v2\n")
+
+	baseDir := t.TempDir()
+	rm := NewRepositoryManager(baseDir, "")
+	localPath := rm.GetRepoPath("owner", "repo")
+	if _, err := git.PlainClone(localPath, false, &git.CloneOptions{URL: upstreamDir, Depth: 1, Tags: git.NoTags}); err != nil {
+		t.Fatalf("clone: %v", err)
+	}
+
+	// The annotated tag is fetched and peeled, leaving the working tree alone
+	sha, err := rm.ResolveRef(context.Background(), "owner", "repo", "v1.0.0")
+	if err != nil {
+		t.Fatalf("ResolveRef(tag): %v", err)
+	}
+	if sha != tagged.String() {
+		t.Errorf("tag resolved to %s, want %s", sha, tagged)
+	}
+	if current, _ := rm.GetCommitSHA(localPath); current != head.String() {
+		t.Errorf("ResolveRef moved HEAD to %s, want %s", current, head)
+	}
+	content, err := rm.ReadFileAt("owner", "repo", "v1.0.0", "SKILL.md")
+	if err != nil {
+		t.Fatalf("ReadFileAt(tag): %v", err)
+	}
+	if string(content) != "v1\n" {
+		t.Errorf("SKILL.md at v1.0.0 = %q, want v1", content)
+	}
+
+	branch, err := upstream.Head()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sha, err = rm.ResolveRef(context.Background(), "owner", "repo", branch.Name().Short())
+	if err != nil {
+		t.Fatalf("ResolveRef(branch): %v", err)
+	}
+	if sha != head.String() {
+		t.Errorf("branch resolved to %s, want %s", sha, head)
+	}
+
+	if _, err := rm.ResolveRef(context.Background(), "owner", "repo", "no-such-ref"); err == nil {
+		t.Error("expected error for unknown ref")
+	}
+	if _, err := rm.ReadFileAt("owner", "repo", "no-such-ref", "SKILL.md"); err == nil {
+		t.Error("expected error reading at an unknown ref")
+	}
+}
+
+func TestCloneOrUpdateOtherHost(t *testing.T) {
+	upstreamDir := t.TempDir()
+	upstream, err := git.PlainInit(upstreamDir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	commitFile(t, upstream, upstreamDir, "SKILL.md", "v1\n")
+
+	// Serve the repository as a bare remote over file://
+	bareDir := filepath.Join(t.TempDir(), "skills.git")
+	if _, err := git.PlainClone(bareDir, true, &git.CloneOptions{URL: upstreamDir}); err != nil {
+		t.Fatalf("bare clone: %v", err)
+	}
+	remote := "file://" + bareDir
+
+	baseDir := t.TempDir()
+	rm := NewRepositoryManager(baseDir, "github-token")
+	owner, repo := "git.example.com/platform/ai", "skills"
+	rm.SetCloneURL(owner, repo, remote)
+
+	localPath, err := rm.CloneOrUpdate(context.Background(), owner, repo)
+	if err != nil {
+		t.Fatalf("CloneOrUpdate: %v", err)
+	}
+	if want := filepath.Join(baseDir, "git.example.com", "platform", "ai", "skills"); localPath != want {
+		t.Errorf("localPath = %s, want %s", localPath, want)
+	}
+	content, _ := os.ReadFile(filepath.Join(localPath, "SKILL.md"))
+	if string(content) != "v1\n" {
+		t.Errorf("SKILL.md = %q, want v1", content)
+	}
+
+	info, err := rm.GetRepositoryInfo(localPath)
+	if err != nil {
+		t.Fatalf("GetRepositoryInfo: %v", err)
+	}
+	if info.Owner != owner || info.Repo != repo {
+		t.Errorf("owner/repo = %s/%s, want %s/%s", info.Owner, info.Repo, owner, repo)
+	}
+	if info.CloneURL != remote {
+		t.Errorf("CloneURL = %s, want %s", info.CloneURL, remote)
+	}
+	if info.Stars != 0 {
+		t.Errorf("Stars = %d, want 0 for non-GitHub hosts", info.Stars)
+	}
+
+	// Updates fetch from the clone's origin
+	commitFile(t, upstream, upstreamDir, "SKILL.md", "v2\n")
+	bare, err := git.PlainOpen(bareDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := bare.Fetch(&git.FetchOptions{RefSpecs: []gitConfig.RefSpec{"+refs/heads/*:refs/heads/*"}}); err != nil {
+		t.Fatalf("update bare remote: %v", err)
+	}
+	rm.mu.Lock()
+	delete(rm.recentlyUpdated, owner+"/"+repo)
+	rm.mu.Unlock()
+
+	if _, err := rm.CloneOrUpdate(context.Background(), owner, repo); err != nil {
+		t.Fatalf("CloneOrUpdate (update): %v", err)
+	}
+	content, _ = os.ReadFile(filepath.Join(localPath, "SKILL.md"))
+	if string(content) != "v2\n" {
+		t.Errorf("SKILL.md = %q after update, want v2", content)
+	}
+}
+
+func TestAuthFor(t *testing.T) {
+	isolateAuth(t)
+	rm := NewRepositoryManager(t.TempDir(), "gh-token")
+	rm.SetHostTokens(map[string]string{
+		"gitlab.example.com": "glpat",
+		"bitbucket.org":      "x-token-auth:bb",
+	})
+
+	tests := []struct {
+		remote   string
+		username string
+		password string
+	}{
+		{"https://github.com/owner/repo.git", "oauth2", "gh-token"},
+		{"https://gitlab.example.com/team/skills.git", "oauth2", "glpat"},
+		{"https://bitbucket.org/team/skills.git", "x-token-auth", "bb"},
+		{"https://gitea.example.com/team/skills.git", "", ""},
+		{"git@gitlab.example.com:team/skills.git", "", ""},
+		{"file:///tmp/skills.git", "", ""},
+	}
+	for _, tt := range tests {
+		auth := rm.authFor(tt.remote)
+		if tt.password == "" {
+			if auth != nil {
+				t.Errorf("authFor(%s) = %v, want nil", tt.remote, auth)
+			}
+			continue
+		}
+		basic, ok := auth.(*gitHttp.BasicAuth)
+		if !ok {
+			t.Fatalf("authFor(%s) = %T, want *BasicAuth", tt.remote, auth)
+		}
+		if basic.Username != tt.username || basic.Password != tt.password {
+			t.Errorf("authFor(%s) = %s:%s, want %s:%s", tt.remote, basic.Username, basic.Password, tt.username, tt.password)
+		}
+	}
+}
+
+func TestCleanupOldReposNestedHosts(t *testing.T) {
+	baseDir := t.TempDir()
+	rm := NewRepositoryManager(baseDir, "")
+
+	oldRepo := filepath.Join(baseDir, "git.example.com", "platform", "ai", "old")
+	newRepo := filepath.Join(baseDir, "git.example.com", "platform", "new")
+	for _, p := range []string{oldRepo, newRepo} {
+		if err := os.MkdirAll(filepath.Join(p, ".git"), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	past := time.Now().Add(-30 * 24 * time.Hour)
+	for _, p := range []string{oldRepo, filepath.Dir(oldRepo), filepath.Dir(newRepo)} {
+		if err := os.Chtimes(p, past, past); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := rm.CleanupOldRepos(7 * 24 * time.Hour); err != nil {
+		t.Fatalf("CleanupOldRepos: %v", err)
+	}
+	if _, err := os.Stat(oldRepo); !os.IsNotExist(err) {
+		t.Error("old clone should be removed")
+	}
+	if _, err := os.Stat(filepath.Dir(oldRepo)); !os.IsNotExist(err) {
+		t.Error("empty namespace directory should be removed")
+	}
+	if _, err := os.Stat(newRepo); err != nil {
+		t.Errorf("recent clone should be kept: %v", err)
+	}
+}
+
+func TestCloneOrUpdateTrackedRef(t *testing.T) {
+	upstreamDir := t.TempDir()
+	upstream, err := git.PlainInit(upstreamDir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	commitFile(t, upstream, upstreamDir, "SKILL.md", "main\n")
+	mainBranch, err := upstream.Head()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w, err := upstream.Worktree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	release := plumbing.NewBranchReferenceName("release")
+	if err := w.Checkout(&git.CheckoutOptions{Branch: release, Create: true}); err != nil {
+		t.Fatal(err)
+	}
+	commitFile(t, upstream, upstreamDir, "SKILL.md", "release-1\n")
+	if err := w.Checkout(&git.CheckoutOptions{Branch: mainBranch.Name()}); err != nil {
+		t.Fatal(err)
+	}
+
+	rm := NewRepositoryManager(t.TempDir(), "")
+	rm.SetCloneURL("owner", "repo", "file://"+upstreamDir)
+	rm.SetRef("owner", "repo", "release")
+
+	localPath, err := rm.CloneOrUpdate(context.Background(), "owner", "repo")
+	if err != nil {
+		t.Fatalf("CloneOrUpdate: %v", err)
+	}
+	content, _ := os.ReadFile(filepath.Join(localPath, "SKILL.md"))
+	if string(content) != "release-1\n" {
+		t.Errorf("SKILL.md = %q, want the release branch", content)
+	}
+
+	// Updates follow the branch, not the default HEAD
+	if err := w.Checkout(&git.CheckoutOptions{Branch: release}); err != nil {
+		t.Fatal(err)
+	}
+	commitFile(t, upstream, upstreamDir, "SKILL.md", "release-2\n")
+	rm.mu.Lock()
+	delete(rm.recentlyUpdated, "owner/repo")
+	rm.mu.Unlock()
+
+	if _, err := rm.CloneOrUpdate(context.Background(), "owner", "repo"); err != nil {
+		t.Fatalf("CloneOrUpdate (update): %v", err)
+	}
+	content, _ = os.ReadFile(filepath.Join(localPath, "SKILL.md"))
+	if string(content) != "release-2\n" {
+		t.Errorf("SKILL.md = %q after update, want release-2", content)
+	}
+
+	// A ref the remote doesn't have leaves no clone behind
+	rm.SetCloneURL("owner", "missing", "file://"+upstreamDir)
+	rm.SetRef("owner", "missing", "no-such-branch")
+	if _, err := rm.CloneOrUpdate(context.Background(), "owner", "missing"); err == nil {
+		t.Error("expected an error for an unknown ref")
+	}
+	if _, err := os.Stat(rm.GetRepoPath("owner", "missing")); !os.IsNotExist(err) {
+		t.Error("expected the failed clone to be removed")
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/asteroid-belt/skulto/internal/config"
 	"github.com/asteroid-belt/skulto/internal/db"
 	"github.com/asteroid-belt/skulto/internal/models"
 	"github.com/asteroid-belt/skulto/internal/security"
@@ -65,12 +66,41 @@ type ScraperConfig struct {
 	DataDir      string // Base data directory (~/.agents/skulto), repositories cloned to DataDir/repositories
 	RepoCacheTTL int    // Days to keep cloned repos
 	UseGitClone  bool   // Use git clone instead of GitHub API
+
+	// HostTokens maps git hosts other than GitHub to access tokens
+	HostTokens map[string]string
+
+	// SSHKey is a private key file for ssh remotes, used when no ssh-agent
+	// is running
+	SSHKey           string
+	SSHKeyPassphrase string
+
+	// MetadataProviders overrides the metadata provider kind (MetadataGitHub,
+	// MetadataGitLab or MetadataNone) per host; "*" applies to every host
+	MetadataProviders map[string]string
+}
+
+// ScraperConfigFromConfig returns the scraper settings in skulto's
+// configuration.
+func ScraperConfigFromConfig(cfg *config.Config) ScraperConfig {
+	return ScraperConfig{
+		Token:             cfg.GitHub.Token,
+		DataDir:           cfg.BaseDir,
+		RepoCacheTTL:      cfg.GitHub.RepoCacheTTL,
+		UseGitClone:       cfg.GitHub.UseGitClone,
+		HostTokens:        cfg.Git.HostTokens,
+		SSHKey:            cfg.Git.SSHKey,
+		SSHKeyPassphrase:  cfg.Git.SSHKeyPassphrase,
+		MetadataProviders: cfg.Git.MetadataProviders,
+	}
 }
 
 // Scraper orchestrates the GitHub scraping pipeline.
 type Scraper struct {
 	client            Client
 	gitClient         *GitClient // Keep reference for cleanup operations
+	hostClient        *GitClient // Clones non-GitHub sources when using the GitHub API
+	metadata          *metadataProviders
 	parser            *SkillParser
 	db                *db.DB
 	config            ScraperConfig
@@ -87,6 +117,11 @@ func NewScraperWithConfig(cfg ScraperConfig, database *db.DB) *Scraper {
 		config:            cfg,
 		claimedSlugs:      make(map[string]string),
 		claimedEmbeddings: make(map[string]string),
+		metadata: &metadataProviders{
+			configured: cfg.MetadataProviders,
+			token:      cfg.Token,
+			hostTokens: cfg.HostTokens,
+		},
 	}
 
 	// Clone directory is always DataDir/repositories
@@ -98,15 +133,57 @@ func NewScraperWithConfig(cfg ScraperConfig, database *db.DB) *Scraper {
 	// Choose client based on configuration
 	if cfg.UseGitClone {
 		gitClient := NewGitClient(cfg.Token, cloneDir)
+		gitClient.SetHostTokens(cfg.HostTokens)
+		gitClient.SetSSHKey(cfg.SSHKey, cfg.SSHKeyPassphrase)
 		s.client = gitClient
 		s.gitClient = gitClient
 	} else {
-		s.client = NewGitHubClient(cfg.Token, 0)
+		githubClient := NewGitHubClient(cfg.Token, 0)
+		s.client = githubClient
+		s.metadata.github = githubClient
+		// Other hosts have no API client; always clone them
+		s.hostClient = NewGitClient(cfg.Token, cloneDir)
+		s.hostClient.SetHostTokens(cfg.HostTokens)
+		s.hostClient.SetSSHKey(cfg.SSHKey, cfg.SSHKeyPassphrase)
 	}
 
 	return s
 }
 
+// skillPathFor returns the subdirectory to scan for skills: the one set on
+// the source, else its seed's, else "" for the whole repository.
+func skillPathFor(stored *models.Source, owner, repo string) string {
+	if stored != nil && stored.SkillPath != "" {
+		return stored.SkillPath
+	}
+	for _, seed := range AllSeeds() {
+		if seed.Owner == owner && seed.Repo == repo {
+			return seed.SkillPath
+		}
+	}
+	return ""
+}
+
+// clientFor returns the client that can fetch owner's repositories.
+func (s *Scraper) clientFor(owner string) Client {
+	if s.hostClient != nil && !IsGitHubOwner(owner) {
+		return s.hostClient
+	}
+	return s.client
+}
+
+// cloneClientFor returns the git client for owner's repositories, or nil
+// when they are read through the GitHub API.
+func (s *Scraper) cloneClientFor(owner string) *GitClient {
+	if s.gitClient != nil {
+		return s.gitClient
+	}
+	if !IsGitHubOwner(owner) {
+		return s.hostClient
+	}
+	return nil
+}
+
 // CleanupOldRepositories removes repositories that haven't been accessed recently.
 // Only works when using git clone-based scraping.
 func (s *Scraper) CleanupOldRepositories() error {
@@ -271,23 +348,55 @@ func (s *Scraper) scrapeRepositoryWithOptions(ctx context.Context, owner, repo s
 	repoStart := time.Now()
 	result := &ScrapeResult{}
 
+	client := s.clientFor(owner)
+
+	// Sources added by URL record where to clone from
+	stored, err := s.db.GetSource(fmt.Sprintf("%s/%s", owner, repo))
+	if err != nil {
+		return nil, fmt.Errorf("get source: %w", err)
+	}
+	gc := s.cloneClientFor(owner)
+	if gc == nil && stored != nil && (stored.Ref != "" || stored.FullHistory) {
+		// The GitHub API only reads the default branch and keeps no history;
+		// clone sources that follow another branch or tag or want history
+		gc, client = s.hostClient, s.hostClient
+	}
+	if gc != nil && stored != nil {
+		gc.ConfigureSource(stored)
+	}
+
 	// Get repository info
-	repoInfo, err := s.client.GetRepositoryInfo(ctx, owner, repo)
+	repoInfo, err := client.GetRepositoryInfo(ctx, owner, repo)
 	if err != nil {
 		return nil, fmt.Errorf("get repo info: %w", err)
 	}
 
+	// Clones carry no stars or forks; ask the host. Counts from the GitHub
+	// API are already current
+	if _, fromAPI := client.(*GitHubClient); !fromAPI {
+		fallback := RepoMetadata{}
+		if stored != nil {
+			fallback = RepoMetadata{Stars: stored.Stars, Forks: stored.Forks, Watchers: stored.Watchers}
+		}
+		meta := s.repositoryMetadata(ctx, owner, repo, fallback)
+		info := *repoInfo
+		info.Stars, info.Forks, info.Watchers = meta.Stars, meta.Forks, meta.Watchers
+		repoInfo = &info
+	}
+
 	// Fetch license information for the repository (once, not per-skill)
-	licenseInfo := s.fetchRepositoryLicense(ctx, owner, repo, repoInfo.DefaultBranch)
+	licenseInfo := s.fetchRepositoryLicense(ctx, client, owner, repo, repoInfo.DefaultBranch)
 
 	// Create/update source in database
+	host, _ := SplitOwner(owner)
 	source := &models.Source{
 		ID:            fmt.Sprintf("%s/%s", owner, repo),
 		Owner:         owner,
 		Repo:          repo,
 		FullName:      repoInfo.FullName,
+		Host:          host,
 		Description:   repoInfo.Description,
-		URL:           fmt.Sprintf("https://github.com/%s/%s", owner, repo),
+		URL:           SourceWebURL(owner, repo),
 		CloneURL:      repoInfo.CloneURL,
 		Stars:         repoInfo.Stars,
 		Forks:         repoInfo.Forks,
@@ -298,32 +407,40 @@ func (s *Scraper) scrapeRepositoryWithOptions(ctx context.Context, owner, repo s
 		LicenseURL:    licenseInfo.URL,
 		LicenseFile:   licenseInfo.File,
 	}
-
-	// Determine source type
-	for _, seed := range OfficialSeeds {
-		if seed.Owner == owner && seed.Repo == repo {
-			source.IsOfficial = true
-			source.Priority = seed.Priority
-			break
-		}
+	if stored != nil && stored.URL != "" {
+		source.URL = stored.URL
 	}
-	for _, seed := range CuratedSeeds {
-		if seed.Owner == owner && seed.Repo == repo {
-			source.IsCurated = true
-			source.Priority = seed.Priority
-			break
+	if stored != nil && stored.CloneURL != "" {
+		source.CloneURL = stored.CloneURL
+	}
+	if stored != nil {
+		source.Ref = stored.Ref
+		source.SkillPath = stored.SkillPath
+		source.FullHistory = stored.FullHistory
+	}
+	// Determine source type
+	ApplySeedTier(source)
+	if stored != nil && stored.Registry != "" {
+		// The registry's listing outranks the built-in seeds and what the
+		// repository says about itself
+		source.Registry = stored.Registry
+		source.Tags = stored.Tags
+		source.IsOfficial = stored.IsOfficial
+		source.IsCurated = stored.IsCurated
+		source.Priority = stored.Priority
+		if stored.Description != "" {
+			source.Description = stored.Description
 		}
 	}
+	skillPath := skillPathFor(stored, owner, repo)
 
 	// Check if source already exists with the same commit (skip check if force=true)
-	existingSource, err := s.db.GetSource(source.ID)
-	if err != nil && err.Error() != "record not found" {
-		return nil, fmt.Errorf("get source: %w", err)
-	}
+	existingSource := stored
 
 	// If source exists and commit hasn't changed, skip full re-scraping (unless force=true)
 	// Still check for stale skills that may have been manually added to DB
-	if !force && existingSource != nil && existingSource.LastCommitSHA == repoInfo.CommitSHA && repoInfo.CommitSHA != "" {
+	// Local directories change without commits, so they are always rescanned
+	if !force && !IsLocalOwner(owner) && existingSource != nil && existingSource.LastCommitSHA == repoInfo.CommitSHA && repoInfo.CommitSHA != "" {
 		result.SourcesSkipped = 1
 		// Still update the metadata
 		now := time.Now()
@@ -335,20 +452,7 @@ func (s *Scraper) scrapeRepositoryWithOptions(ctx context.Context, owner, repo s
 		}
 
 		// Still clean up stale skills even when skipping re-scrape
-		skillPath := ""
-		for _, seed := range OfficialSeeds {
-			if seed.Owner == owner && seed.Repo == repo {
-				skillPath = seed.SkillPath
-				break
-			}
-		}
-		for _, seed := range CuratedSeeds {
-			if seed.Owner == owner && seed.Repo == repo {
-				skillPath = seed.SkillPath
-				break
-			}
-		}
-		if skillFiles, err := s.client.ListSkillFiles(ctx, owner, repo, skillPath); err == nil {
+		if skillFiles, err := client.ListSkillFiles(ctx, owner, repo, skillPath); err == nil {
 			foundIDs := make(map[string]bool, len(skillFiles))
 			for _, sf := range skillFiles {
 				foundIDs[sf.ID] = true
@@ -371,17 +475,8 @@ func (s *Scraper) scrapeRepositoryWithOptions(ctx context.Context, owner, repo s
 
 	result.SourcesProcessed = 1
 
-	// Find skill path for this seed
-	skillPath := ""
-	for _, seed := range AllSeeds() {
-		if seed.Owner == owner && seed.Repo == repo {
-			skillPath = seed.SkillPath
-			break
-		}
-	}
-
 	// List skill files in repository
-	skillFiles, err := s.client.ListSkillFiles(ctx, owner, repo, skillPath)
+	skillFiles, err := client.ListSkillFiles(ctx, owner, repo, skillPath)
 	if err != nil {
 		return nil, fmt.Errorf("list skill files: %w", err)
 	}
@@ -417,7 +512,7 @@ func (s *Scraper) scrapeRepositoryWithOptions(ctx context.Context, owner, repo s
 		}
 
 		// Get file content
-		content, err := s.client.GetFileContent(ctx, owner, repo, sf.Path, "")
+		content, err := client.GetFileContent(ctx, owner, repo, sf.Path, "")
 		if err != nil {
 			result.Errors = append(result.Errors, fmt.Errorf("get content %s: %w", sf.Path, err))
 			continue
@@ -943,9 +1038,9 @@ func containsWord(content, word string) bool {
 
 // fetchRepositoryLicense fetches and detects license information for a repository.
 // Returns empty RepositoryLicenseInfo if license not found or on error (non-blocking).
-func (s *Scraper) fetchRepositoryLicense(ctx context.Context, owner, repo, ref string) RepositoryLicenseInfo {
+func (s *Scraper) fetchRepositoryLicense(ctx context.Context, client Client, owner, repo, ref string) RepositoryLicenseInfo {
 	// Fetch LICENSE file from repository
-	fileName, licenseURL, _, err := s.client.GetLicenseFile(ctx, owner, repo, ref)
+	fileName, licenseURL, _, err := client.GetLicenseFile(ctx, owner, repo, ref)
 	if err != nil {
 		// Gracefully handle errors - license is optional
 		return RepositoryLicenseInfo{}
@@ -957,7 +1052,7 @@ func (s *Scraper) fetchRepositoryLicense(ctx context.Context, owner, repo, ref s
 	}
 
 	// Fetch the license content and detect type
-	licenseContent, err := s.client.GetFileContent(ctx, owner, repo, fileName, ref)
+	licenseContent, err := client.GetFileContent(ctx, owner, repo, fileName, ref)
 	if err != nil {
 		// Gracefully handle errors - we still have the file name and URL
 		return RepositoryLicenseInfo{
@@ -9,8 +9,11 @@ import (
 	"testing"
 	"time"
 
+	"github.com/asteroid-belt/skulto/internal/config"
 	"github.com/asteroid-belt/skulto/internal/db"
 	"github.com/asteroid-belt/skulto/internal/models"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -711,3 +714,162 @@ func TestScrapeRepository_CleansUpStaleSkills(t *testing.T) {
 	require.NoError(t, err)
 	assert.Empty(t, installs, "installation records should be removed")
 }
+
+func TestScrapeRepositoryOtherHost(t *testing.T) {
+	upstreamDir := t.TempDir()
+	upstream, err := git.PlainInit(upstreamDir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(upstreamDir, "review"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	commitFile(t, upstream, upstreamDir, "review/SKILL.md", "---\nname: review\ndescription: Review code\n---\n\n# Review\n")
+
+	for _, useGitClone := range []bool{true, false} {
+		t.Run(fmt.Sprintf("UseGitClone=%v", useGitClone), func(t *testing.T) {
+			database, err := db.New(db.Config{Path: ":memory:", MaxIdleConn: 1, MaxOpenConn: 1})
+			if err != nil {
+				t.Fatalf("Failed to create test database: %v", err)
+			}
+			defer func() { _ = database.Close() }()
+
+			source, err := ParseRepositoryURL("https://gitlab.example.com/platform/skills.git")
+			if err != nil {
+				t.Fatal(err)
+			}
+			// Point the recorded remote at the local repository
+			source.CloneURL = "file://" + upstreamDir
+			if err := database.UpsertSource(source); err != nil {
+				t.Fatal(err)
+			}
+
+			s := NewScraperWithConfig(ScraperConfig{DataDir: t.TempDir(), UseGitClone: useGitClone}, database)
+			result, err := s.ScrapeRepository(context.Background(), source.Owner, source.Repo)
+			if err != nil {
+				t.Fatalf("ScrapeRepository: %v", err)
+			}
+			if result.SkillsNew != 1 {
+				t.Errorf("SkillsNew = %d, want 1 (errors: %v)", result.SkillsNew, result.Errors)
+			}
+
+			stored, err := database.GetSource(source.ID)
+			if err != nil || stored == nil {
+				t.Fatalf("GetSource: %v", err)
+			}
+			if stored.Host != "gitlab.example.com" {
+				t.Errorf("Host = %q", stored.Host)
+			}
+			if stored.CloneURL != source.CloneURL {
+				t.Errorf("CloneURL = %q, want %q", stored.CloneURL, source.CloneURL)
+			}
+			if stored.URL != "https://gitlab.example.com/platform/skills" {
+				t.Errorf("URL = %q", stored.URL)
+			}
+		})
+	}
+}
+
+func TestScrapeRepositoryLocalDir(t *testing.T) {
+	dir := t.TempDir()
+	writeSkill := func(name string) {
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, name), 0755))
+		content := fmt.Sprintf("---\nname: %s\ndescription: %s skill\n---\n\n# %s\n", name, name, name)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name, "SKILL.md"), []byte(content), 0644))
+	}
+	writeSkill("review")
+
+	database, err := db.New(db.Config{Path: ":memory:", MaxIdleConn: 1, MaxOpenConn: 1})
+	require.NoError(t, err)
+	defer func() { _ = database.Close() }()
+
+	source, err := ParseRepositoryURL(dir)
+	require.NoError(t, err)
+	require.NoError(t, database.UpsertSource(source))
+
+	s := NewScraperWithConfig(ScraperConfig{DataDir: t.TempDir(), UseGitClone: true}, database)
+	result, err := s.ScrapeRepository(context.Background(), source.Owner, source.Repo)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.SkillsNew)
+
+	// A skill added without any commit is picked up on the next scan
+	writeSkill("deploy")
+	_, err = s.ScrapeRepository(context.Background(), source.Owner, source.Repo)
+	require.NoError(t, err)
+
+	skills, err := database.GetSkillsBySourceID(source.ID)
+	require.NoError(t, err)
+	assert.Len(t, skills, 2)
+
+	stored, err := database.GetSource(source.ID)
+	require.NoError(t, err)
+	require.NotNil(t, stored)
+	assert.Equal(t, models.SourceHostLocal, stored.Host)
+	assert.Equal(t, dir, stored.LocalDir())
+}
+
+func TestScrapeRepositoryRefAndPath(t *testing.T) {
+	upstreamDir := t.TempDir()
+	upstream, err := git.PlainInit(upstreamDir, false)
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(filepath.Join(upstreamDir, "skills", "review"), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(upstreamDir, "examples", "demo"), 0755))
+	commitFile(t, upstream, upstreamDir, "examples/demo/SKILL.md", "---\nname: demo\ndescription: Demo\n---\n\n# Demo\n")
+	mainBranch, err := upstream.Head()
+	require.NoError(t, err)
+
+	// The review skill only exists on the release branch
+	w, err := upstream.Worktree()
+	require.NoError(t, err)
+	require.NoError(t, w.Checkout(&git.CheckoutOptions{Branch: plumbing.NewBranchReferenceName("release"), Create: true}))
+	commitFile(t, upstream, upstreamDir, "skills/review/SKILL.md", "---\nname: review\ndescription: Review code\n---\n\n# Review\n")
+	require.NoError(t, w.Checkout(&git.CheckoutOptions{Branch: mainBranch.Name()}))
+
+	for _, useGitClone := range []bool{true, false} {
+		t.Run(fmt.Sprintf("UseGitClone=%v", useGitClone), func(t *testing.T) {
+			database, err := db.New(db.Config{Path: ":memory:", MaxIdleConn: 1, MaxOpenConn: 1})
+			require.NoError(t, err)
+			defer func() { _ = database.Close() }()
+
+			source, err := ParseRepositoryURL("acme/skills")
+			require.NoError(t, err)
+			source.CloneURL = "file://" + upstreamDir
+			source.Ref = "release"
+			source.SkillPath = "skills"
+			require.NoError(t, database.UpsertSource(source))
+
+			s := NewScraperWithConfig(ScraperConfig{DataDir: t.TempDir(), UseGitClone: useGitClone}, database)
+			result, err := s.ScrapeRepository(context.Background(), source.Owner, source.Repo)
+			require.NoError(t, err)
+			assert.Equal(t, 1, result.SkillsFound, "only skills/ on the release branch")
+
+			skills, err := database.GetSkillsBySourceID(source.ID)
+			require.NoError(t, err)
+			require.Len(t, skills, 1)
+			assert.Equal(t, "skills/review/SKILL.md", skills[0].FilePath)
+
+			stored, err := database.GetSource(source.ID)
+			require.NoError(t, err)
+			require.NotNil(t, stored)
+			assert.Equal(t, "release", stored.Ref)
+			assert.Equal(t, "skills", stored.SkillPath)
+		})
+	}
+}
+
+func TestScraperConfigFromConfig(t *testing.T) {
+	cfg := &config.Config{BaseDir: "/data"}
+	cfg.GitHub.Token = "gh"
+	cfg.GitHub.UseGitClone = true
+	cfg.Git.HostTokens = map[string]string{"gitlab.com": "gl"}
+	cfg.Git.SSHKey = "/keys/id"
+	cfg.Git.MetadataProviders = map[string]string{"*": MetadataNone}
+
+	got := ScraperConfigFromConfig(cfg)
+	if got.Token != "gh" || got.DataDir != "/data" || !got.UseGitClone || got.SSHKey != "/keys/id" {
+		t.Errorf("ScraperConfigFromConfig = %+v", got)
+	}
+	if got.HostTokens["gitlab.com"] != "gl" || got.MetadataProviders["*"] != MetadataNone {
+		t.Errorf("host settings not carried over: %+v", got)
+	}
+}
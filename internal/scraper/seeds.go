@@ -1,6 +1,10 @@
 package scraper
 
-import "strings"
+import (
+	"strings"
+
+	"github.com/asteroid-belt/skulto/internal/models"
+)
 
 // SeedRepository represents a known source of skills.
 type SeedRepository struct {
@@ -71,6 +75,26 @@ var SearchQueries = []string{
 	"filename:SKILL.md path:.cursor/skills",
 }
 
+// ApplySeedTier marks source official or curated, with the seed's
+// priority, when it is one of the built-in seeds. A registry listing
+// overrides this.
+func ApplySeedTier(source *models.Source) {
+	for _, seed := range OfficialSeeds {
+		if seed.Owner == source.Owner && seed.Repo == source.Repo {
+			source.IsOfficial = true
+			source.Priority = seed.Priority
+			break
+		}
+	}
+	for _, seed := range CuratedSeeds {
+		if seed.Owner == source.Owner && seed.Repo == source.Repo {
+			source.IsCurated = true
+			source.Priority = seed.Priority
+			break
+		}
+	}
+}
+
 // AllSeeds returns all seed repositories sorted by priority (highest first).
 func AllSeeds() []SeedRepository {
 	all := make([]SeedRepository, 0, len(OfficialSeeds)+len(CuratedSeeds))
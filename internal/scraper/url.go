@@ -1,63 +1,134 @@
 package scraper
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
 	"strings"
 
 	"github.com/asteroid-belt/skulto/internal/models"
 )
 
+// GitHubHost is the host that "owner/repo" shorthand refers to.
+const GitHubHost = "github.com"
+
 // ParseRepositoryURL parses a repository URL in various formats and returns a Source struct.
 // Supported formats:
-// - owner/repo
+// - owner/repo (GitHub)
 // - https://github.com/owner/repo
 // - https://github.com/owner/repo.git
 // - git@github.com:owner/repo.git
+// - host/group/repo, e.g. gitlab.example.com/team/skills
+// - https://, http://, ssh:// and git:// URLs on any host
+// - git@host:group/repo.git on any host
+// - ./path, ../path, /abs/path, ~/path and file:// URLs (local directories)
+//
+// Sources on hosts other than GitHub keep the host in their owner
+// ("gitlab.example.com/team"), so their IDs and clone paths never collide
+// with GitHub's. Nested GitLab groups become part of the owner.
 func ParseRepositoryURL(urlStr string) (*models.Source, error) {
 	urlStr = strings.TrimSpace(urlStr)
 	if urlStr == "" {
 		return nil, fmt.Errorf("repository URL cannot be empty")
 	}
+	if IsLocalPath(urlStr) {
+		return ParseLocalSource(urlStr)
+	}
 
-	var owner, repo string
+	remote, err := splitRepositoryURL(urlStr)
+	if err != nil {
+		return nil, err
+	}
+	if remote.host == GitHubHost {
+		return parseGitHubPath(urlStr, remote.path)
+	}
+	return parseHostedPath(urlStr, remote)
+}
 
-	// Handle short format: owner/repo
-	if !strings.Contains(urlStr, "://") && !strings.Contains(urlStr, "git@") {
-		parts := strings.Split(urlStr, "/")
-		if len(parts) == 2 && parts[0] != "" && parts[1] != "" {
-			owner = parts[0]
-			repo = strings.TrimSuffix(parts[1], ".git")
-		} else {
-			return nil, fmt.Errorf("invalid repository format: expected 'owner/repo', got '%s'", urlStr)
+// remoteURL is a repository URL broken into the parts ParseRepositoryURL needs.
+type remoteURL struct {
+	host     string // Hostname without port or user
+	path     string // Repository path on the host, without surrounding slashes
+	scheme   string // "https", "http", "ssh" or "git"
+	hostPort string // Host with port, for rebuilding http(s) URLs
+	raw      string // Original URL, used as-is to clone ssh remotes
+	user     string // User named in the URL, if any
+}
+
+// splitRepositoryURL recognises the URL forms accepted by ParseRepositoryURL.
+func splitRepositoryURL(urlStr string) (*remoteURL, error) {
+	// Scheme URLs: https://host/path, ssh://git@host:2222/path, ...
+	if strings.Contains(urlStr, "://") {
+		u, err := url.Parse(urlStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid repository URL %s: %w", urlStr, err)
 		}
-	} else if strings.HasPrefix(urlStr, "https://github.com/") || strings.HasPrefix(urlStr, "http://github.com/") {
-		// Handle HTTPS URL: https://github.com/owner/repo[.git]
-		// Remove protocol and domain
-		parts := strings.TrimPrefix(urlStr, "https://")
-		parts = strings.TrimPrefix(parts, "http://")
-		parts = strings.TrimPrefix(parts, "github.com/")
-
-		pathParts := strings.Split(parts, "/")
-		if len(pathParts) >= 2 {
-			owner = pathParts[0]
-			repo = strings.TrimSuffix(pathParts[1], ".git")
-		} else {
-			return nil, fmt.Errorf("invalid GitHub HTTPS URL: %s", urlStr)
+		switch u.Scheme {
+		case "https", "http", "ssh", "git":
+		default:
+			return nil, fmt.Errorf("unsupported repository URL scheme %q: %s", u.Scheme, urlStr)
 		}
-	} else if strings.HasPrefix(urlStr, "git@github.com:") {
-		// Handle SSH URL: git@github.com:owner/repo[.git]
-		parts := strings.TrimPrefix(urlStr, "git@github.com:")
-		pathParts := strings.Split(parts, "/")
-		if len(pathParts) >= 2 {
-			owner = pathParts[0]
-			repo = strings.TrimSuffix(pathParts[1], ".git")
-		} else {
-			return nil, fmt.Errorf("invalid GitHub SSH URL: %s", urlStr)
+		if u.Hostname() == "" {
+			return nil, fmt.Errorf("repository URL has no host: %s", urlStr)
 		}
-	} else {
-		return nil, fmt.Errorf("unsupported repository URL format: %s", urlStr)
+		return &remoteURL{
+			host:     strings.ToLower(u.Hostname()),
+			path:     strings.Trim(u.Path, "/"),
+			scheme:   u.Scheme,
+			hostPort: strings.ToLower(u.Host),
+			raw:      urlStr,
+			user:     u.User.Username(),
+		}, nil
 	}
 
+	// scp-like SSH: [user@]host:path
+	if i := strings.Index(urlStr, ":"); i > 0 && !strings.Contains(urlStr[:i], "/") {
+		host, user := urlStr[:i], ""
+		if at := strings.LastIndex(host, "@"); at >= 0 {
+			host, user = host[at+1:], host[:at]
+		}
+		if host == "" {
+			return nil, fmt.Errorf("repository URL has no host: %s", urlStr)
+		}
+		return &remoteURL{
+			host:   strings.ToLower(host),
+			path:   strings.Trim(urlStr[i+1:], "/"),
+			scheme: "ssh",
+			raw:    urlStr,
+			user:   user,
+		}, nil
+	}
+
+	// Shorthand: owner/repo on GitHub, or host/group/repo elsewhere
+	parts := strings.Split(urlStr, "/")
+	if len(parts) == 2 && parts[0] != "" && parts[1] != "" {
+		return &remoteURL{host: GitHubHost, path: urlStr, scheme: "https", hostPort: GitHubHost}, nil
+	}
+	if len(parts) >= 3 && isHostname(parts[0]) {
+		host := strings.ToLower(parts[0])
+		return &remoteURL{
+			host:     host,
+			path:     strings.Trim(strings.Join(parts[1:], "/"), "/"),
+			scheme:   "https",
+			hostPort: host,
+		}, nil
+	}
+	return nil, fmt.Errorf("invalid repository format: expected 'owner/repo' or a git URL, got '%s'", urlStr)
+}
+
+// parseGitHubPath builds a GitHub source from "owner/repo[.git][/...]".
+func parseGitHubPath(urlStr, path string) (*models.Source, error) {
+	pathParts := strings.Split(path, "/")
+	if len(pathParts) < 2 {
+		return nil, fmt.Errorf("invalid GitHub URL: %s", urlStr)
+	}
+	owner := pathParts[0]
+	repo := strings.TrimSuffix(pathParts[1], ".git")
+
 	// Validate parsed components
 	if owner == "" || repo == "" {
 		return nil, fmt.Errorf("could not parse owner and repo from URL: %s", urlStr)
@@ -71,15 +142,61 @@ func ParseRepositoryURL(urlStr string) (*models.Source, error) {
 		return nil, fmt.Errorf("invalid repo name: %s (must be 1-100 alphanumeric chars, hyphens, underscores, or dots)", repo)
 	}
 
-	// Create Source struct with sensible defaults for user-added sources
+	return newUserSource(owner, repo, GitHubHost, SourceWebURL(owner, repo), DefaultCloneURL(owner, repo)), nil
+}
+
+// parseHostedPath builds a source for a repository on a host other than
+// GitHub. The last path element is the repo; everything before it is the
+// namespace (a user, group or nested group).
+func parseHostedPath(urlStr string, remote *remoteURL) (*models.Source, error) {
+	path := remote.path
+	// GitLab web URLs continue past the repo: group/repo/-/tree/main
+	if i := strings.Index(path, "/-/"); i >= 0 {
+		path = path[:i]
+	}
+	path = strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+
+	segments := strings.Split(path, "/")
+	if len(segments) < 2 {
+		return nil, fmt.Errorf("could not parse namespace and repo from URL: %s (expected host/namespace/repo)", urlStr)
+	}
+	for _, seg := range segments {
+		if !isValidPathSegment(seg) {
+			return nil, fmt.Errorf("invalid repository path segment %q in %s", seg, urlStr)
+		}
+	}
+
+	namespace := strings.Join(segments[:len(segments)-1], "/")
+	repo := segments[len(segments)-1]
+	owner := remote.host + "/" + namespace
+
+	webURL := SourceWebURL(owner, repo)
+	var cloneURL string
+	switch remote.scheme {
+	case "https", "http":
+		// Rebuild rather than reuse the input so credentials in the URL are
+		// never stored
+		base := fmt.Sprintf("%s://%s/%s/%s", remote.scheme, remote.hostPort, namespace, repo)
+		webURL = base
+		cloneURL = base + ".git"
+	default:
+		cloneURL = remote.raw
+	}
+
+	return newUserSource(owner, repo, remote.host, webURL, cloneURL), nil
+}
+
+// newUserSource returns a Source with sensible defaults for user-added sources.
+func newUserSource(owner, repo, host, webURL, cloneURL string) *models.Source {
 	sourceID := owner + "/" + repo
-	source := &models.Source{
+	return &models.Source{
 		ID:       sourceID,
 		Owner:    owner,
 		Repo:     repo,
 		FullName: sourceID,
-		URL:      fmt.Sprintf("https://github.com/%s/%s", owner, repo),
-		CloneURL: fmt.Sprintf("https://github.com/%s/%s.git", owner, repo),
+		Host:     host,
+		URL:      webURL,
+		CloneURL: cloneURL,
 
 		// Default metadata for user-added sources
 		Priority:      5, // Medium priority
@@ -87,10 +204,194 @@ func ParseRepositoryURL(urlStr string) (*models.Source, error) {
 		IsCurated:     false,
 		DefaultBranch: "main",
 	}
+}
 
+// IsLocalPath reports whether s names a local directory rather than a
+// remote repository: a file:// URL or a path starting with ".", "/" or "~".
+func IsLocalPath(s string) bool {
+	return strings.HasPrefix(s, "file://") ||
+		s == "." || s == ".." || s == "~" ||
+		strings.HasPrefix(s, "./") || strings.HasPrefix(s, "../") ||
+		strings.HasPrefix(s, "/") || strings.HasPrefix(s, "~/")
+}
+
+// ParseLocalSource returns a source for a local directory, which is scanned
+// in place rather than cloned. Its owner is "local/<hash of the path>" so
+// directories with the same name never collide, and its CloneURL is the
+// directory's file:// URL.
+func ParseLocalSource(pathStr string) (*models.Source, error) {
+	dir := strings.TrimPrefix(strings.TrimSpace(pathStr), "file://")
+	if dir == "~" || strings.HasPrefix(dir, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, fmt.Errorf("get home directory: %w", err)
+		}
+		dir = filepath.Join(home, strings.TrimPrefix(dir, "~"))
+	}
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("resolve %s: %w", pathStr, err)
+	}
+	info, err := os.Stat(abs)
+	if err != nil {
+		return nil, fmt.Errorf("local source %s: %w", pathStr, err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("local source %s is not a directory", pathStr)
+	}
+
+	sum := sha256.Sum256([]byte(abs))
+	owner := models.SourceHostLocal + "/" + hex.EncodeToString(sum[:4])
+	repo := localRepoName(filepath.Base(abs))
+	fileURL := "file://" + filepath.ToSlash(abs)
+
+	source := newUserSource(owner, repo, models.SourceHostLocal, fileURL, fileURL)
+	source.DefaultBranch = ""
 	return source, nil
 }
 
+// localRepoName turns a directory name into a valid repo name.
+func localRepoName(name string) string {
+	var b strings.Builder
+	for _, ch := range name {
+		if isAlphanumeric(ch) || ch == '-' || ch == '_' || ch == '.' {
+			b.WriteRune(ch)
+		} else {
+			b.WriteRune('-')
+		}
+	}
+	if !isValidPathSegment(b.String()) {
+		return "local"
+	}
+	return b.String()
+}
+
+// IsLocalOwner reports whether owner belongs to a local directory source.
+func IsLocalOwner(owner string) bool {
+	host, _ := SplitOwner(owner)
+	return host == models.SourceHostLocal
+}
+
+// SplitOwner splits a source owner into its git host and namespace. GitHub
+// owners are plain account names, which can't contain slashes; owners on
+// other hosts start with the host ("gitlab.example.com/team" →
+// "gitlab.example.com", "team").
+func SplitOwner(owner string) (host, namespace string) {
+	if i := strings.Index(owner, "/"); i > 0 {
+		return owner[:i], owner[i+1:]
+	}
+	return GitHubHost, owner
+}
+
+// IsGitHubOwner reports whether owner refers to a GitHub account.
+func IsGitHubOwner(owner string) bool {
+	host, _ := SplitOwner(owner)
+	return host == GitHubHost
+}
+
+// SourceWebURL returns the https URL of a repository's web page.
+func SourceWebURL(owner, repo string) string {
+	host, namespace := SplitOwner(owner)
+	return fmt.Sprintf("https://%s/%s/%s", host, namespace, repo)
+}
+
+// NormalizeSkillPath cleans a subdirectory to restrict scanning to, as given
+// to `skulto add --path`: "./skills/" becomes "skills", and "" or "." means
+// the whole repository. Paths leaving the repository are rejected.
+func NormalizeSkillPath(p string) (string, error) {
+	p = strings.TrimSpace(strings.ReplaceAll(p, "\\", "/"))
+	if p == "" {
+		return "", nil
+	}
+	if strings.HasPrefix(p, "/") {
+		return "", fmt.Errorf("path %q must be relative to the repository root", p)
+	}
+	p = path.Clean(p)
+	if p == "." {
+		return "", nil
+	}
+	if p == ".." || strings.HasPrefix(p, "../") {
+		return "", fmt.Errorf("path %q is outside the repository", p)
+	}
+	return p, nil
+}
+
+// DefaultCloneURL returns the https clone URL for a repository, used when no
+// clone URL has been recorded for it.
+func DefaultCloneURL(owner, repo string) string {
+	return SourceWebURL(owner, repo) + ".git"
+}
+
+// BlobURL returns a web link to a file at a revision. Link formats differ
+// between forges; hosts that can't be identified get the repository page.
+func BlobURL(owner, repo, ref, path string) string {
+	host, _ := SplitOwner(owner)
+	base := SourceWebURL(owner, repo)
+	switch {
+	case host == GitHubHost:
+		return fmt.Sprintf("%s/blob/%s/%s", base, ref, path)
+	case host == "bitbucket.org":
+		return fmt.Sprintf("%s/src/%s/%s", base, ref, path)
+	case strings.Contains(host, "gitlab"):
+		return fmt.Sprintf("%s/-/blob/%s/%s", base, ref, path)
+	case host == "codeberg.org" || strings.Contains(host, "gitea") || strings.Contains(host, "forgejo"):
+		return fmt.Sprintf("%s/src/commit/%s/%s", base, ref, path)
+	default:
+		return base
+	}
+}
+
+// CommitURL returns a web link to a commit, or "" on hosts whose link
+// format can't be identified.
+func CommitURL(owner, repo, sha string) string {
+	host, _ := SplitOwner(owner)
+	base := SourceWebURL(owner, repo)
+	switch {
+	case host == GitHubHost:
+		return fmt.Sprintf("%s/commit/%s", base, sha)
+	case host == "bitbucket.org":
+		return fmt.Sprintf("%s/commits/%s", base, sha)
+	case strings.Contains(host, "gitlab"):
+		return fmt.Sprintf("%s/-/commit/%s", base, sha)
+	case host == "codeberg.org" || strings.Contains(host, "gitea") || strings.Contains(host, "forgejo"):
+		return fmt.Sprintf("%s/commit/%s", base, sha)
+	default:
+		return ""
+	}
+}
+
+// isHostname reports whether the first element of a shorthand path is a git
+// host rather than a GitHub account name. Account names can't contain dots,
+// so any dotted element is a host.
+func isHostname(s string) bool {
+	if s == "localhost" {
+		return true
+	}
+	if !strings.Contains(s, ".") {
+		return false
+	}
+	for _, ch := range s {
+		if !isAlphanumeric(ch) && ch != '.' && ch != '-' {
+			return false
+		}
+	}
+	return true
+}
+
+// isValidPathSegment validates one element of a repository path on a
+// non-GitHub host: alphanumerics, hyphens, underscores and dots.
+func isValidPathSegment(seg string) bool {
+	if seg == "" || seg == "." || seg == ".." || len(seg) > 255 {
+		return false
+	}
+	for _, ch := range seg {
+		if !isAlphanumeric(ch) && ch != '-' && ch != '_' && ch != '.' {
+			return false
+		}
+	}
+	return true
+}
+
 // isValidGitHubUsername validates a GitHub username.
 // GitHub usernames must:
 // - Start and end with alphanumeric character
@@ -0,0 +1,114 @@
+package scraper
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Local directory sources are read from the working tree rather than from a
+// commit, so uncommitted edits show up on the next scan. These helpers mirror
+// the git tree readers in repository.go.
+
+// resolveWorkingTreePath joins a slash-separated repository path onto root,
+// refusing paths that escape it.
+func resolveWorkingTreePath(root, relativePath string) (string, error) {
+	clean := filepath.Clean(filepath.FromSlash(strings.TrimPrefix(relativePath, "/")))
+	if clean == ".." || strings.HasPrefix(clean, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("path %q is outside the directory", relativePath)
+	}
+	return filepath.Join(root, clean), nil
+}
+
+// listWorkingTreeSkillFiles walks root for skill files, skipping .git and
+// other hidden directories. If skillPath is set, only that path is searched.
+func listWorkingTreeSkillFiles(root, skillPath string) ([]string, error) {
+	var skillFiles []string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != root && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return nil
+		}
+		name := filepath.ToSlash(rel)
+		if !IsSkillFilePath(name) {
+			return nil
+		}
+		if skillPath != "" && !strings.HasPrefix(name, skillPath+"/") && name != skillPath {
+			return nil
+		}
+
+		skillFiles = append(skillFiles, name)
+		return nil
+	})
+	return skillFiles, err
+}
+
+// readWorkingTreeFile reads a regular file under root.
+func readWorkingTreeFile(root, relativePath string) ([]byte, int64, error) {
+	path, err := resolveWorkingTreePath(root, relativePath)
+	if err != nil {
+		return nil, 0, err
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, 0, err
+	}
+	if !info.Mode().IsRegular() {
+		return nil, 0, fmt.Errorf("%s is not a file", relativePath)
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, 0, err
+	}
+	return content, info.Size(), nil
+}
+
+// listWorkingTreeDirectory lists a directory under root, leaving out .git.
+// Returns nil if the directory doesn't exist.
+func listWorkingTreeDirectory(root, dirPath string) ([]DirEntry, error) {
+	dir := root
+	if dirPath != "" && dirPath != "." {
+		var err error
+		if dir, err = resolveWorkingTreePath(root, dirPath); err != nil {
+			return nil, err
+		}
+	}
+
+	items, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var entries []DirEntry
+	for _, item := range items {
+		if item.Name() == ".git" {
+			continue
+		}
+		de := DirEntry{Name: item.Name(), IsDir: item.IsDir()}
+		if !de.IsDir {
+			if info, err := item.Info(); err == nil {
+				de.Size = info.Size()
+			}
+		}
+		entries = append(entries, de)
+	}
+	return entries, nil
+}
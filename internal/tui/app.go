@@ -489,12 +489,8 @@ func (m *Model) cleanupOldRepositories() {
 		return
 	}
 
-	cfg := scraper.ScraperConfig{
-		Token:        m.cfg.GitHub.Token,
-		DataDir:      m.cfg.BaseDir,
-		RepoCacheTTL: m.cfg.GitHub.RepoCacheTTL,
-		UseGitClone:  true,
-	}
+	cfg := scraper.ScraperConfigFromConfig(m.cfg)
+	cfg.UseGitClone = true
 	s := scraper.NewScraperWithConfig(cfg, m.db)
 	_ = s.CleanupOldRepositories() // Ignore errors - cleanup is best-effort
 }
@@ -885,6 +881,30 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						)
 					}
 					m.locationDialog.SetWidth(m.width)
+
+					// Show requires: and platforms so confirming the dialog installs
+					// them too; refuse up front if skulto is too old or a dependency
+					// is missing or cyclic.
+					compat := installer.CheckCompatibility(skill)
+					m.locationDialog.SetCompatibility(compat)
+					deps, err := m.installService.ResolveDependencies(skill.Slug)
+					if err == nil {
+						err = compat.VersionError(skill.Slug)
+					}
+					if err != nil {
+						m.showLocationDialog = false
+						m.pendingInstallSkill = nil
+						m.pendingInstallSource = nil
+						m.detailView.SetInstallingState(true)
+						return m, func() tea.Msg {
+							return views.SkillInstalledMsg{Success: false, Err: err}
+						}
+					}
+					required := make([]string, 0, len(deps))
+					for _, dep := range deps {
+						required = append(required, dep.Slug)
+					}
+					m.locationDialog.SetRequiredSkills(required)
 					return m, nil
 				}
 				// Uninstalling - use existing uninstall
@@ -1590,12 +1610,8 @@ func (m *Model) loadDataCmd() tea.Cmd {
 func (m *Model) syncCmd(githubToken string) tea.Cmd {
 	return func() tea.Msg {
 		// Create scraper with configuration
-		cfg := scraper.ScraperConfig{
-			Token:        githubToken,
-			DataDir:      m.cfg.BaseDir,
-			RepoCacheTTL: m.cfg.GitHub.RepoCacheTTL,
-			UseGitClone:  m.cfg.GitHub.UseGitClone,
-		}
+		cfg := scraper.ScraperConfigFromConfig(m.cfg)
+		cfg.Token = githubToken
 		s := scraper.NewScraperWithConfig(cfg, m.db)
 
 		// Set timeout for syncing
@@ -1864,12 +1880,9 @@ func (m *Model) fetchPrimarySkills() ([]models.Skill, error) {
 	repo := scraper.PrimarySkillsRepo
 
 	// Must use git clone mode so skill files are available for symlink-based installation
-	s := scraper.NewScraperWithConfig(scraper.ScraperConfig{
-		Token:        m.cfg.GitHub.Token,
-		DataDir:      m.cfg.BaseDir,
-		RepoCacheTTL: m.cfg.GitHub.RepoCacheTTL,
-		UseGitClone:  true,
-	}, m.db)
+	scraperCfg := scraper.ScraperConfigFromConfig(m.cfg)
+	scraperCfg.UseGitClone = true
+	s := scraper.NewScraperWithConfig(scraperCfg, m.db)
 
 	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
 	defer cancel()
@@ -1894,12 +1907,9 @@ func (m *Model) syncPrimarySkillsCmd() tea.Cmd {
 		repo := scraper.PrimarySkillsRepo
 
 		// Must use git clone mode so skill files are available for symlink-based installation
-		s := scraper.NewScraperWithConfig(scraper.ScraperConfig{
-			Token:        m.cfg.GitHub.Token,
-			DataDir:      m.cfg.BaseDir,
-			RepoCacheTTL: m.cfg.GitHub.RepoCacheTTL,
-			UseGitClone:  true,
-		}, m.db)
+		scraperCfg := scraper.ScraperConfigFromConfig(m.cfg)
+		scraperCfg.UseGitClone = true
+		s := scraper.NewScraperWithConfig(scraperCfg, m.db)
 
 		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
 		defer cancel()
@@ -1925,9 +1935,9 @@ func (m *Model) executeManageChangesCmd(skillSlug string, toInstall, toUninstall
 	return func() tea.Msg {
 		ctx := context.Background()
 
-		// Execute uninstalls first
+		// Execute uninstalls first, recording them for 'skulto undo'
 		if len(toUninstall) > 0 {
-			if err := m.installService.Uninstall(ctx, skillSlug, toUninstall); err != nil {
+			if _, err := m.installService.UninstallJournaled(ctx, skillSlug, toUninstall, false); err != nil {
 				return manageChangesCompleteMsg{err: fmt.Errorf("uninstall failed: %w", err)}
 			}
 		}
@@ -1971,7 +1981,12 @@ func (m *Model) installBatchSkillsCmd(skills []models.Skill, locations []install
 
 		for _, skill := range skills {
 			skillCopy := skill // avoid closure issue
-			if err := m.installer.InstallTo(ctx, &skillCopy, source, locations); err != nil {
+			compatible, _, err := installer.FilterCompatibleLocations(&skillCopy, locations)
+			if err != nil {
+				failed++
+				continue
+			}
+			if err := m.installer.InstallTo(ctx, &skillCopy, source, compatible); err != nil {
 				failed++
 				continue
 			}
@@ -2223,12 +2238,7 @@ func (m *Model) addSourceCmd(repoURL string) tea.Cmd {
 		}
 
 		// Create scraper with configuration
-		cfg := scraper.ScraperConfig{
-			Token:        m.cfg.GitHub.Token,
-			DataDir:      m.cfg.BaseDir,
-			RepoCacheTTL: m.cfg.GitHub.RepoCacheTTL,
-			UseGitClone:  m.cfg.GitHub.UseGitClone,
-		}
+		cfg := scraper.ScraperConfigFromConfig(m.cfg)
 		s := scraper.NewScraperWithConfig(cfg, m.db)
 
 		// Set timeout for syncing this repository
@@ -2272,10 +2282,20 @@ func (m *Model) installCmd(skill *models.Skill) tea.Cmd {
 				m.skillsInstalled++
 			}
 		} else {
-			// Uninstalling - use UninstallAll to handle both new and legacy installations
-			err = m.installer.UninstallAll(context.Background(), skill)
+			// Refuse if another installed skill requires this one
+			var dependents []string
+			dependents, err = m.installService.Dependents(context.Background(), skill.Slug, nil)
+			if err == nil && len(dependents) > 0 {
+				err = &installer.RequiredByError{Slug: skill.Slug, Dependents: dependents}
+			}
+			if err != nil {
+				return views.SkillInstalledMsg{Success: false, Err: err}
+			}
+			// Uninstalling from every location handles both new and legacy
+			// installations; dependents were checked above. The service
+			// tracks telemetry and records the removal for 'skulto undo'.
+			_, err = m.installService.UninstallJournaled(context.Background(), skill.Slug, nil, true)
 			if err == nil {
-				m.telemetry.TrackSkillUninstalled(skill.Title, skill.Category, skill.IsLocal)
 				m.skillsUninstalled++
 			}
 		}
@@ -2294,7 +2314,14 @@ func (m *Model) installToLocationsCmd(skill *models.Skill, source *models.Source
 		secScanner := security.NewScanner()
 		secScanner.ScanAndClassify(skill)
 		_ = m.db.UpdateSkillSecurity(skill)
-		err := m.installer.InstallTo(context.Background(), skill, source, locations)
+		locations, _, err := installer.FilterCompatibleLocations(skill, locations)
+		if err != nil {
+			return views.SkillInstalledMsg{Success: false, Err: err}
+		}
+		if _, err := m.installService.InstallDependenciesTo(context.Background(), skill.Slug, locations); err != nil {
+			return views.SkillInstalledMsg{Success: false, Err: err}
+		}
+		err = m.installer.InstallTo(context.Background(), skill, source, locations)
 		return views.SkillInstalledMsg{
 			Success: err == nil,
 			Err:     err,
@@ -2385,8 +2412,16 @@ func (m *Model) installLocalSkillFromDetailCmd(skill *models.Skill, locations []
 		secScanner.ScanAndClassify(skill)
 		_ = m.db.UpdateSkillSecurity(skill)
 
+		locations, _, err := installer.FilterCompatibleLocations(skill, locations)
+		if err != nil {
+			return views.SkillInstalledMsg{Success: false, Err: err}
+		}
+		if _, err := m.installService.InstallDependenciesTo(context.Background(), skill.Slug, locations); err != nil {
+			return views.SkillInstalledMsg{Success: false, Err: err}
+		}
+
 		// Install using the local skill method
-		err := m.installer.InstallLocalSkillTo(context.Background(), skill, sourcePath, locations)
+		err = m.installer.InstallLocalSkillTo(context.Background(), skill, sourcePath, locations)
 		if err != nil {
 			return views.SkillInstalledMsg{
 				Success: false,
@@ -2449,18 +2484,43 @@ func (m *Model) saveManifestCmd() tea.Cmd {
 			}
 
 			sourceName := ""
+			localOnly := skill.SourceID == nil || skill.Source == nil
 			if skill.Source != nil {
 				sourceName = skill.Source.FullName
+			} else if localOnly && skill.FilePath == manifest.VendorPath(cwd, skill.Slug) {
+				// Already vendored into the project by `skulto save --vendor`
+				sourceName = manifest.VendorSource(skill.Slug)
+				localOnly = false
 			}
 			entries = append(entries, manifest.SkillEntry{
 				Slug:       skill.Slug,
 				SourceName: sourceName,
-				LocalOnly:  skill.SourceID == nil || skill.Source == nil,
+				LocalOnly:  localOnly,
 			})
 		}
 
 		mf, _ := manifest.BuildFromSkills(entries)
 
+		// Keep ignored skills and per-skill settings from an existing manifest
+		existing, err := manifest.Read(cwd)
+		if err == nil && existing != nil {
+			mf.Ignored = existing.Ignored
+			mf.Workspace = existing.Workspace
+			mf.Extends = existing.Extends
+			for slug, source := range mf.Skills {
+				if existing.Skills[slug] == source {
+					if opts := existing.SkillOptions(slug); !opts.IsZero() {
+						mf.Options[slug] = opts
+					}
+				}
+			}
+		}
+
+		// Skills inherited from a workspace root stay in the root manifest
+		if _, root, err := manifest.FindWorkspaceRoot(cwd); err == nil && root != nil {
+			mf.DropInherited(root, existing)
+		}
+
 		// Write manifest
 		if err := manifest.Write(cwd, mf); err != nil {
 			return manifestSavedMsg{err: fmt.Errorf("failed to write manifest: %w", err)}
@@ -2,6 +2,7 @@ package components
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/asteroid-belt/skulto/internal/detect"
 	"github.com/asteroid-belt/skulto/internal/installer"
@@ -47,6 +48,8 @@ type InstallLocationDialog struct {
 	confirmed         bool
 	platforms         []installer.Platform
 	rememberLocations bool
+	requiredSkills    []string // Skills from requires: that install alongside
+	compat            *installer.Compatibility
 
 	// Collapsible groups
 	preferredCount int  // options[0:preferredCount] = group 1 (preferred)
@@ -385,6 +388,28 @@ func (d *InstallLocationDialog) SetRememberLocations(enabled bool) {
 	d.rememberLocations = enabled
 }
 
+// SetRequiredSkills lists skills that will be installed alongside the selected
+// skill because it declares them in requires:.
+func (d *InstallLocationDialog) SetRequiredSkills(slugs []string) {
+	d.requiredSkills = slugs
+}
+
+// SetCompatibility marks platforms the skill does not declare in its
+// platforms frontmatter. Unsupported selections are skipped on install.
+func (d *InstallLocationDialog) SetCompatibility(compat installer.Compatibility) {
+	d.compat = &compat
+}
+
+// isUnsupported reports whether the skill excludes the given platform.
+func (d *InstallLocationDialog) isUnsupported(p installer.Platform) bool {
+	return d.compat != nil && !d.compat.Supports(p)
+}
+
+// RequiredSkills returns the skills shown as installing alongside.
+func (d *InstallLocationDialog) RequiredSkills() []string {
+	return d.requiredSkills
+}
+
 // Reset clears the dialog state for reuse.
 func (d *InstallLocationDialog) Reset() {
 	d.currentIndex = 0
@@ -525,6 +550,9 @@ func (d *InstallLocationDialog) View() string {
 
 			line := indicator + checkbox + " " + nameStyle.Render(opt.DisplayName)
 			line += "    " + descStyle.Render("("+opt.Description+")")
+			if d.isUnsupported(opt.Location.Platform) {
+				line += " " + lipgloss.NewStyle().Foreground(theme.Current.Error).Render("⚠ unsupported")
+			}
 
 			optStyle := lipgloss.NewStyle().
 				Width(contentWidth).
@@ -628,7 +656,22 @@ func (d *InstallLocationDialog) View() string {
 		Padding(1, 2).
 		Width(dialogWidth)
 
-	parts := []string{title, subtitle, ""}
+	parts := []string{title, subtitle}
+	if len(d.requiredSkills) > 0 {
+		requiresStyle := lipgloss.NewStyle().
+			Foreground(goldColor).
+			Width(contentWidth).
+			Align(lipgloss.Center)
+		parts = append(parts, requiresStyle.Render("Also installs required skills: "+strings.Join(d.requiredSkills, ", ")))
+	}
+	if d.compat != nil && len(d.compat.Platforms)+len(d.compat.Unknown) > 0 {
+		compatStyle := lipgloss.NewStyle().
+			Foreground(mutedColor).
+			Width(contentWidth).
+			Align(lipgloss.Center)
+		parts = append(parts, compatStyle.Render("Supports: "+strings.Join(d.compat.PlatformNames(), ", ")))
+	}
+	parts = append(parts, "")
 	if scrollUp != "" {
 		parts = append(parts, scrollUp)
 	}
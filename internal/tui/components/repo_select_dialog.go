@@ -318,7 +318,7 @@ func (d *RepoSelectDialog) View() string {
 		Align(lipgloss.Center).
 		MarginTop(1).
 		Bold(true)
-	warning := warningStyle.Render("! This action cannot be undone")
+	warning := warningStyle.Render("! Removes the source and its skills; 'skulto undo' reverses this")
 
 	// Main dialog container
 	dialogStyle := lipgloss.NewStyle().
@@ -202,7 +202,7 @@ func (asv *AddSourceView) View() string {
 	labelStyle := lipgloss.NewStyle().
 		Foreground(theme.Current.Text).
 		MarginBottom(1)
-	label := labelStyle.Render("Repository URL (owner/repo):")
+	label := labelStyle.Render("Repository URL (owner/repo or git URL):")
 
 	// Input field
 	inputStyle := lipgloss.NewStyle().
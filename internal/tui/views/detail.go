@@ -11,6 +11,7 @@ import (
 	"github.com/asteroid-belt/skulto/internal/favorites"
 	"github.com/asteroid-belt/skulto/internal/log"
 	"github.com/asteroid-belt/skulto/internal/models"
+	"github.com/asteroid-belt/skulto/internal/scraper"
 	"github.com/asteroid-belt/skulto/internal/telemetry"
 	"github.com/asteroid-belt/skulto/internal/tui/theme"
 	"github.com/atotto/clipboard"
@@ -22,8 +23,9 @@ import (
 // SkillLoadedMsg is sent when async skill loading completes.
 // This message is handled by app.go to update the detail view.
 type SkillLoadedMsg struct {
-	Skill *models.Skill
-	Err   error
+	Skill      *models.Skill
+	LastChange *scraper.Commit // Newest commit that changed the skill, if its source keeps history
+	Err        error
 }
 
 // SkillInstalledMsg is sent when async skill installation completes.
@@ -50,10 +52,11 @@ type DetailView struct {
 	telemetry telemetry.Client
 	favorites *favorites.Store
 
-	skill     *models.Skill
-	skillID   string
-	loading   bool
-	loadError error
+	skill      *models.Skill
+	skillID    string
+	loading    bool
+	loadError  error
+	lastChange *scraper.Commit
 
 	// Favorite state (cached)
 	isFavorite bool
@@ -123,6 +126,7 @@ func (dv *DetailView) SetSkill(skillID string) tea.Cmd {
 	dv.loadError = nil
 	dv.scrollOffset = 0
 	dv.skill = nil
+	dv.lastChange = nil
 	dv.renderedContent = nil
 	dv.renderedHeader = ""
 
@@ -135,7 +139,13 @@ func (dv *DetailView) SetSkill(skillID string) tea.Cmd {
 func (dv *DetailView) loadSkillCmd(skillID string) tea.Cmd {
 	return func() tea.Msg {
 		skill, err := dv.db.GetSkill(skillID)
-		return SkillLoadedMsg{Skill: skill, Err: err}
+		msg := SkillLoadedMsg{Skill: skill, Err: err}
+		if skill != nil && skill.Source != nil && dv.cfg != nil {
+			// Sources without history simply show no change row
+			repoManager := scraper.NewRepositoryManagerFromConfig(dv.cfg)
+			msg.LastChange, _ = repoManager.LastChange(skill.Source, skill.FilePath)
+		}
+		return msg
 	}
 }
 
@@ -155,6 +165,7 @@ func (dv *DetailView) HandleSkillLoaded(msg SkillLoadedMsg) {
 	}
 
 	dv.skill = msg.Skill
+	dv.lastChange = msg.LastChange
 
 	// Check if this skill has any installations (source of truth for "installed")
 	dv.hasInstallations, _ = dv.db.HasInstallations(msg.Skill.ID)
@@ -633,8 +644,10 @@ func (dv *DetailView) renderRemoteDetails() string {
 			if len(commitSHA) > 7 {
 				commitSHA = commitSHA[:7]
 			}
-			commitURL = fmt.Sprintf("https://github.com/%s/%s/commit/%s",
-				dv.skill.Source.Owner, dv.skill.Source.Repo, dv.skill.Source.LastCommitSHA)
+			commitURL = scraper.CommitURL(dv.skill.Source.Owner, dv.skill.Source.Repo, dv.skill.Source.LastCommitSHA)
+			if commitURL == "" {
+				commitURL = dv.skill.Source.URL
+			}
 		}
 		if dv.skill.Source.LicenseType != "" {
 			licenseType = dv.skill.Source.LicenseType
@@ -667,8 +680,13 @@ func (dv *DetailView) renderRemoteDetails() string {
 		result = append(result, dv.renderMetadataRow(fmt.Sprintf("⚖️  License: %s | %s", licenseType, licenseURL)))
 	}
 
+	result = append(result, row5)
+	if c := dv.lastChange; c != nil {
+		result = append(result, dv.renderMetadataRow(fmt.Sprintf("🕘 Changed: %s by %s, %s | %s",
+			c.ShortSHA(), c.Author, formatTime(c.Date), c.Message)))
+	}
+
 	result = append(result,
-		row5,
 		dv.renderDivider(),
 		dv.renderTitle(),
 		dv.renderDescription(),
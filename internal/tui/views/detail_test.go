@@ -4,10 +4,12 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/asteroid-belt/skulto/internal/config"
 	"github.com/asteroid-belt/skulto/internal/db"
 	"github.com/asteroid-belt/skulto/internal/models"
+	"github.com/asteroid-belt/skulto/internal/scraper"
 	"github.com/asteroid-belt/skulto/internal/telemetry"
 )
 
@@ -329,6 +331,39 @@ func TestDetailView_RemoteSkillNoLocalIndicator(t *testing.T) {
 	}
 }
 
+// TestDetailView_ShowsLastChange verifies that the newest commit touching
+// the skill is shown when its source keeps history.
+func TestDetailView_ShowsLastChange(t *testing.T) {
+	database := setupTestDB(t)
+	defer func() { _ = database.Close() }()
+
+	view := NewDetailView(database, &config.Config{}, nil)
+	view.Init(telemetry.New(nil))
+	view.SetSize(120, 40)
+
+	msg := view.SetSkill("test-skill-id")().(SkillLoadedMsg)
+	msg.LastChange = &scraper.Commit{
+		SHA:     "0123456789abcdef",
+		Author:  "Ada",
+		Date:    time.Now().Add(-2 * time.Hour),
+		Message: "Tighten the review checklist",
+	}
+	view.HandleSkillLoaded(msg)
+
+	output := view.View()
+	for _, want := range []string{"0123456", "Ada", "2 hours ago", "Tighten the review checklist"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Detail view should show %q for the last change\nGot: %s", want, output)
+		}
+	}
+
+	// Without history there is no change row
+	view.HandleSkillLoaded(view.SetSkill("test-skill-id")().(SkillLoadedMsg))
+	if strings.Contains(view.View(), "Changed:") {
+		t.Error("Detail view should not show a change row without history")
+	}
+}
+
 // setupLoadedDetailView creates a DetailView with a skill containing the given
 // number of content lines, fully loaded and ready for scroll testing.
 func setupLoadedDetailView(t *testing.T, lines int) *DetailView {
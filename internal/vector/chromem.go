@@ -11,6 +11,9 @@ import (
 	"github.com/philippgille/chromem-go"
 )
 
+// skillsCollection is the chromem collection holding skill embeddings.
+const skillsCollection = "skills"
+
 // ChromemStore implements VectorStore using chromem-go.
 // This is the default implementation - zero external dependencies.
 type ChromemStore struct {
@@ -46,7 +49,7 @@ func NewChromemStore(cfg Config) (*ChromemStore, error) {
 	// Create or get collection with OpenAI embedding function
 	embeddingFunc := chromem.NewEmbeddingFuncOpenAI(cfg.OpenAIKey, chromem.EmbeddingModelOpenAI3Small)
 
-	collection, err := db.GetOrCreateCollection("skills", nil, embeddingFunc)
+	collection, err := db.GetOrCreateCollection(skillsCollection, nil, embeddingFunc)
 	if err != nil {
 		return nil, fmt.Errorf("create collection: %w", err)
 	}
@@ -163,3 +166,59 @@ func (s *ChromemStore) Close() error {
 	// chromem-go persists automatically, no explicit close needed
 	return nil
 }
+
+// Compact deletes the embeddings stored in dataDir for skills not in
+// current, such as skills removed since they were embedded, and returns how
+// many it deleted. It reads the store directly, so no API key is needed.
+func Compact(ctx context.Context, dataDir string, current []string) (int, error) {
+	if _, err := os.Stat(dataDir); os.IsNotExist(err) {
+		return 0, nil
+	}
+	db, err := chromem.NewPersistentDB(dataDir, false)
+	if err != nil {
+		return 0, fmt.Errorf("open chromem db: %w", err)
+	}
+	collection := db.GetCollection(skillsCollection, nil)
+	if collection == nil || collection.Count() == 0 {
+		return 0, nil
+	}
+
+	// chromem can't list documents, but a query as wide as the collection
+	// returns them all. Any current skill's embedding will do as the query
+	keep := make(map[string]bool, len(current))
+	var probe []float32
+	for _, id := range current {
+		keep[id] = true
+		if probe == nil {
+			if doc, err := collection.GetByID(ctx, id); err == nil {
+				probe = doc.Embedding
+			}
+		}
+	}
+	if probe == nil {
+		// Nothing embedded is still current
+		removed := collection.Count()
+		if err := db.DeleteCollection(skillsCollection); err != nil {
+			return 0, fmt.Errorf("delete collection: %w", err)
+		}
+		return removed, nil
+	}
+
+	results, err := collection.QueryEmbedding(ctx, probe, collection.Count(), nil, nil)
+	if err != nil {
+		return 0, fmt.Errorf("list embeddings: %w", err)
+	}
+	var stale []string
+	for _, r := range results {
+		if !keep[r.ID] {
+			stale = append(stale, r.ID)
+		}
+	}
+	if len(stale) == 0 {
+		return 0, nil
+	}
+	if err := collection.Delete(ctx, nil, nil, stale...); err != nil {
+		return 0, fmt.Errorf("delete embeddings: %w", err)
+	}
+	return len(stale), nil
+}
@@ -0,0 +1,74 @@
+package vector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/philippgille/chromem-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// seedStore writes embeddings for ids into a persistent store at dir.
+func seedStore(t *testing.T, dir string, ids ...string) {
+	t.Helper()
+	db, err := chromem.NewPersistentDB(dir, false)
+	require.NoError(t, err)
+	collection, err := db.GetOrCreateCollection(skillsCollection, nil, nil)
+	require.NoError(t, err)
+	for i, id := range ids {
+		embedding := make([]float32, len(ids))
+		embedding[i] = 1
+		require.NoError(t, collection.AddDocument(context.Background(), chromem.Document{ID: id, Embedding: embedding, Content: id}))
+	}
+}
+
+// storedIDs reopens the store at dir and reports which of ids it holds.
+func storedIDs(t *testing.T, dir string, ids ...string) []string {
+	t.Helper()
+	db, err := chromem.NewPersistentDB(dir, false)
+	require.NoError(t, err)
+	collection := db.GetCollection(skillsCollection, nil)
+	if collection == nil {
+		return nil
+	}
+	var found []string
+	for _, id := range ids {
+		if _, err := collection.GetByID(context.Background(), id); err == nil {
+			found = append(found, id)
+		}
+	}
+	return found
+}
+
+func TestCompact(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	seedStore(t, dir, "a", "b", "c")
+
+	removed, err := Compact(ctx, dir, []string{"a", "c", "never-embedded"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, removed)
+	assert.Equal(t, []string{"a", "c"}, storedIDs(t, dir, "a", "b", "c"))
+
+	// Nothing to do the second time
+	removed, err = Compact(ctx, dir, []string{"a", "c"})
+	require.NoError(t, err)
+	assert.Zero(t, removed)
+}
+
+func TestCompact_NothingCurrent(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	seedStore(t, dir, "a", "b")
+
+	removed, err := Compact(ctx, dir, []string{"other"})
+	require.NoError(t, err)
+	assert.Equal(t, 2, removed)
+	assert.Empty(t, storedIDs(t, dir, "a", "b"))
+
+	// A missing store is not an error
+	removed, err = Compact(ctx, t.TempDir()+"/missing", nil)
+	require.NoError(t, err)
+	assert.Zero(t, removed)
+}